@@ -21,6 +21,11 @@ type Client interface {
 	PostRefund(ctx context.Context, tx string) error
 	GetTxFromOutpoint(ctx context.Context, outpoint string) (*wire.MsgTx, error)
 	GetTxFromTxID(ctx context.Context, txID string) (*wire.MsgTx, error)
+	GetTxsFromAddress(ctx context.Context, address string) ([]*wire.MsgTx, error)
 	GetRecommendedFees(ctx context.Context, speed Speed) (int64, error)
 	GetFeeFromTxId(ctx context.Context, txId string) (int64, error)
+	GetBlockHeight(ctx context.Context) (int64, error)
+	// SubscribeBlocks emits the chain tip height every time a new block is
+	// found. The channel is closed once the context is cancelled.
+	SubscribeBlocks(ctx context.Context) (<-chan int64, error)
 }
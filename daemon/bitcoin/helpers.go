@@ -19,6 +19,13 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
+// Estimated virtual sizes of the transactions spending a swap contract, with
+// one P2WSH input and a single output, used to quote fees before building them
+const (
+	ClaimTxVSize  = 150
+	RefundTxVSize = 140
+)
+
 // BuildTransactionWithFee builds a transaction with the given fee rate by first calculating the virtual size
 // and then building the final transaction with the correct fee amount.
 func BuildTransactionWithFee(satsPerVbyte int64, buildFn func(feeAmount int64, isFeeCalculationRun bool) (*psbt.Packet, error)) (*psbt.Packet, error) {
@@ -452,6 +459,50 @@ func GetOutputAddress(msgTx *wire.MsgTx, index int, network lightning.Network) (
 	return addresses[0], nil
 }
 
+// FindTxPayingToAddress returns the first transaction with an output paying
+// to the given address, or nil if there is none.
+func FindTxPayingToAddress(txs []*wire.MsgTx, address string, network lightning.Network) *wire.MsgTx {
+	for _, tx := range txs {
+		for i := range tx.TxOut {
+			outputAddress, err := GetOutputAddress(tx, i, network)
+			if err != nil {
+				continue
+			}
+			if outputAddress.String() == address {
+				return tx
+			}
+		}
+	}
+
+	return nil
+}
+
+// HasUnspentOutputToAddress reports whether any of the transactions has an
+// output paying to the given address that isn't spent by another one of them.
+func HasUnspentOutputToAddress(txs []*wire.MsgTx, address string, network lightning.Network) bool {
+	spent := make(map[wire.OutPoint]bool)
+	for _, tx := range txs {
+		for _, input := range tx.TxIn {
+			spent[input.PreviousOutPoint] = true
+		}
+	}
+
+	for _, tx := range txs {
+		hash := tx.TxHash()
+		for i := range tx.TxOut {
+			outputAddress, err := GetOutputAddress(tx, i, network)
+			if err != nil || outputAddress.String() != address {
+				continue
+			}
+			if !spent[*wire.NewOutPoint(&hash, uint32(i))] { //nolint:gosec
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 // ReverseSwapScript creates the reverse swap script for swap out transactions
 // This is equivalent to the reverseSwapScript function in server-backend
 func ReverseSwapScript(preimageHash, claimPublicKey, refundPublicKey []byte, timeoutBlockHeight int) ([]byte, error) {
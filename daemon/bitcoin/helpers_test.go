@@ -10,6 +10,7 @@ import (
 
 	"github.com/40acres/40swap/daemon/lightning"
 	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcd/btcutil/psbt"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/btcsuite/btcd/txscript"
@@ -521,3 +522,46 @@ func TestSignFinishExtractPSBT(t *testing.T) {
 		})
 	}
 }
+
+func TestHasUnspentOutputToAddress(t *testing.T) {
+	address := "bcrt1q76kh4zg0vfkt7yy8dz8tpfwqgcnm0pxd76az73d8wmqgln5640fsdy0mjx"
+	decoded, err := btcutil.DecodeAddress(address, lightning.ToChainCfgNetwork(lightning.Regtest))
+	require.NoError(t, err)
+	pkScript, err := txscript.PayToAddrScript(decoded)
+	require.NoError(t, err)
+
+	lockTx := wire.NewMsgTx(2)
+	lockTx.AddTxOut(wire.NewTxOut(10000, pkScript))
+	lockHash := lockTx.TxHash()
+
+	spendTx := wire.NewMsgTx(2)
+	spendTx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&lockHash, 0), nil, nil))
+
+	tests := []struct {
+		name string
+		txs  []*wire.MsgTx
+		want bool
+	}{
+		{
+			name: "no transactions",
+			txs:  nil,
+			want: false,
+		},
+		{
+			name: "unspent lock transaction",
+			txs:  []*wire.MsgTx{lockTx},
+			want: true,
+		},
+		{
+			name: "spent lock transaction",
+			txs:  []*wire.MsgTx{spendTx, lockTx},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, HasUnspentOutputToAddress(tt.txs, address, lightning.Regtest))
+		})
+	}
+}
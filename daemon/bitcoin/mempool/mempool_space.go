@@ -8,13 +8,21 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/40acres/40swap/daemon/bitcoin"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/btcsuite/btcd/wire"
 )
 
 const BaseURL = "https://mempool.space/api"
 
+// DefaultBlockPollInterval is how often the chain tip is polled when
+// subscribing to new blocks.
+const DefaultBlockPollInterval = 30 * time.Second
+
 var ErrUnexpectedStatus = fmt.Errorf("unexpected status code")
 
 type Option func(*Options)
@@ -25,14 +33,22 @@ func WithURL(url string) func(*Options) {
 	}
 }
 
+func WithBlockPollInterval(interval time.Duration) func(*Options) {
+	return func(s *Options) {
+		s.blockPollInterval = interval
+	}
+}
+
 type Options struct {
-	baseURL string
+	baseURL           string
+	blockPollInterval time.Duration
 }
 
 type MempoolSpace struct {
-	client    *http.Client
-	baseURL   string
-	authToken string
+	client            *http.Client
+	baseURL           string
+	authToken         string
+	blockPollInterval time.Duration
 }
 
 // New creates a new MempoolSpace client
@@ -42,13 +58,15 @@ func New(token string, options ...Option) *MempoolSpace {
 		authToken: token,
 	}
 	opts := Options{
-		baseURL: BaseURL,
+		baseURL:           BaseURL,
+		blockPollInterval: DefaultBlockPollInterval,
 	}
 	for _, option := range options {
 		option(&opts)
 	}
 
 	mempoolSpace.baseURL = opts.baseURL
+	mempoolSpace.blockPollInterval = opts.blockPollInterval
 
 	return &mempoolSpace
 }
@@ -102,6 +120,99 @@ func (m *MempoolSpace) GetTxFromTxID(ctx context.Context, txID string) (*wire.Ms
 	return tx, nil
 }
 
+// GetTxsFromAddress retrieves the transactions (mempool and most recent
+// confirmed ones) that involve the given address
+func (m *MempoolSpace) GetTxsFromAddress(ctx context.Context, address string) ([]*wire.MsgTx, error) {
+	req, err := m.makeRequest(ctx, "/address/"+address+"/txs", "GET", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("unexpected status code: %d: %w", resp.StatusCode, ErrUnexpectedStatus)
+	}
+
+	// The transactions are built from the response itself, as fetching each
+	// of them would take a request per transaction
+	var esploraTxs []esploraTx
+	if err := json.NewDecoder(resp.Body).Decode(&esploraTxs); err != nil {
+		return nil, fmt.Errorf("failed to decode address transactions: %w", err)
+	}
+
+	txs := make([]*wire.MsgTx, 0, len(esploraTxs))
+	for _, esploraTx := range esploraTxs {
+		tx, err := esploraTx.msgTx()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode transaction %s: %w", esploraTx.TxID, err)
+		}
+		txs = append(txs, tx)
+	}
+
+	return txs, nil
+}
+
+// esploraTx is a transaction as the esplora API of mempool.space returns it
+type esploraTx struct {
+	TxID     string `json:"txid"`
+	Version  int32  `json:"version"`
+	Locktime uint32 `json:"locktime"`
+	Vin      []struct {
+		TxID      string   `json:"txid"`
+		Vout      uint32   `json:"vout"`
+		ScriptSig string   `json:"scriptsig"`
+		Witness   []string `json:"witness"`
+		Sequence  uint32   `json:"sequence"`
+	} `json:"vin"`
+	Vout []struct {
+		ScriptPubKey string `json:"scriptpubkey"`
+		Value        int64  `json:"value"`
+	} `json:"vout"`
+}
+
+func (t *esploraTx) msgTx() (*wire.MsgTx, error) {
+	tx := wire.NewMsgTx(t.Version)
+	tx.LockTime = t.Locktime
+
+	for _, vin := range t.Vin {
+		hash, err := chainhash.NewHashFromStr(vin.TxID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid input txid: %w", err)
+		}
+		scriptSig, err := hex.DecodeString(vin.ScriptSig)
+		if err != nil {
+			return nil, fmt.Errorf("invalid input script: %w", err)
+		}
+		witness := make(wire.TxWitness, 0, len(vin.Witness))
+		for _, item := range vin.Witness {
+			decoded, err := hex.DecodeString(item)
+			if err != nil {
+				return nil, fmt.Errorf("invalid input witness: %w", err)
+			}
+			witness = append(witness, decoded)
+		}
+
+		input := wire.NewTxIn(wire.NewOutPoint(hash, vin.Vout), scriptSig, witness)
+		input.Sequence = vin.Sequence
+		tx.AddTxIn(input)
+	}
+
+	for _, vout := range t.Vout {
+		pkScript, err := hex.DecodeString(vout.ScriptPubKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid output script: %w", err)
+		}
+		tx.AddTxOut(wire.NewTxOut(vout.Value, pkScript))
+	}
+
+	return tx, nil
+}
+
 func (m *MempoolSpace) PostRefund(ctx context.Context, tx string) error {
 	req, err := m.makeRequest(ctx, "/tx/", "POST", &tx)
 	if err != nil {
@@ -182,6 +293,77 @@ func (m *MempoolSpace) GetFeeFromTxId(ctx context.Context, txId string) (int64,
 	return onchainFees, nil
 }
 
+// GetBlockHeight retrieves the height of the current chain tip
+func (m *MempoolSpace) GetBlockHeight(ctx context.Context) (int64, error) {
+	req, err := m.makeRequest(ctx, "/blocks/tip/height", "GET", nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return 0, fmt.Errorf("unexpected status code: %d: %w", resp.StatusCode, ErrUnexpectedStatus)
+	}
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+
+	height, err := strconv.ParseInt(strings.TrimSpace(string(bodyBytes)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse block height: %w", err)
+	}
+
+	return height, nil
+}
+
+// SubscribeBlocks polls the chain tip and emits its height whenever it changes.
+// The current height is emitted as soon as it is known.
+func (m *MempoolSpace) SubscribeBlocks(ctx context.Context) (<-chan int64, error) {
+	height, err := m.GetBlockHeight(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := make(chan int64, 1)
+	blocks <- height
+
+	go func() {
+		defer close(blocks)
+
+		ticker := time.NewTicker(m.blockPollInterval)
+		defer ticker.Stop()
+
+		last := height
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				height, err := m.GetBlockHeight(ctx)
+				if err != nil || height == last {
+					continue
+				}
+				last = height
+
+				select {
+				case blocks <- height:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return blocks, nil
+}
+
 func (m *MempoolSpace) makeRequest(ctx context.Context, path string, method string, body *string) (*http.Request, error) {
 	var req *http.Request
 	var err error
@@ -41,6 +41,21 @@ func (m *MockClient) EXPECT() *MockClientMockRecorder {
 	return m.recorder
 }
 
+// GetBlockHeight mocks base method.
+func (m *MockClient) GetBlockHeight(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockHeight", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockHeight indicates an expected call of GetBlockHeight.
+func (mr *MockClientMockRecorder) GetBlockHeight(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockHeight", reflect.TypeOf((*MockClient)(nil).GetBlockHeight), ctx)
+}
+
 // GetFeeFromTxId mocks base method.
 func (m *MockClient) GetFeeFromTxId(ctx context.Context, txId string) (int64, error) {
 	m.ctrl.T.Helper()
@@ -101,6 +116,21 @@ func (mr *MockClientMockRecorder) GetTxFromTxID(ctx, txID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTxFromTxID", reflect.TypeOf((*MockClient)(nil).GetTxFromTxID), ctx, txID)
 }
 
+// GetTxsFromAddress mocks base method.
+func (m *MockClient) GetTxsFromAddress(ctx context.Context, address string) ([]*wire.MsgTx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTxsFromAddress", ctx, address)
+	ret0, _ := ret[0].([]*wire.MsgTx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTxsFromAddress indicates an expected call of GetTxsFromAddress.
+func (mr *MockClientMockRecorder) GetTxsFromAddress(ctx, address any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTxsFromAddress", reflect.TypeOf((*MockClient)(nil).GetTxsFromAddress), ctx, address)
+}
+
 // PostRefund mocks base method.
 func (m *MockClient) PostRefund(ctx context.Context, tx string) error {
 	m.ctrl.T.Helper()
@@ -114,3 +144,18 @@ func (mr *MockClientMockRecorder) PostRefund(ctx, tx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostRefund", reflect.TypeOf((*MockClient)(nil).PostRefund), ctx, tx)
 }
+
+// SubscribeBlocks mocks base method.
+func (m *MockClient) SubscribeBlocks(ctx context.Context) (<-chan int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeBlocks", ctx)
+	ret0, _ := ret[0].(<-chan int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscribeBlocks indicates an expected call of SubscribeBlocks.
+func (mr *MockClientMockRecorder) SubscribeBlocks(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeBlocks", reflect.TypeOf((*MockClient)(nil).SubscribeBlocks), ctx)
+}
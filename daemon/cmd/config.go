@@ -0,0 +1,243 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/urfave/cli/v3"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultConfigPath is the config file read when none is given, it's fine for
+// it not to exist
+const defaultConfigPath = "./.40swapd/40swapd.yaml"
+
+const redacted = "********"
+
+// secretSettings are redacted when the configuration is dumped
+var secretSettings = map[string]bool{
+	"db-password":       true,
+	"lndconnect":        true,
+	"mempool-token":     true,
+	"backup-passphrase": true,
+	"webhook-secret":    true,
+}
+
+var configFlag = cli.StringFlag{
+	Name:  "config",
+	Usage: "Config file with the settings keyed by flag name, YAML or TOML by its extension",
+	Value: defaultConfigPath,
+	Sources: cli.NewValueSourceChain(
+		cli.EnvVar("40SWAPD_CONFIG")),
+}
+
+var configCommand = cli.Command{
+	Name:  "config",
+	Usage: "Inspect the configuration",
+	Commands: []*cli.Command{
+		{
+			Name:  "dump",
+			Usage: "Print the effective configuration, from flags, environment, config file and defaults, with the secrets redacted",
+			Flags: []cli.Flag{
+				&rpcMacaroon,
+				&rpcHost,
+			},
+			Action: func(ctx context.Context, cmd *cli.Command) error {
+				out, err := yaml.Marshal(effectiveConfig(cmd))
+				if err != nil {
+					return fmt.Errorf("could not encode configuration: %w", err)
+				}
+
+				fmt.Print(string(out))
+
+				return nil
+			},
+		},
+	},
+}
+
+// configSource looks a setting up in the config file
+type configSource struct {
+	path  string
+	key   string
+	value string
+}
+
+func (s *configSource) Lookup() (string, bool) { return s.value, true }
+func (s *configSource) String() string         { return fmt.Sprintf("config file %q", s.path) }
+func (s *configSource) GoString() string {
+	return fmt.Sprintf("&configSource{path:%q,key:%q}", s.path, s.key)
+}
+
+// configPath finds the config file before the flags are parsed: --config,
+// then 40SWAPD_CONFIG, then the default. explicit is false for the default.
+func configPath(args []string) (path string, explicit bool) {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			break
+		}
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !strings.HasPrefix(arg, "-") || name != configFlag.Name {
+			continue
+		}
+		if hasValue {
+			return value, true
+		}
+		if i+1 < len(args) {
+			return args[i+1], true
+		}
+	}
+
+	if path, ok := os.LookupEnv("40SWAPD_CONFIG"); ok {
+		return path, true
+	}
+
+	return defaultConfigPath, false
+}
+
+// loadConfig reads the config file into the flags of app and its commands, as
+// their last source, so flags and environment variables take precedence. A
+// missing default config file is fine.
+func loadConfig(app *cli.Command, path string, explicit bool) error {
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist) && !explicit:
+		return nil
+	case err != nil:
+		return fmt.Errorf("could not read config file: %w", err)
+	}
+
+	values := map[string]any{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".toml":
+		err = toml.Unmarshal(data, &values)
+	case ".yaml", ".yml", "":
+		err = yaml.Unmarshal(data, &values)
+	default:
+		return fmt.Errorf("config file %s must be .yaml, .yml or .toml", path)
+	}
+	if err != nil {
+		return fmt.Errorf("could not parse config file %s: %w", path, err)
+	}
+
+	flags := configFlags(app)
+	for key, value := range values {
+		flag, ok := flags[key]
+		if !ok {
+			return fmt.Errorf("config file %s: unknown setting %q", path, key)
+		}
+
+		str, err := configValue(value)
+		if err != nil {
+			return fmt.Errorf("config file %s: %s: %w", path, key, err)
+		}
+
+		flagSources(flag).Append(cli.NewValueSourceChain(&configSource{path: path, key: key, value: str}))
+	}
+
+	return nil
+}
+
+// configValue formats a setting like it would be given on the command line,
+// lists are comma separated
+func configValue(value any) (string, error) {
+	switch v := value.(type) {
+	case []any:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			str, err := configValue(item)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, str)
+		}
+
+		return strings.Join(items, ","), nil
+	case map[string]any:
+		return "", fmt.Errorf("nested settings aren't supported")
+	case nil:
+		return "", nil
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
+
+// configFlags are the settings of app and its commands by name. Flags of the
+// subcommands are only settings when they can also come from the environment,
+// the rest are arguments of a single invocation, like the amount of a swap.
+func configFlags(app *cli.Command) map[string]cli.Flag {
+	flags := map[string]cli.Flag{}
+	for _, flag := range app.Flags {
+		if flagSources(flag) != nil && flag != &configFlag && flag != cli.HelpFlag {
+			flags[flag.Names()[0]] = flag
+		}
+	}
+
+	var walk func(commands []*cli.Command)
+	walk = func(commands []*cli.Command) {
+		for _, command := range commands {
+			for _, flag := range command.Flags {
+				if sources := flagSources(flag); sources != nil && len(sources.EnvKeys()) > 0 {
+					flags[flag.Names()[0]] = flag
+				}
+			}
+			walk(command.Commands)
+		}
+	}
+	walk(app.Commands)
+
+	return flags
+}
+
+// flagSources is the chain the value of a flag is looked up in, nil for
+// flags of unknown types
+func flagSources(flag cli.Flag) *cli.ValueSourceChain {
+	switch f := flag.(type) {
+	case *cli.StringFlag:
+		return &f.Sources
+	case *cli.StringSliceFlag:
+		return &f.Sources
+	case *cli.BoolFlag:
+		return &f.Sources
+	case *cli.IntFlag:
+		return &f.Sources
+	case *cli.UintFlag:
+		return &f.Sources
+	case *cli.FloatFlag:
+		return &f.Sources
+	case *cli.DurationFlag:
+		return &f.Sources
+	default:
+		return nil
+	}
+}
+
+// effectiveConfig is the value of every setting available to cmd, with the
+// secrets redacted. It can be used as a config file.
+func effectiveConfig(cmd *cli.Command) map[string]any {
+	config := map[string]any{}
+	for name := range configFlags(cmd.Root()) {
+		value := cmd.Value(name)
+		switch v := value.(type) {
+		case nil:
+			// Not a setting of cmd
+			continue
+		case time.Duration:
+			value = v.String()
+		case string:
+			if secretSettings[name] && v != "" {
+				value = redacted
+			}
+		}
+		config[name] = value
+	}
+
+	return config
+}
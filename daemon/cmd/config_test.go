@@ -0,0 +1,389 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+)
+
+// newConfigTestApp builds an app with a few settings of each kind. Loading a
+// config file changes the flags, so every test gets its own.
+func newConfigTestApp(action cli.ActionFunc) *cli.Command {
+	return &cli.Command{
+		Name: "40swapd",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "db-host",
+				Value:   "localhost",
+				Sources: cli.NewValueSourceChain(cli.EnvVar("40SWAPD_DB_HOST")),
+			},
+			&cli.StringFlag{
+				Name:    "db-password",
+				Sources: cli.NewValueSourceChain(cli.EnvVar("40SWAPD_DB_PASSWORD")),
+			},
+			&cli.IntFlag{
+				Name:    "db-port",
+				Value:   5432,
+				Sources: cli.NewValueSourceChain(cli.EnvVar("40SWAPD_DB_PORT")),
+			},
+			&cli.StringSliceFlag{
+				Name:    "webhook-url",
+				Sources: cli.NewValueSourceChain(cli.EnvVar("40SWAPD_WEBHOOK_URLS")),
+			},
+			&cli.DurationFlag{
+				Name:    "swap-not-found-grace-period",
+				Value:   time.Hour,
+				Sources: cli.NewValueSourceChain(cli.EnvVar("40SWAPD_SWAP_NOT_FOUND_GRACE_PERIOD")),
+			},
+		},
+		Commands: []*cli.Command{
+			{
+				Name: "swap",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "rpc-host",
+						Value:   "localhost",
+						Sources: cli.NewValueSourceChain(cli.EnvVar("40SWAPD_RPC_HOST")),
+					},
+					// Not a setting, it can't come from the environment
+					&cli.UintFlag{Name: "amt"},
+				},
+				Action: action,
+			},
+		},
+	}
+}
+
+func writeConfigFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+	return path
+}
+
+func TestConfigPath(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         []string
+		env          string
+		wantPath     string
+		wantExplicit bool
+	}{
+		{
+			name:     "default",
+			args:     []string{"swap", "out"},
+			wantPath: defaultConfigPath,
+		},
+		{
+			name:         "flag with a separate value",
+			args:         []string{"--config", "a.yaml", "swap"},
+			wantPath:     "a.yaml",
+			wantExplicit: true,
+		},
+		{
+			name:         "flag with an inline value",
+			args:         []string{"--config=a.toml", "swap"},
+			wantPath:     "a.toml",
+			wantExplicit: true,
+		},
+		{
+			name:         "single dash flag",
+			args:         []string{"-config", "a.yaml"},
+			wantPath:     "a.yaml",
+			wantExplicit: true,
+		},
+		{
+			name:         "environment",
+			args:         []string{"swap"},
+			env:          "env.yaml",
+			wantPath:     "env.yaml",
+			wantExplicit: true,
+		},
+		{
+			name:         "flag over environment",
+			args:         []string{"--config", "a.yaml"},
+			env:          "env.yaml",
+			wantPath:     "a.yaml",
+			wantExplicit: true,
+		},
+		{
+			name:     "other flag with the same prefix",
+			args:     []string{"--config-dir", "dir"},
+			wantPath: defaultConfigPath,
+		},
+		{
+			name:     "value of another flag",
+			args:     []string{"--label", "config"},
+			wantPath: defaultConfigPath,
+		},
+		{
+			name:     "after the end of the flags",
+			args:     []string{"--", "--config", "a.yaml"},
+			wantPath: defaultConfigPath,
+		},
+		{
+			name:     "flag without a value",
+			args:     []string{"--config"},
+			wantPath: defaultConfigPath,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("40SWAPD_CONFIG", tt.env)
+			if tt.env == "" {
+				require.NoError(t, os.Unsetenv("40SWAPD_CONFIG"))
+			}
+
+			path, explicit := configPath(tt.args)
+			require.Equal(t, tt.wantPath, path)
+			require.Equal(t, tt.wantExplicit, explicit)
+		})
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	yamlConfig := `
+db-host: file-host
+db-port: 5433
+webhook-url:
+  - https://a.example.com
+  - https://b.example.com
+swap-not-found-grace-period: 2h
+rpc-host: file-rpc-host
+`
+	tomlConfig := `
+db-host = "toml-host"
+webhook-url = ["https://a.example.com"]
+`
+
+	tests := []struct {
+		name     string
+		file     string
+		content  string
+		explicit bool
+		args     []string
+		env      map[string]string
+		want     map[string]any
+		wantErr  string
+	}{
+		{
+			name:    "file",
+			file:    "40swapd.yaml",
+			content: yamlConfig,
+			want: map[string]any{
+				"db-host":                     "file-host",
+				"db-port":                     int64(5433),
+				"webhook-url":                 []string{"https://a.example.com", "https://b.example.com"},
+				"swap-not-found-grace-period": 2 * time.Hour,
+				"rpc-host":                    "file-rpc-host",
+			},
+		},
+		{
+			name:    "environment over file",
+			file:    "40swapd.yaml",
+			content: yamlConfig,
+			env:     map[string]string{"40SWAPD_DB_HOST": "env-host", "40SWAPD_RPC_HOST": "env-rpc-host"},
+			want: map[string]any{
+				"db-host":  "env-host",
+				"db-port":  int64(5433),
+				"rpc-host": "env-rpc-host",
+			},
+		},
+		{
+			name:    "flag over environment and file",
+			file:    "40swapd.yaml",
+			content: yamlConfig,
+			args:    []string{"--db-host", "flag-host", "swap", "--rpc-host", "flag-rpc-host"},
+			env:     map[string]string{"40SWAPD_DB_HOST": "env-host"},
+			want: map[string]any{
+				"db-host":  "flag-host",
+				"rpc-host": "flag-rpc-host",
+			},
+		},
+		{
+			name:    "toml",
+			file:    "40swapd.toml",
+			content: tomlConfig,
+			want: map[string]any{
+				"db-host":     "toml-host",
+				"db-port":     int64(5432),
+				"webhook-url": []string{"https://a.example.com"},
+			},
+		},
+		{
+			name: "missing default file",
+			file: "",
+			want: map[string]any{
+				"db-host":  "localhost",
+				"rpc-host": "localhost",
+			},
+		},
+		{
+			name:     "missing explicit file",
+			file:     "",
+			explicit: true,
+			wantErr:  "could not read config file",
+		},
+		{
+			name:    "unknown setting",
+			file:    "40swapd.yaml",
+			content: "db-hots: localhost\n",
+			wantErr: `unknown setting "db-hots"`,
+		},
+		{
+			name:    "argument of a command",
+			file:    "40swapd.yaml",
+			content: "amt: 1000\n",
+			wantErr: `unknown setting "amt"`,
+		},
+		{
+			name:    "nested setting",
+			file:    "40swapd.yaml",
+			content: "db-host:\n  name: localhost\n",
+			wantErr: "db-host: nested settings aren't supported",
+		},
+		{
+			name:    "unknown extension",
+			file:    "40swapd.json",
+			content: "{}",
+			wantErr: "must be .yaml, .yml or .toml",
+		},
+		{
+			name:    "invalid file",
+			file:    "40swapd.yaml",
+			content: "db-host: [",
+			wantErr: "could not parse config file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			got := map[string]any{}
+			app := newConfigTestApp(func(ctx context.Context, cmd *cli.Command) error {
+				for name := range tt.want {
+					got[name] = cmd.Value(name)
+				}
+
+				return nil
+			})
+
+			path := filepath.Join(t.TempDir(), "missing.yaml")
+			if tt.file != "" {
+				path = writeConfigFile(t, tt.file, tt.content)
+			}
+			err := loadConfig(app, path, tt.explicit)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+
+				return
+			}
+			require.NoError(t, err)
+
+			args := tt.args
+			if args == nil {
+				args = []string{"swap"}
+			}
+			require.NoError(t, app.Run(context.Background(), append([]string{"40swapd"}, args...)))
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestConfigValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   any
+		want    string
+		wantErr string
+	}{
+		{name: "string", value: "localhost", want: "localhost"},
+		{name: "int", value: 5432, want: "5432"},
+		{name: "float", value: 0.005, want: "0.005"},
+		{name: "bool", value: true, want: "true"},
+		{name: "empty", value: nil, want: ""},
+		{name: "list", value: []any{"https://a.example.com", "https://b.example.com"}, want: "https://a.example.com,https://b.example.com"},
+		{name: "list of numbers", value: []any{1, 2}, want: "1,2"},
+		{name: "empty list", value: []any{}, want: ""},
+		{name: "nested", value: map[string]any{"host": "localhost"}, wantErr: "nested settings aren't supported"},
+		{name: "list of nested", value: []any{map[string]any{}}, wantErr: "nested settings aren't supported"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := configValue(tt.value)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEffectiveConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		args    []string
+		want    map[string]any
+	}{
+		{
+			name: "defaults",
+			want: map[string]any{
+				"db-host":                     "localhost",
+				"db-password":                 "",
+				"db-port":                     int64(5432),
+				"webhook-url":                 []string{},
+				"swap-not-found-grace-period": "1h0m0s",
+				"rpc-host":                    "localhost",
+			},
+		},
+		{
+			name:    "file and flags with the secrets redacted",
+			content: "db-password: hunter2\nwebhook-url: [https://a.example.com]\nswap-not-found-grace-period: 90m\n",
+			args:    []string{"--db-host", "db.example.com", "swap", "--amt", "1000"},
+			want: map[string]any{
+				"db-host":                     "db.example.com",
+				"db-password":                 redacted,
+				"db-port":                     int64(5432),
+				"webhook-url":                 []string{"https://a.example.com"},
+				"swap-not-found-grace-period": "1h30m0s",
+				"rpc-host":                    "localhost",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got map[string]any
+			app := newConfigTestApp(func(ctx context.Context, cmd *cli.Command) error {
+				got = effectiveConfig(cmd)
+
+				return nil
+			})
+			path := writeConfigFile(t, "40swapd.yaml", tt.content)
+			require.NoError(t, loadConfig(app, path, true))
+
+			args := tt.args
+			if args == nil {
+				args = []string{"swap"}
+			}
+			require.NoError(t, app.Run(context.Background(), append([]string{"40swapd"}, args...)))
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
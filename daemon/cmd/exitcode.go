@@ -0,0 +1,53 @@
+package main
+
+import (
+	"errors"
+
+	"github.com/urfave/cli/v3"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Exit codes of the commands, so scripts can tell why they failed
+const (
+	exitOK           = 0
+	exitError        = 1
+	exitInvalid      = 2
+	exitNotFound     = 3
+	exitConflict     = 4
+	exitUnauthorized = 5
+	exitUnavailable  = 6
+)
+
+// exitCode maps the error a command failed with to its exit code, gRPC errors
+// of the daemon by their status code
+func exitCode(err error) int {
+	if err == nil {
+		return exitOK
+	}
+
+	var exitCoder cli.ExitCoder
+	if errors.As(err, &exitCoder) {
+		return exitCoder.ExitCode()
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return exitError
+	}
+
+	switch st.Code() {
+	case codes.InvalidArgument, codes.OutOfRange:
+		return exitInvalid
+	case codes.NotFound:
+		return exitNotFound
+	case codes.AlreadyExists, codes.FailedPrecondition, codes.Aborted:
+		return exitConflict
+	case codes.Unauthenticated, codes.PermissionDenied:
+		return exitUnauthorized
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return exitUnavailable
+	default:
+		return exitError
+	}
+}
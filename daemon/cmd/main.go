@@ -2,10 +2,13 @@ package main
 
 import (
 	"context"
-	"encoding/json"
+	"crypto/tls"
 	"fmt"
+	"net"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strconv"
 	"syscall"
 	"time"
 
@@ -13,11 +16,18 @@ import (
 	"github.com/40acres/40swap/daemon/bitcoin/mempool"
 	"github.com/40acres/40swap/daemon/daemon"
 	"github.com/40acres/40swap/daemon/database"
+	"github.com/40acres/40swap/daemon/lightning"
 	"github.com/40acres/40swap/daemon/lightning/lnd"
+	"github.com/40acres/40swap/daemon/metrics"
+	"github.com/40acres/40swap/daemon/notifier"
+	"github.com/40acres/40swap/daemon/price"
 	"github.com/40acres/40swap/daemon/rpc"
 	"github.com/40acres/40swap/daemon/swaps"
+	"github.com/40acres/40swap/daemon/tracing"
 	log "github.com/sirupsen/logrus"
 	"github.com/urfave/cli/v3"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
 	_ "github.com/40acres/40swap/daemon/logging"
 	_ "github.com/lib/pq"
@@ -51,7 +61,7 @@ func main() {
 	app := &cli.Command{
 		Name:  "40swapd",
 		Usage: "Manage 40swap daemon and perform swaps",
-		Description: `The 40swap daemon supports two database modes:
+		Description: `The 40swap daemon supports three database modes:
   1. Embedded: Uses an embedded PostgreSQL database. This is the default mode and requires no additional configuration. You can specify the following parameters:
 	   - db-data-path: Path to the database data directory 			
   2. External: Connects to an external PostgreSQL database. In this mode, you must provide the following parameters:
@@ -59,8 +69,26 @@ func main() {
      - db-user: Database username
      - db-password: Database password
      - db-name: Database name
-     - db-port: Database port`,
+     - db-port: Database port
+  3. SQLite: Stores everything in a single SQLite file, set db-type to sqlite. You can specify the following parameters:
+     - db-sqlite-path: Path to the SQLite database file`,
 		Flags: []cli.Flag{
+			&configFlag,
+			&outputFlag,
+			&cli.StringFlag{
+				Name:  "db-type",
+				Usage: "Database engine: postgres or sqlite",
+				Value: database.DialectPostgres,
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("40SWAPD_DB_TYPE")),
+			},
+			&cli.StringFlag{
+				Name:  "db-sqlite-path",
+				Usage: "Path to the SQLite database file (NOTE: This is only used for SQLite databases)",
+				Value: "./.data/40swapd.db",
+				Sources: cli.NewValueSourceChain(
+					cli.EnvVar("40SWAPD_DB_SQLITE_PATH")),
+			},
 			&cli.StringFlag{
 				Name:  "db-host",
 				Usage: "Database host",
@@ -117,6 +145,42 @@ func main() {
 					cli.EnvVar("40SWAPD_LNDCONNECT")),
 			},
 			&grpcPort,
+			&rpcTLSCert,
+			&rpcNoTLS,
+			&rpcSocket,
+			&cli.IntFlag{
+				Name:    "rest-port",
+				Usage:   "Port to serve the REST/JSON gateway of the RPC server on, 0 disables it. It listens on --rpc-listen-host, or only on localhost when --rpc-socket is set and no listen host is given",
+				Value:   0,
+				Sources: cli.NewValueSourceChain(cli.EnvVar("40SWAPD_REST_PORT")),
+			},
+			&cli.StringFlag{
+				Name:    "rpc-listen-host",
+				Usage:   "Interface the RPC server listens on, all of them when empty",
+				Sources: cli.NewValueSourceChain(cli.EnvVar("40SWAPD_RPC_LISTEN_HOST")),
+			},
+			&cli.StringFlag{
+				Name:    "rpc-tls-key",
+				Usage:   "TLS key of the RPC server, generated along the certificate when missing",
+				Value:   "./.40swapd/tls.key",
+				Sources: cli.NewValueSourceChain(cli.EnvVar("40SWAPD_RPC_TLS_KEY")),
+			},
+			&cli.StringSliceFlag{
+				Name:    "rpc-tls-extra-host",
+				Usage:   "Extra domain or IP the generated TLS certificate is valid for",
+				Sources: cli.NewValueSourceChain(cli.EnvVar("40SWAPD_RPC_TLS_EXTRA_HOST")),
+			},
+			&cli.StringFlag{
+				Name:    "rpc-macaroon-dir",
+				Usage:   "Directory with the macaroon root key and the admin, readonly and swap macaroons, generated when missing",
+				Value:   "./.40swapd",
+				Sources: cli.NewValueSourceChain(cli.EnvVar("40SWAPD_RPC_MACAROON_DIR")),
+			},
+			&cli.BoolFlag{
+				Name:    "rpc-no-macaroons",
+				Usage:   "Don't require macaroons on the RPC server",
+				Sources: cli.NewValueSourceChain(cli.EnvVar("40SWAPD_RPC_NO_MACAROONS")),
+			},
 			&serverUrl,
 			&tlsCert,
 			&macaroon,
@@ -142,6 +206,162 @@ func main() {
 				Sources: cli.NewValueSourceChain(
 					cli.EnvVar("MEMPOOL_TOKEN")),
 			},
+			&cli.DurationFlag{
+				Name:    "swap-not-found-grace-period",
+				Usage:   "How long to keep retrying swaps the server reports as not found before marking them as failed",
+				Value:   daemon.DefaultNotFoundGracePeriod,
+				Sources: cli.NewValueSourceChain(cli.EnvVar("40SWAPD_SWAP_NOT_FOUND_GRACE_PERIOD")),
+			},
+			&cli.IntFlag{
+				Name:    "monitor-max-concurrency",
+				Usage:   "Maximum number of swaps monitored at the same time",
+				Value:   daemon.DefaultMaxConcurrentSwaps,
+				Sources: cli.NewValueSourceChain(cli.EnvVar("40SWAPD_MONITOR_MAX_CONCURRENCY")),
+			},
+			&cli.DurationFlag{
+				Name:    "monitor-min-interval",
+				Usage:   "How often a swap is polled right after its status changes",
+				Value:   daemon.DefaultMinPollInterval,
+				Sources: cli.NewValueSourceChain(cli.EnvVar("40SWAPD_MONITOR_MIN_INTERVAL")),
+			},
+			&cli.DurationFlag{
+				Name:    "monitor-max-interval",
+				Usage:   "Longest wait between polls of a swap whose status doesn't change",
+				Value:   daemon.DefaultMaxPollInterval,
+				Sources: cli.NewValueSourceChain(cli.EnvVar("40SWAPD_MONITOR_MAX_INTERVAL")),
+			},
+			&cli.DurationFlag{
+				Name:    "shutdown-timeout",
+				Usage:   "How long to wait for claims, refunds and other work in flight when shutting down",
+				Value:   daemon.DefaultShutdownTimeout,
+				Sources: cli.NewValueSourceChain(cli.EnvVar("40SWAPD_SHUTDOWN_TIMEOUT")),
+			},
+			&cli.BoolFlag{
+				Name:    "ha",
+				Usage:   "Run in high-availability mode, where only the daemon holding the leader lock on the shared database monitors and claims swaps",
+				Sources: cli.NewValueSourceChain(cli.EnvVar("40SWAPD_HA")),
+			},
+			&cli.IntFlag{
+				Name:    "ha-lock-id",
+				Usage:   "Postgres advisory lock the daemons sharing a database compete for",
+				Value:   daemon.DefaultLeaderLockID,
+				Sources: cli.NewValueSourceChain(cli.EnvVar("40SWAPD_HA_LOCK_ID")),
+			},
+			&cli.DurationFlag{
+				Name:    "ha-check-interval",
+				Usage:   "How often followers campaign for leadership and the leader checks it still holds the lock",
+				Value:   daemon.DefaultLeaderCheckInterval,
+				Sources: cli.NewValueSourceChain(cli.EnvVar("40SWAPD_HA_CHECK_INTERVAL")),
+			},
+			&cli.DurationFlag{
+				Name:    "backup-interval",
+				Usage:   "How often to back up the swaps to backup-dir, 0 disables the backups",
+				Value:   0,
+				Sources: cli.NewValueSourceChain(cli.EnvVar("40SWAPD_BACKUP_INTERVAL")),
+			},
+			&cli.StringFlag{
+				Name:    "backup-dir",
+				Usage:   "Directory the scheduled backups are written to",
+				Value:   "./.40swapd/backups",
+				Sources: cli.NewValueSourceChain(cli.EnvVar("40SWAPD_BACKUP_DIR")),
+			},
+			&cli.IntFlag{
+				Name:    "backup-keep",
+				Usage:   "Number of scheduled backups to keep",
+				Value:   daemon.DefaultBackupKeep,
+				Sources: cli.NewValueSourceChain(cli.EnvVar("40SWAPD_BACKUP_KEEP")),
+			},
+			&cli.StringFlag{
+				Name:    "backup-passphrase",
+				Usage:   "Passphrase the scheduled backups are encrypted with, unencrypted when empty",
+				Sources: cli.NewValueSourceChain(cli.EnvVar("40SWAPD_BACKUP_PASSPHRASE")),
+			},
+			&cli.StringFlag{
+				Name:    "price-source",
+				Usage:   "Where the bitcoin price swaps are valued at comes from: none, http or file",
+				Value:   "none",
+				Sources: cli.NewValueSourceChain(cli.EnvVar("40SWAPD_PRICE_SOURCE")),
+			},
+			&cli.StringFlag{
+				Name:    "price-url",
+				Usage:   "URL of the prices API for the http price source, answering like mempool.space's /v1/prices",
+				Value:   price.DefaultURL,
+				Sources: cli.NewValueSourceChain(cli.EnvVar("40SWAPD_PRICE_URL")),
+			},
+			&cli.StringFlag{
+				Name:    "price-file",
+				Usage:   "JSON file mapping currencies to the price of a bitcoin, e.g. {\"USD\": 65000}, for the file price source",
+				Sources: cli.NewValueSourceChain(cli.EnvVar("40SWAPD_PRICE_FILE")),
+			},
+			&cli.StringFlag{
+				Name:    "fiat-currency",
+				Usage:   "Currency swaps are valued in when they complete",
+				Value:   daemon.DefaultFiatCurrency,
+				Sources: cli.NewValueSourceChain(cli.EnvVar("40SWAPD_FIAT_CURRENCY")),
+			},
+			&cli.IntFlag{
+				Name:    "metrics-port",
+				Usage:   "Port to expose Prometheus metrics on, 0 disables them",
+				Value:   0,
+				Sources: cli.NewValueSourceChain(cli.EnvVar("40SWAPD_METRICS_PORT")),
+			},
+			&cli.StringFlag{
+				Name:    "metrics-host",
+				Usage:   "Interface to expose Prometheus metrics on, defaults to --rpc-listen-host, or only localhost when --rpc-socket is set and no listen host is given",
+				Sources: cli.NewValueSourceChain(cli.EnvVar("40SWAPD_METRICS_HOST")),
+			},
+			&cli.StringFlag{
+				Name:    "tracing-exporter",
+				Usage:   "Where to export traces: none, otlp, stdout or file",
+				Value:   tracing.ExporterNone,
+				Sources: cli.NewValueSourceChain(cli.EnvVar("40SWAPD_TRACING_EXPORTER")),
+				Validator: func(s string) error {
+					switch s {
+					case tracing.ExporterNone, tracing.ExporterOTLP, tracing.ExporterStdout, tracing.ExporterFile:
+						return nil
+					}
+
+					return fmt.Errorf("invalid tracing exporter: %s", s)
+				},
+			},
+			&cli.StringFlag{
+				Name:    "tracing-otlp-endpoint",
+				Usage:   "OTLP gRPC collector endpoint (host:port), defaults to the OTEL_EXPORTER_OTLP_ENDPOINT environment variable",
+				Sources: cli.NewValueSourceChain(cli.EnvVar("40SWAPD_TRACING_OTLP_ENDPOINT")),
+			},
+			&cli.BoolFlag{
+				Name:    "tracing-otlp-insecure",
+				Usage:   "Disable TLS when connecting to the OTLP collector",
+				Value:   false,
+				Sources: cli.NewValueSourceChain(cli.EnvVar("40SWAPD_TRACING_OTLP_INSECURE")),
+			},
+			&cli.StringFlag{
+				Name:    "tracing-file",
+				Usage:   "File to write traces to when using the file exporter",
+				Value:   "40swapd-traces.json",
+				Sources: cli.NewValueSourceChain(cli.EnvVar("40SWAPD_TRACING_FILE")),
+			},
+			&cli.StringSliceFlag{
+				Name:    "webhook-url",
+				Usage:   "URL to post swap notifications to, can be repeated",
+				Sources: cli.NewValueSourceChain(cli.EnvVar("40SWAPD_WEBHOOK_URLS")),
+			},
+			&cli.StringFlag{
+				Name:    "webhook-secret",
+				Usage:   "Secret used to sign the webhook payloads and their timestamp with HMAC-SHA256, the payloads are sent unsigned without it",
+				Sources: cli.NewValueSourceChain(cli.EnvVar("40SWAPD_WEBHOOK_SECRET")),
+			},
+			&cli.StringSliceFlag{
+				Name:    "webhook-events",
+				Usage:   "Event types to send to the webhooks (e.g. swap.refunded, swap.expired, swap.error, autoswap.failed), all when empty",
+				Sources: cli.NewValueSourceChain(cli.EnvVar("40SWAPD_WEBHOOK_EVENTS")),
+			},
+			&cli.IntFlag{
+				Name:    "webhook-max-attempts",
+				Usage:   "How many times a webhook notification is tried before giving up",
+				Value:   notifier.DefaultMaxAttempts,
+				Sources: cli.NewValueSourceChain(cli.EnvVar("40SWAPD_WEBHOOK_MAX_ATTEMPTS")),
+			},
 			&cli.BoolFlag{
 				Name:    "auto-swap-enabled",
 				Usage:   "Enable or disable auto swap out feature",
@@ -200,23 +420,13 @@ func main() {
 					if err != nil {
 						return err
 					}
-
-					port, err := validatePort(c.Int("db-port"))
-					if err != nil {
-						return err
+					if c.Bool("ha") && (c.String("db-type") == database.DialectSQLite || c.String("db-host") == "embedded") {
+						return fmt.Errorf("❌ High-availability mode needs an external Postgres database, set --db-host")
 					}
 
-					db, closeDb, err := database.New(
-						c.String("db-user"),
-						c.String("db-password"),
-						c.String("db-name"),
-						port,
-						c.String("db-data-path"),
-						c.String("db-host"),
-						c.Bool("db-keep-alive"),
-					)
+					db, closeDb, err := openDatabase(c)
 					if err != nil {
-						return fmt.Errorf("❌ Could not connect to database: %w", err)
+						return err
 					}
 					defer func() {
 						if err := closeDb(); err != nil {
@@ -224,18 +434,23 @@ func main() {
 						}
 					}()
 
-					dbErr := db.MigrateDatabase()
-					if dbErr != nil {
-						log.Errorf("❌ Could not migrate database: %v", err)
-					}
+					network := networkFromFlags(c)
 
-					// Get the network
-					network := rpc.Network_MAINNET
-					if c.Bool("regtest") {
-						network = rpc.Network_REGTEST
-					} else if c.Bool("testnet") {
-						network = rpc.Network_TESTNET
+					tracingConfig := tracing.Config{
+						Exporter:     c.String("tracing-exporter"),
+						OTLPEndpoint: c.String("tracing-otlp-endpoint"),
+						OTLPInsecure: c.Bool("tracing-otlp-insecure"),
+						File:         c.String("tracing-file"),
 					}
+					shutdownTracing, err := tracing.Init(ctx, tracingConfig)
+					if err != nil {
+						return fmt.Errorf("❌ Could not set up tracing: %w", err)
+					}
+					defer func() {
+						if err := shutdownTracing(context.Background()); err != nil {
+							log.Errorf("❌ Could not flush traces: %v", err)
+						}
+					}()
 
 					// Create auto swap config from CLI flags
 					autoSwapConfig := daemon.NewAutoSwapConfigFromFlags(
@@ -279,17 +494,139 @@ func main() {
 
 					mempool := mempool.New(c.String("mempool-token"), mempool.WithURL(c.String("mempool-endpoint")))
 
-					server := rpc.NewRPCServer(grpcPort, db, swapClient, lnClient, mempool, c.Int("minrelayfee"), network)
+					var (
+						swapsBackend     swaps.ClientInterface = swapClient
+						lightningBackend lightning.Client      = lnClient
+						bitcoinBackend   bitcoinutils.Client   = mempool
+					)
+					var metricsAddr string
+					if c.Int("metrics-port") != 0 {
+						metricsPort, err := validatePort(c.Int("metrics-port"))
+						if err != nil {
+							return err
+						}
+						metricsHost := c.String("metrics-host")
+						if metricsHost == "" {
+							metricsHost = c.String("rpc-listen-host")
+						}
+						if metricsHost == "" && c.String("rpc-socket") != "" {
+							metricsHost = "localhost"
+						}
+						metricsAddr = net.JoinHostPort(metricsHost, strconv.FormatUint(uint64(metricsPort), 10))
+
+						swapsBackend = metrics.NewSwapsClient(swapClient)
+						lightningBackend = metrics.NewLightningClient(lnClient)
+						bitcoinBackend = metrics.NewBitcoinClient(mempool)
+					}
+
+					if tracingConfig.Enabled() {
+						swapsBackend = tracing.NewSwapsClient(swapsBackend)
+						lightningBackend = tracing.NewLightningClient(lightningBackend)
+						bitcoinBackend = tracing.NewBitcoinClient(bitcoinBackend)
+					}
+
+					serverOpts, selfClientConfig, tlsCert, err := rpcServerOptions(c)
+					if err != nil {
+						return err
+					}
+
+					server := rpc.NewRPCServer(grpcPort, db, swapsBackend, lightningBackend, bitcoinBackend, c.Int("minrelayfee"), network, serverOpts...)
+					server.Host = c.String("rpc-listen-host")
+					server.SocketPath = c.String("rpc-socket")
+					if restPort := c.Int("rest-port"); restPort != 0 {
+						port, err := validatePort(restPort)
+						if err != nil {
+							return err
+						}
+						// The gateway forwards the macaroon of every request
+						gatewaySelf := selfClientConfig
+						gatewaySelf.MacaroonPath = ""
+						server.EnableGateway(port, gatewaySelf, tlsCert)
+					}
 					defer server.Stop()
 
 					// Create auto swap service if enabled
 					var autoSwapService *daemon.AutoSwapService
 					if autoSwapConfig.IsEnabled() {
-						rpcClient := rpc.NewRPCClient("localhost", grpcPort)
-						autoSwapService = daemon.NewAutoSwapService(swapClient, rpcClient, lnClient, db, autoSwapConfig)
+						selfHost := "localhost"
+						if host := c.String("rpc-listen-host"); host != "" {
+							selfHost = host
+						}
+						rpcClient, err := rpc.NewRPCClient(selfHost, grpcPort, selfClientConfig)
+						if err != nil {
+							return err
+						}
+						autoSwapService = daemon.NewAutoSwapService(swapsBackend, rpcClient, lightningBackend, db, autoSwapConfig)
+					}
+
+					var webhooks *notifier.Notifier
+					if urls := c.StringSlice("webhook-url"); len(urls) > 0 {
+						if c.String("webhook-secret") == "" {
+							log.Warn("No webhook secret given, webhook payloads are sent unsigned")
+						}
+						webhooks = notifier.New(db, notifier.Config{
+							URLs:        urls,
+							Secret:      c.String("webhook-secret"),
+							Events:      c.StringSlice("webhook-events"),
+							MaxAttempts: int32(c.Int("webhook-max-attempts")), // nolint:gosec
+						})
+						server.SetNotifier(webhooks)
+					}
+
+					var elector *daemon.Elector
+					if c.Bool("ha") {
+						lock, err := db.NewAdvisoryLock(c.Int("ha-lock-id"))
+						if err != nil {
+							return fmt.Errorf("❌ Could not create leader lock: %w", err)
+						}
+						elector = daemon.NewElector(lock, c.Duration("ha-check-interval"), server.SetLeader)
 					}
 
-					err = daemon.Start(ctx, server, db, swapClient, lnClient, mempool, rpc.ToLightningNetworkType(network), autoSwapService)
+					var backups *daemon.Backups
+					if interval := c.Duration("backup-interval"); interval > 0 {
+						backups = daemon.NewBackups(db, daemon.BackupConfig{
+							Dir:        c.String("backup-dir"),
+							Interval:   interval,
+							Keep:       int(c.Int("backup-keep")),
+							Passphrase: c.String("backup-passphrase"),
+						})
+					}
+
+					fiat := daemon.FiatConfig{Currency: c.String("fiat-currency")}
+					switch c.String("price-source") {
+					case "none":
+					case "http":
+						fiat.Source = price.NewHTTP(c.String("price-url"))
+					case "file":
+						if c.String("price-file") == "" {
+							return fmt.Errorf("❌ price-file is required with the file price source")
+						}
+						fiat.Source = price.NewFile(c.String("price-file"))
+					default:
+						return fmt.Errorf("❌ Invalid price-source %q, must be none, http or file", c.String("price-source"))
+					}
+
+					err = daemon.Start(ctx, daemon.Config{
+						Server:              server,
+						Repository:          db,
+						Swaps:               swapsBackend,
+						Lightning:           lightningBackend,
+						Bitcoin:             bitcoinBackend,
+						Network:             rpc.ToLightningNetworkType(network),
+						NotFoundGracePeriod: c.Duration("swap-not-found-grace-period"),
+						AutoSwap:            autoSwapService,
+						Webhooks:            webhooks,
+						Scheduler: daemon.SchedulerConfig{
+							MaxConcurrentSwaps: int(c.Int("monitor-max-concurrency")),
+							MinPollInterval:    c.Duration("monitor-min-interval"),
+							MaxPollInterval:    c.Duration("monitor-max-interval"),
+						},
+						ShutdownTimeout: c.Duration("shutdown-timeout"),
+						Elector:         elector,
+						Backups:         backups,
+						Fiat:            fiat,
+						MetricsAddr:     metricsAddr,
+					})
 					if err != nil {
 						return err
 					}
@@ -324,7 +661,14 @@ func main() {
 								Name:  "amt",
 								Usage: "Amount in sats to swap",
 							},
+							&clientRequestID,
+							&label,
 							&grpcPort,
+							&rpcTLSCert,
+							&rpcMacaroon,
+							&rpcNoTLS,
+							&rpcSocket,
+							&rpcHost,
 							&bitcoin,
 						},
 						Action: func(ctx context.Context, c *cli.Command) error {
@@ -341,11 +685,19 @@ func main() {
 								return err
 							}
 
-							client := rpc.NewRPCClient("localhost", grpcPort)
+							client, err := rpc.NewRPCClient(c.String("rpc-host"), grpcPort, rpcClientConfig(c))
+							if err != nil {
+								return err
+							}
 
 							swapInRequest := rpc.SwapInRequest{
 								Chain:    chain,
 								RefundTo: c.String("refund-to"),
+								Label:    c.String("label"),
+							}
+							if c.IsSet("client-request-id") {
+								id := c.String("client-request-id")
+								swapInRequest.ClientRequestId = &id
 							}
 							payreq := c.String("payreq")
 							if payreq == "" && c.Uint("amt") == 0 {
@@ -371,15 +723,7 @@ func main() {
 								return err
 							}
 
-							// Marshal response into json
-							resp, err := json.MarshalIndent(swap, "", indent)
-							if err != nil {
-								return err
-							}
-
-							fmt.Printf("%s\n", resp)
-
-							return nil
+							return printOutput(c, swap)
 						},
 					},
 					{
@@ -387,6 +731,11 @@ func main() {
 						Usage: "Perform a swap out",
 						Flags: []cli.Flag{
 							&grpcPort,
+							&rpcTLSCert,
+							&rpcMacaroon,
+							&rpcNoTLS,
+							&rpcSocket,
+							&rpcHost,
 							&amountSats,
 							&cli.StringFlag{
 								// This address is optional since in case that is not given,
@@ -399,6 +748,8 @@ func main() {
 								Usage: "The maximum routing fee in percentage for the lightning networ",
 								Value: 0.5,
 							},
+							&clientRequestID,
+							&label,
 						},
 						Action: func(ctx context.Context, cmd *cli.Command) error {
 							grpcPort, err := validatePort(cmd.Int("grpc-port"))
@@ -406,7 +757,10 @@ func main() {
 								return err
 							}
 
-							client := rpc.NewRPCClient("localhost", grpcPort)
+							client, err := rpc.NewRPCClient(cmd.String("rpc-host"), grpcPort, rpcClientConfig(cmd))
+							if err != nil {
+								return err
+							}
 
 							maxRoutingFeePercent := cmd.Float("max-routing-fee-percent")
 							if maxRoutingFeePercent < 0 || maxRoutingFeePercent > 100 {
@@ -419,21 +773,86 @@ func main() {
 								AmountSats:           cmd.Uint("amt"),
 								Address:              cmd.String("address"),
 								MaxRoutingFeePercent: &mrfp,
+								Label:                cmd.String("label"),
+							}
+							if cmd.IsSet("client-request-id") {
+								id := cmd.String("client-request-id")
+								swapOutRequest.ClientRequestId = &id
 							}
 
 							swap, err := client.SwapOut(ctx, &swapOutRequest)
 							if err != nil {
 								return err
 							}
-							// Marshal response into json
-							resp, err := json.MarshalIndent(swap, "", indent)
+							return printOutput(cmd, swap)
+						},
+					},
+					{
+						Name:  "quote",
+						Usage: "Estimate the fees of a swap without creating it",
+						Flags: []cli.Flag{
+							&grpcPort,
+							&rpcTLSCert,
+							&rpcMacaroon,
+							&rpcNoTLS,
+							&rpcSocket,
+							&rpcHost,
+							&amountSats,
+							&cli.StringFlag{
+								Name:     "type",
+								Usage:    "The type of swap (IN or OUT)",
+								Required: true,
+							},
+							&cli.FloatFlag{
+								Name:  "max-routing-fee-percent",
+								Usage: "The maximum routing fee in percentage for the lightning network, only for swap outs",
+								Value: 0.5,
+							},
+						},
+						Action: func(ctx context.Context, cmd *cli.Command) error {
+							grpcPort, err := validatePort(cmd.Int("grpc-port"))
+							if err != nil {
+								return err
+							}
+							client, err := rpc.NewRPCClient(cmd.String("rpc-host"), grpcPort, rpcClientConfig(cmd))
 							if err != nil {
 								return err
 							}
 
-							fmt.Printf("%s\n", resp)
+							var resp any
+							swapType := cmd.String("type")
+
+							switch swapType {
+							case "IN":
+								quote, err := client.QuoteSwapIn(ctx, &rpc.QuoteSwapInRequest{
+									Chain:      rpc.Chain_BITCOIN,
+									AmountSats: cmd.Uint("amt"),
+								})
+								if err != nil {
+									return err
+								}
+								resp = quote
+							case "OUT":
+								maxRoutingFeePercent := cmd.Float("max-routing-fee-percent")
+								if maxRoutingFeePercent < 0 || maxRoutingFeePercent > 100 {
+									return fmt.Errorf("max-routing-fee-percent must be between 0 and 100")
+								}
+								mrfp := float32(maxRoutingFeePercent)
+
+								quote, err := client.QuoteSwapOut(ctx, &rpc.QuoteSwapOutRequest{
+									Chain:                rpc.Chain_BITCOIN,
+									AmountSats:           cmd.Uint("amt"),
+									MaxRoutingFeePercent: &mrfp,
+								})
+								if err != nil {
+									return err
+								}
+								resp = quote
+							default:
+								return fmt.Errorf("invalid swap type: %s", swapType)
+							}
 
-							return nil
+							return printOutput(cmd, resp)
 						},
 					},
 					{
@@ -441,6 +860,11 @@ func main() {
 						Usage: "Check the status of a swap",
 						Flags: []cli.Flag{
 							&grpcPort,
+							&rpcTLSCert,
+							&rpcMacaroon,
+							&rpcNoTLS,
+							&rpcSocket,
+							&rpcHost,
 							&cli.StringFlag{
 								Name:     "id",
 								Usage:    "The ID of the swap to check",
@@ -457,9 +881,12 @@ func main() {
 							if err != nil {
 								return err
 							}
-							client := rpc.NewRPCClient("localhost", grpcPort)
+							client, err := rpc.NewRPCClient(cmd.String("rpc-host"), grpcPort, rpcClientConfig(cmd))
+							if err != nil {
+								return err
+							}
 
-							var resp []byte
+							var resp any
 							swapType := cmd.String("type")
 							swapId := cmd.String("id")
 
@@ -471,11 +898,7 @@ func main() {
 								if err != nil {
 									return err
 								}
-								// Marshal response into json
-								resp, err = json.MarshalIndent(status, "", indent)
-								if err != nil {
-									return err
-								}
+								resp = status
 							case "OUT":
 								status, err := client.GetSwapOut(ctx, &rpc.GetSwapOutRequest{
 									Id: swapId,
@@ -483,18 +906,120 @@ func main() {
 								if err != nil {
 									return err
 								}
-								// Marshal response into json
-								resp, err = json.MarshalIndent(status, "", indent)
-								if err != nil {
-									return err
-								}
+								resp = status
 							default:
 								return fmt.Errorf("invalid swap type: %s", swapType)
 							}
 
-							fmt.Printf("%s\n", resp)
+							return printOutput(cmd, resp)
+						},
+					},
+					{
+						Name:  "history",
+						Usage: "Show the history of a swap",
+						Flags: []cli.Flag{
+							&grpcPort,
+							&rpcTLSCert,
+							&rpcMacaroon,
+							&rpcNoTLS,
+							&rpcSocket,
+							&rpcHost,
+							&cli.StringFlag{
+								Name:     "id",
+								Usage:    "The ID of the swap",
+								Required: true,
+							},
+						},
+						Action: func(ctx context.Context, cmd *cli.Command) error {
+							grpcPort, err := validatePort(cmd.Int("grpc-port"))
+							if err != nil {
+								return err
+							}
+							client, err := rpc.NewRPCClient(cmd.String("rpc-host"), grpcPort, rpcClientConfig(cmd))
+							if err != nil {
+								return err
+							}
+
+							timeline, err := client.GetSwapTimeline(ctx, &rpc.GetSwapTimelineRequest{
+								Id: cmd.String("id"),
+							})
+							if err != nil {
+								return err
+							}
+
+							return printOutput(cmd, timeline)
+						},
+					},
+					{
+						Name:  "reopen",
+						Usage: "Resume monitoring of a swap that was marked as failed",
+						Flags: []cli.Flag{
+							&grpcPort,
+							&rpcTLSCert,
+							&rpcMacaroon,
+							&rpcNoTLS,
+							&rpcSocket,
+							&rpcHost,
+							&cli.StringFlag{
+								Name:     "id",
+								Usage:    "The ID of the swap to reopen",
+								Required: true,
+							},
+						},
+						Action: func(ctx context.Context, cmd *cli.Command) error {
+							grpcPort, err := validatePort(cmd.Int("grpc-port"))
+							if err != nil {
+								return err
+							}
+							client, err := rpc.NewRPCClient(cmd.String("rpc-host"), grpcPort, rpcClientConfig(cmd))
+							if err != nil {
+								return err
+							}
 
-							return nil
+							swap, err := client.ReopenSwap(ctx, &rpc.ReopenSwapRequest{
+								Id: cmd.String("id"),
+							})
+							if err != nil {
+								return err
+							}
+
+							return printOutput(cmd, swap)
+						},
+					},
+					{
+						Name:  "cancel",
+						Usage: "Cancel a swap that hasn't been paid or funded yet",
+						Flags: []cli.Flag{
+							&grpcPort,
+							&rpcTLSCert,
+							&rpcMacaroon,
+							&rpcNoTLS,
+							&rpcSocket,
+							&rpcHost,
+							&cli.StringFlag{
+								Name:     "id",
+								Usage:    "The ID of the swap to cancel",
+								Required: true,
+							},
+						},
+						Action: func(ctx context.Context, cmd *cli.Command) error {
+							grpcPort, err := validatePort(cmd.Int("grpc-port"))
+							if err != nil {
+								return err
+							}
+							client, err := rpc.NewRPCClient(cmd.String("rpc-host"), grpcPort, rpcClientConfig(cmd))
+							if err != nil {
+								return err
+							}
+
+							swap, err := client.CancelSwap(ctx, &rpc.CancelSwapRequest{
+								Id: cmd.String("id"),
+							})
+							if err != nil {
+								return err
+							}
+
+							return printOutput(cmd, swap)
 						},
 					},
 					{
@@ -502,6 +1027,11 @@ func main() {
 						Usage: "Recover a swap that was paid more than once",
 						Flags: []cli.Flag{
 							&grpcPort,
+							&rpcTLSCert,
+							&rpcMacaroon,
+							&rpcNoTLS,
+							&rpcSocket,
+							&rpcHost,
 							&cli.StringFlag{
 								Name:     "outpoint",
 								Usage:    "The outpoint of the swap to recover, in the format txid:index",
@@ -518,7 +1048,10 @@ func main() {
 								return err
 							}
 
-							client := rpc.NewRPCClient("localhost", grpcPort)
+							client, err := rpc.NewRPCClient(cmd.String("rpc-host"), grpcPort, rpcClientConfig(cmd))
+							if err != nil {
+								return err
+							}
 
 							isValid := bitcoinutils.IsValidOutpoint(cmd.String("outpoint"))
 							if !isValid {
@@ -536,18 +1069,52 @@ func main() {
 								return err
 							}
 
-							resp, err := json.MarshalIndent(swap, "", indent)
-							if err != nil {
-								return err
-							}
-
-							fmt.Printf("%s\n", resp)
-
-							return nil
+							return printOutput(cmd, swap)
 						},
 					},
 				},
 			},
+			&reportCommand,
+			&configCommand,
+			{
+				Name:  "recover",
+				Usage: "Recover the funds of pending swaps",
+				Description: `Claims pending swap outs using the stored preimage and refunds pending swap ins whose
+contract has expired. In offline mode only the local database and the chain backend
+(mempool-endpoint) are used, so funds can be recovered even if the swap server is gone.`,
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "offline",
+						Usage: "Never contact the swap server, use only the local database and the chain backend",
+					},
+				},
+				Action: func(ctx context.Context, c *cli.Command) error {
+					if !c.Bool("offline") {
+						return fmt.Errorf("only offline recovery is supported, use --offline (to recover reused swap addresses use 'swap recover')")
+					}
+
+					db, closeDb, err := openDatabase(c)
+					if err != nil {
+						return err
+					}
+					defer func() {
+						if err := closeDb(); err != nil {
+							log.Errorf("❌ Could not close database: %v", err)
+						}
+					}()
+
+					network := networkFromFlags(c)
+					mempool := mempool.New(c.String("mempool-token"), mempool.WithURL(c.String("mempool-endpoint")))
+
+					recovery := daemon.NewOfflineRecovery(db, mempool, rpc.ToLightningNetworkType(network))
+					results, err := recovery.Recover(ctx)
+					if err != nil {
+						return err
+					}
+
+					return printOutput(c, results)
+				},
+			},
 			{
 				Name:  "help",
 				Usage: "Show help",
@@ -562,10 +1129,119 @@ func main() {
 		},
 	}
 
+	configFile, explicit := configPath(os.Args[1:])
+	if err := loadConfig(app, configFile, explicit); err != nil {
+		log.Fatal(err)
+	}
+
 	app_err := app.Run(ctx, os.Args)
 	if app_err != nil {
-		log.Fatal(app_err)
+		log.Error(app_err)
+		os.Exit(exitCode(app_err))
+	}
+}
+
+// rpcClientConfig is how the client commands connect to the daemon: the unix
+// socket, if any, and the credentials
+func rpcClientConfig(c *cli.Command) rpc.ClientConfig {
+	creds := rpc.ClientConfig{MacaroonPath: c.String("rpc-macaroon"), SocketPath: c.String("rpc-socket")}
+	if !c.Bool("rpc-no-tls") {
+		creds.TLSCertPath = c.String("rpc-tls-cert")
+	}
+
+	return creds
+}
+
+// rpcServerOptions secures the RPC server with TLS and macaroons, generating
+// them when missing. It also returns the admin credentials the daemon uses
+// to call itself and the TLS certificate, nil when TLS is disabled.
+func rpcServerOptions(c *cli.Command) ([]grpc.ServerOption, rpc.ClientConfig, *tls.Certificate, error) {
+	var opts []grpc.ServerOption
+	var tlsCert *tls.Certificate
+	creds := rpc.ClientConfig{SocketPath: c.String("rpc-socket")}
+
+	if !c.Bool("rpc-no-tls") {
+		// The daemon dials the interface it listens on for auto swaps
+		extraHosts := c.StringSlice("rpc-tls-extra-host")
+		if host := c.String("rpc-listen-host"); host != "" {
+			extraHosts = append(extraHosts, host)
+		}
+		cert, err := rpc.LoadOrCreateTLSCert(c.String("rpc-tls-cert"), c.String("rpc-tls-key"), extraHosts)
+		if err != nil {
+			return nil, creds, nil, fmt.Errorf("❌ Could not set up TLS: %w", err)
+		}
+		tlsCert = &cert
+		opts = append(opts, grpc.Creds(credentials.NewServerTLSFromCert(tlsCert)))
+		creds.TLSCertPath = c.String("rpc-tls-cert")
+	}
+
+	if !c.Bool("rpc-no-macaroons") {
+		macaroons, err := rpc.LoadOrCreateMacaroons(c.String("rpc-macaroon-dir"))
+		if err != nil {
+			return nil, creds, nil, fmt.Errorf("❌ Could not set up macaroons: %w", err)
+		}
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(macaroons.UnaryServerInterceptor),
+			grpc.ChainStreamInterceptor(macaroons.StreamServerInterceptor),
+		)
+		creds.MacaroonPath = filepath.Join(c.String("rpc-macaroon-dir"), rpc.PermissionAdmin+".macaroon")
+	}
+
+	return opts, creds, tlsCert, nil
+}
+
+// openDatabase connects to the database configured in the flags and applies
+// any pending migration.
+func openDatabase(c *cli.Command) (*database.Database, func() error, error) {
+	var (
+		db      *database.Database
+		closeDb func() error
+		err     error
+	)
+	switch c.String("db-type") {
+	case database.DialectPostgres:
+		var port uint32
+		port, err = validatePort(c.Int("db-port"))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		db, closeDb, err = database.New(
+			c.String("db-user"),
+			c.String("db-password"),
+			c.String("db-name"),
+			port,
+			c.String("db-data-path"),
+			c.String("db-host"),
+			c.Bool("db-keep-alive"),
+		)
+	case database.DialectSQLite:
+		db, closeDb, err = database.NewSQLite(c.String("db-sqlite-path"))
+	default:
+		return nil, nil, fmt.Errorf("❌ Invalid db-type %q, must be postgres or sqlite", c.String("db-type"))
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("❌ Could not connect to database: %w", err)
+	}
+
+	dbErr := db.MigrateDatabase()
+	if dbErr != nil {
+		log.Errorf("❌ Could not migrate database: %v", dbErr)
 	}
+
+	return db, closeDb, nil
+}
+
+// networkFromFlags returns the network selected in the flags
+func networkFromFlags(c *cli.Command) rpc.Network {
+	network := rpc.Network_MAINNET
+	if c.Bool("regtest") {
+		network = rpc.Network_REGTEST
+	} else if c.Bool("testnet") {
+		network = rpc.Network_TESTNET
+	}
+
+	return network
 }
 
 // Lightnig networks
@@ -600,12 +1276,55 @@ var grpcPort = cli.IntFlag{
 	Sources: cli.NewValueSourceChain(
 		cli.EnvVar("40SWAPD_GRPC_PORT")),
 }
+var rpcTLSCert = cli.StringFlag{
+	Name:  "rpc-tls-cert",
+	Usage: "TLS certificate of the RPC server, generated by the daemon when missing",
+	Value: "./.40swapd/tls.cert",
+	Sources: cli.NewValueSourceChain(
+		cli.EnvVar("40SWAPD_RPC_TLS_CERT")),
+}
+var rpcNoTLS = cli.BoolFlag{
+	Name:  "rpc-no-tls",
+	Usage: "Serve and connect to the RPC server without TLS",
+	Sources: cli.NewValueSourceChain(
+		cli.EnvVar("40SWAPD_RPC_NO_TLS")),
+}
+var rpcMacaroon = cli.StringFlag{
+	Name:  "rpc-macaroon",
+	Usage: "Macaroon to authenticate to the RPC server with, empty to send none",
+	Value: "./.40swapd/admin.macaroon",
+	Sources: cli.NewValueSourceChain(
+		cli.EnvVar("40SWAPD_RPC_MACAROON")),
+}
+var rpcSocket = cli.StringFlag{
+	Name:  "rpc-socket",
+	Usage: "Unix socket the RPC server listens on instead of the gRPC port",
+	Sources: cli.NewValueSourceChain(
+		cli.EnvVar("40SWAPD_RPC_SOCKET")),
+}
+var rpcHost = cli.StringFlag{
+	Name:  "rpc-host",
+	Usage: "Host where the daemon is listening",
+	Value: "localhost",
+	Sources: cli.NewValueSourceChain(
+		cli.EnvVar("40SWAPD_RPC_HOST")),
+}
 var amountSats = cli.UintFlag{
 	Name:     "amt",
 	Usage:    "Amount in sats to swap",
 	Required: true,
 }
 
+var clientRequestID = cli.StringFlag{
+	Name:  "client-request-id",
+	Usage: "Idempotency key of the request, repeating it returns the swap it created instead of creating another one",
+}
+
+var label = cli.StringFlag{
+	Name:  "label",
+	Usage: "Label to tag the swap with",
+}
+
 var serverUrl = cli.StringFlag{
 	Name:  "server-url",
 	Usage: "Server URL",
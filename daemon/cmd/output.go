@@ -0,0 +1,228 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/urfave/cli/v3"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+	outputTable = "table"
+)
+
+var outputFlag = cli.StringFlag{
+	Name:      "output",
+	Aliases:   []string{"o"},
+	Usage:     "Format the responses of the commands are printed in: json, yaml or table",
+	Value:     outputJSON,
+	Sources:   cli.NewValueSourceChain(cli.EnvVar("40SWAPD_OUTPUT")),
+	Validator: validateOutput,
+}
+
+func validateOutput(format string) error {
+	switch format {
+	case outputJSON, outputYAML, outputTable:
+		return nil
+	}
+
+	return fmt.Errorf("invalid output format: %s", format)
+}
+
+// printOutput prints the response of a command to stdout in the format given
+// with --output
+func printOutput(cmd *cli.Command, value any) error {
+	return writeOutput(os.Stdout, cmd.String(outputFlag.Name), value)
+}
+
+// writeOutput writes value in format. Protobuf messages are serialized with
+// protojson, so the output uses the same field names as the REST gateway.
+func writeOutput(w io.Writer, format string, value any) error {
+	if err := validateOutput(format); err != nil {
+		return err
+	}
+
+	data, err := marshalJSON(value)
+	if err != nil {
+		return fmt.Errorf("could not encode response: %w", err)
+	}
+
+	if format == outputJSON {
+		var out bytes.Buffer
+		if err := json.Indent(&out, data, "", indent); err != nil {
+			return fmt.Errorf("could not encode response: %w", err)
+		}
+		out.WriteByte('\n')
+		_, err := out.WriteTo(w)
+
+		return err
+	}
+
+	var generic any
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return fmt.Errorf("could not encode response: %w", err)
+	}
+
+	if format == outputYAML {
+		out, err := yaml.Marshal(generic)
+		if err != nil {
+			return fmt.Errorf("could not encode response: %w", err)
+		}
+		_, err = w.Write(out)
+
+		return err
+	}
+
+	return writeTable(w, generic)
+}
+
+func marshalJSON(value any) ([]byte, error) {
+	if message, ok := value.(proto.Message); ok {
+		return protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(message)
+	}
+
+	return json.Marshal(value)
+}
+
+// writeTable prints the fields of an object as key/value rows, nested fields
+// keyed by their path, and its lists of objects, or value itself if it's one,
+// as tables with a column per field.
+func writeTable(w io.Writer, value any) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	switch v := value.(type) {
+	case []any:
+		writeRows(tw, v)
+	case map[string]any:
+		var lists []string
+		fields := 0
+		for _, key := range sortedKeys(v) {
+			if rows, ok := v[key].([]any); ok && isObjectList(rows) {
+				lists = append(lists, key)
+
+				continue
+			}
+			flatten(key, v[key], func(key, value string) {
+				fmt.Fprintf(tw, "%s\t%s\n", key, value)
+				fields++
+			})
+		}
+
+		for i, key := range lists {
+			if i > 0 || fields > 0 {
+				fmt.Fprintln(tw)
+			}
+			fmt.Fprintf(tw, "%s:\n", key)
+			writeRows(tw, v[key].([]any))
+		}
+	default:
+		fmt.Fprintln(tw, scalar(v))
+	}
+
+	return tw.Flush()
+}
+
+// writeRows prints a list of objects as a table with the union of their fields
+// as columns
+func writeRows(w io.Writer, rows []any) {
+	var columns []string
+	values := make([]map[string]string, 0, len(rows))
+	for _, row := range rows {
+		fields := map[string]string{}
+		flatten("", row, func(key, value string) {
+			if !slices.Contains(columns, key) {
+				columns = append(columns, key)
+			}
+			fields[key] = value
+		})
+		values = append(values, fields)
+	}
+	sort.Strings(columns)
+
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = strings.ToUpper(column)
+	}
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+
+	for _, fields := range values {
+		row := make([]string, len(columns))
+		for i, column := range columns {
+			row[i] = fields[column]
+		}
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+}
+
+// flatten calls add with every scalar in value keyed by its dotted path,
+// lists are joined with commas
+func flatten(prefix string, value any, add func(key, value string)) {
+	switch v := value.(type) {
+	case map[string]any:
+		for _, key := range sortedKeys(v) {
+			path := key
+			if prefix != "" {
+				path = prefix + "." + key
+			}
+			flatten(path, v[key], add)
+		}
+	case []any:
+		if isObjectList(v) {
+			data, _ := json.Marshal(v)
+			add(prefix, string(data))
+
+			return
+		}
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = scalar(item)
+		}
+		add(prefix, strings.Join(items, ","))
+	default:
+		add(prefix, scalar(v))
+	}
+}
+
+func isObjectList(values []any) bool {
+	for _, value := range values {
+		if _, ok := value.(map[string]any); !ok {
+			return false
+		}
+	}
+
+	return len(values) > 0
+}
+
+func scalar(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func sortedKeys(values map[string]any) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
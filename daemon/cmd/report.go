@@ -0,0 +1,200 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/40acres/40swap/daemon/rpc"
+	"github.com/urfave/cli/v3"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// reportCSV is the report format besides the --output ones
+const reportCSV = "csv"
+
+var reportCommand = cli.Command{
+	Name:  "report",
+	Usage: "Export the finished swaps in a date range with their costs, for accounting",
+	Flags: []cli.Flag{
+		&grpcPort,
+		&rpcTLSCert,
+		&rpcMacaroon,
+		&rpcNoTLS,
+		&rpcSocket,
+		&rpcHost,
+		&cli.StringFlag{
+			Name:  "from",
+			Usage: "Report swaps created at or after this date (YYYY-MM-DD or RFC 3339), all of them when empty",
+		},
+		&cli.StringFlag{
+			Name:  "to",
+			Usage: "Report swaps created before this date (YYYY-MM-DD or RFC 3339), up to now when empty",
+		},
+		&cli.StringFlag{
+			Name:  "period",
+			Usage: "Period the totals are aggregated by (day, week or month)",
+			Value: "month",
+		},
+		&cli.StringFlag{
+			Name:  "format",
+			Usage: "Set to csv to print the report as CSV, the default unless --output is given",
+		},
+		&cli.BoolFlag{
+			Name:  "totals",
+			Usage: "Output the totals per period and type instead of the swaps, only for csv",
+		},
+	},
+	Action: func(ctx context.Context, cmd *cli.Command) error {
+		from, err := parseReportTime(cmd.String("from"))
+		if err != nil {
+			return err
+		}
+		to, err := parseReportTime(cmd.String("to"))
+		if err != nil {
+			return err
+		}
+		period, ok := rpc.ReportPeriod_value[strings.ToUpper(cmd.String("period"))]
+		if !ok {
+			return fmt.Errorf("invalid period: %s", cmd.String("period"))
+		}
+		format, err := reportFormat(cmd)
+		if err != nil {
+			return err
+		}
+
+		grpcPort, err := validatePort(cmd.Int("grpc-port"))
+		if err != nil {
+			return err
+		}
+		client, err := rpc.NewRPCClient(cmd.String("rpc-host"), grpcPort, rpcClientConfig(cmd))
+		if err != nil {
+			return err
+		}
+
+		report, err := client.ExportSwaps(ctx, &rpc.ExportSwapsRequest{
+			From:   from,
+			To:     to,
+			Period: rpc.ReportPeriod(period),
+		})
+		if err != nil {
+			return err
+		}
+
+		if format == reportCSV {
+			if cmd.Bool("totals") {
+				return writeReportTotalsCSV(os.Stdout, report.Totals)
+			}
+
+			return writeReportCSV(os.Stdout, report.Swaps)
+		}
+
+		return writeOutput(os.Stdout, format, report)
+	},
+}
+
+// reportFormat is the format the report is printed in. It's CSV unless
+// --output is given, in which case --format csv is needed for CSV.
+func reportFormat(cmd *cli.Command) (string, error) {
+	switch format := cmd.String("format"); format {
+	case reportCSV:
+		return reportCSV, nil
+	case "":
+	default:
+		return "", fmt.Errorf("invalid report format: %s, use --output for json, yaml or table", format)
+	}
+
+	if !cmd.IsSet(outputFlag.Name) {
+		return reportCSV, nil
+	}
+
+	return cmd.String(outputFlag.Name), nil
+}
+
+// parseReportTime parses a date, taken as midnight UTC, or an RFC 3339
+// timestamp. It's nil when empty.
+func parseReportTime(value string) (*timestamppb.Timestamp, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		t, err = time.Parse(time.RFC3339, value)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %q: must be YYYY-MM-DD or RFC 3339", value)
+	}
+
+	return timestamppb.New(t), nil
+}
+
+func writeReportCSV(w io.Writer, swaps []*rpc.SwapReport) error {
+	out := csv.NewWriter(w)
+	err := out.Write([]string{
+		"id", "type", "status", "outcome", "amount_sats", "service_fee_sats", "onchain_fee_sats",
+		"offchain_fee_sats", "total_fee_sats", "lock_tx_id", "claim_tx_id", "refund_tx_id", "created_at", "updated_at",
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, swap := range swaps {
+		err := out.Write([]string{
+			swap.Id,
+			swap.Type,
+			swap.Status.String(),
+			swap.Outcome,
+			strconv.FormatUint(swap.AmountSats, 10),
+			strconv.FormatUint(swap.ServiceFeeSats, 10),
+			strconv.FormatUint(swap.OnchainFeeSats, 10),
+			strconv.FormatUint(swap.OffchainFeeSats, 10),
+			strconv.FormatUint(swap.TotalFeeSats, 10),
+			swap.GetLockTxId(),
+			swap.GetClaimTxId(),
+			swap.GetRefundTxId(),
+			swap.CreatedAt.AsTime().Format(time.RFC3339),
+			swap.UpdatedAt.AsTime().Format(time.RFC3339),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	out.Flush()
+
+	return out.Error()
+}
+
+func writeReportTotalsCSV(w io.Writer, totals []*rpc.SwapReportTotal) error {
+	out := csv.NewWriter(w)
+	err := out.Write([]string{
+		"period", "type", "count", "amount_sats", "service_fee_sats", "onchain_fee_sats", "offchain_fee_sats", "total_fee_sats",
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, total := range totals {
+		err := out.Write([]string{
+			total.Period,
+			total.Type,
+			strconv.FormatUint(uint64(total.Count), 10),
+			strconv.FormatUint(total.AmountSats, 10),
+			strconv.FormatUint(total.ServiceFeeSats, 10),
+			strconv.FormatUint(total.OnchainFeeSats, 10),
+			strconv.FormatUint(total.OffchainFeeSats, 10),
+			strconv.FormatUint(total.TotalFeeSats, 10),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	out.Flush()
+
+	return out.Error()
+}
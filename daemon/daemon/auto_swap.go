@@ -9,7 +9,9 @@ import (
 
 	"github.com/40acres/40swap/daemon/database/models"
 	"github.com/40acres/40swap/daemon/lightning"
+	"github.com/40acres/40swap/daemon/metrics"
 	"github.com/40acres/40swap/daemon/money"
+	"github.com/40acres/40swap/daemon/notifier"
 	"github.com/40acres/40swap/daemon/rpc"
 	swaps "github.com/40acres/40swap/daemon/swaps"
 	log "github.com/sirupsen/logrus"
@@ -22,12 +24,15 @@ type AutoSwapService struct {
 	rpcClient       rpc.SwapServiceClient
 	repository      Repository
 	config          *AutoSwapConfig
+	// notifier is optional, nil when no webhooks are configured
+	notifier *notifier.Notifier
 
 	runningSwaps   []string // List of currently running auto swap IDs
 	runningSwapsMu sync.Mutex
 
 	monitoredSwaps   map[string]struct{} // Set of swapIDs being monitored
 	monitoredSwapsMu sync.Mutex
+	monitors         sync.WaitGroup
 }
 
 // NewAutoSwapService creates a new AutoSwapService with dependencies for reusing existing logic
@@ -67,8 +72,8 @@ func (s *AutoSwapService) RecoverPendingAutoSwaps(ctx context.Context) error {
 		log.Infof("[AutoSwap] Recovering auto swap: %s", swap.SwapID)
 		s.addRunningSwap(swap.SwapID)
 
-		// Start monitoring this swap in the background with a long-lived context
-		go s.monitorSwapUntilTerminal(context.Background(), swap.SwapID)
+		// Start monitoring this swap in the background
+		s.goMonitorSwap(ctx, swap.SwapID)
 	}
 
 	if len(pendingAutoSwaps) > 0 {
@@ -133,6 +138,16 @@ func (s *AutoSwapService) unsetSwapMonitored(swapID string) {
 	delete(s.monitoredSwaps, swapID)
 }
 
+// goMonitorSwap monitors the swap in the background until it's done or the
+// context is cancelled
+func (s *AutoSwapService) goMonitorSwap(ctx context.Context, swapID string) {
+	s.monitors.Add(1)
+	go func() {
+		defer s.monitors.Done()
+		s.monitorSwapUntilTerminal(ctx, swapID)
+	}()
+}
+
 // Monitor a swap until it reaches a terminal state, then remove it from the running list
 func (s *AutoSwapService) monitorSwapUntilTerminal(ctx context.Context, swapID string) {
 	s.setSwapMonitored(swapID)
@@ -181,7 +196,7 @@ func (s *AutoSwapService) RunAutoSwapCheck(ctx context.Context) error {
 		s.runningSwapsMu.Unlock()
 		for _, swapID := range swapsToMonitor {
 			if !s.isSwapBeingMonitored(swapID) {
-				go s.monitorSwapUntilTerminal(context.Background(), swapID)
+				s.goMonitorSwap(ctx, swapID)
 			}
 		}
 
@@ -259,6 +274,7 @@ func (s *AutoSwapService) RunAutoSwapCheck(ctx context.Context) error {
 			}
 
 			swap, err := s.rpcClient.SwapOut(ctx, &swapOutRequest)
+			metrics.AutoSwapAttempt(err)
 			if err != nil {
 				log.Errorf("[AutoSwap] Swap out attempt %d failed: %v", attempt, err)
 				lastErr = err
@@ -280,7 +296,7 @@ func (s *AutoSwapService) RunAutoSwapCheck(ctx context.Context) error {
 			}
 
 			log.Infof("[AutoSwap] Auto swap out completed successfully for swap: %v, now processing swap:", swap.SwapId)
-			go s.monitorSwapUntilTerminal(context.Background(), swap.SwapId)
+			s.goMonitorSwap(ctx, swap.SwapId)
 
 			return nil // Success, exit
 		}
@@ -2,8 +2,6 @@ package daemon
 
 import (
 	"context"
-	"crypto/sha256"
-	"encoding/hex"
 	"errors"
 	"fmt"
 	"sync"
@@ -19,11 +17,6 @@ import (
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
-
-	"github.com/btcsuite/btcd/btcec/v2"
-	"github.com/btcsuite/btcd/btcutil"
-	"github.com/btcsuite/btcd/txscript"
-	"github.com/btcsuite/btcd/wire"
 )
 
 // Test helpers
@@ -82,28 +75,7 @@ func TestRefundFallbackInAutoSwapSuite(t *testing.T) {
 
 	ctx := context.Background()
 
-	// Build redeem script that is compatible with SignFinishExtractPSBT preimage witness
-	// Script: OP_DROP <pubkey> OP_CHECKSIG
-	privBytes, err := hex.DecodeString(validPrivateKeyForPsbt)
-	require.NoError(t, err)
-	privKey, _ := btcec.PrivKeyFromBytes(privBytes)
-	pubKey := privKey.PubKey().SerializeCompressed()
-
-	sb := txscript.NewScriptBuilder()
-	sb.AddOp(txscript.OP_DROP)
-	sb.AddData(pubKey)
-	sb.AddOp(txscript.OP_CHECKSIG)
-	redeemScript, err := sb.Script()
-	require.NoError(t, err)
-
-	// Create spending tx with P2WSH output
-	scriptHash := sha256.Sum256(redeemScript)
-	addr, err := btcutil.NewAddressWitnessScriptHash(scriptHash[:], lightning.ToChainCfgNetwork(lightning.Regtest))
-	require.NoError(t, err)
-	pkScript, err := txscript.PayToAddrScript(addr)
-	require.NoError(t, err)
-	spendingTx := wire.NewMsgTx(2)
-	spendingTx.AddTxOut(wire.NewTxOut(100000, pkScript))
+	spendingTx, claimAddress, redeemScript := refundableLockTx(t)
 
 	// Expectations
 	bitcoinClient.EXPECT().GetRecommendedFees(ctx, bitcoin.HalfHourFee).Return(int64(10), nil)
@@ -117,14 +89,14 @@ func TestRefundFallbackInAutoSwapSuite(t *testing.T) {
 		SwapID:             "abc",
 		RefundAddress:      "bcrt1q76kh4zg0vfkt7yy8dz8tpfwqgcnm0pxd76az73d8wmqgln5640fsdy0mjx",
 		RefundPrivatekey:   validPrivateKeyForPsbt,
-		ClaimAddress:       addr.String(),
-		RedeemScript:       hex.EncodeToString(redeemScript),
+		ClaimAddress:       claimAddress,
+		RedeemScript:       redeemScript,
 		TimeoutBlockHeight: 1000,
 		LockTxID:           "some-lock-txid",
 	}
 
 	// Run
-	_, err = monitor.InitiateRefund(ctx, &swap)
+	_, err := monitor.InitiateRefund(ctx, &swap)
 	require.NoError(t, err)
 }
 
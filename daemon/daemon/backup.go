@@ -0,0 +1,120 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/40acres/40swap/daemon/database"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	DefaultBackupKeep  = 7
+	backupFilePrefix   = "40swapd-backup-"
+	backupFileTimeFmt  = "20060102T150405Z"
+	backupFileSuffix   = ".json"
+	encryptedBackupExt = ".enc"
+)
+
+// BackupConfig configures the scheduled backups of the database
+type BackupConfig struct {
+	Dir      string
+	Interval time.Duration
+	// Keep is how many of the latest backups are kept in Dir
+	Keep int
+	// Passphrase encrypts the backups, they are written in clear when empty
+	Passphrase string
+}
+
+//go:generate go tool mockgen -destination=mock_backupper.go -package=daemon . Backupper
+type Backupper interface {
+	Backup(ctx context.Context) (*database.Backup, error)
+}
+
+// Backups writes a backup of the swaps to a directory on an interval,
+// keeping only the latest ones.
+type Backups struct {
+	db     Backupper
+	config BackupConfig
+	now    func() time.Time
+}
+
+func NewBackups(db Backupper, config BackupConfig) *Backups {
+	if config.Keep <= 0 {
+		config.Keep = DefaultBackupKeep
+	}
+
+	return &Backups{
+		db:     db,
+		config: config,
+		now:    time.Now,
+	}
+}
+
+// Run backs up the database right away and then every interval until the
+// context is cancelled. Failed backups are logged and retried on the next
+// interval.
+func (b *Backups) Run(ctx context.Context) {
+	log.Infof("Backing up the database to %s every %s", b.config.Dir, b.config.Interval)
+	ticker := time.NewTicker(b.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		if path, err := b.backup(ctx); err != nil {
+			log.Errorf("Database backup failed: %v", err)
+		} else {
+			log.Infof("Database backed up to %s", path)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (b *Backups) backup(ctx context.Context) (string, error) {
+	backup, err := b.db.Backup(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(b.config.Dir, 0o700); err != nil {
+		return "", fmt.Errorf("could not create backup directory: %w", err)
+	}
+
+	name := backupFilePrefix + b.now().UTC().Format(backupFileTimeFmt) + backupFileSuffix
+	if b.config.Passphrase != "" {
+		name += encryptedBackupExt
+	}
+	path := filepath.Join(b.config.Dir, name)
+	if err := database.WriteBackupFile(path, backup, b.config.Passphrase); err != nil {
+		return "", err
+	}
+
+	return path, b.prune()
+}
+
+// prune removes all but the latest config.Keep backups. The timestamps in
+// the names sort them chronologically.
+func (b *Backups) prune() error {
+	backups, err := filepath.Glob(filepath.Join(b.config.Dir, backupFilePrefix+"*"+backupFileSuffix+"*"))
+	if err != nil {
+		return fmt.Errorf("could not list backups: %w", err)
+	}
+	slices.Sort(backups)
+
+	for len(backups) > b.config.Keep {
+		if err := os.Remove(backups[0]); err != nil {
+			return fmt.Errorf("could not remove old backup: %w", err)
+		}
+		backups = backups[1:]
+	}
+
+	return nil
+}
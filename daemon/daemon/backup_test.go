@@ -0,0 +1,58 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/40acres/40swap/daemon/database"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestBackups(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	db := NewMockBackupper(ctrl)
+	dir := t.TempDir()
+
+	backups := NewBackups(db, BackupConfig{
+		Dir:        filepath.Join(dir, "backups"),
+		Interval:   time.Hour,
+		Keep:       2,
+		Passphrase: "secret",
+	})
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	backups.now = func() time.Time {
+		now = now.Add(time.Hour)
+
+		return now
+	}
+
+	db.EXPECT().Backup(ctx).Return(&database.Backup{SchemaVersion: "1"}, nil).Times(3)
+	for range 3 {
+		_, err := backups.backup(ctx)
+		require.NoError(t, err)
+	}
+
+	// Only the latest backups are kept
+	entries, err := os.ReadDir(filepath.Join(dir, "backups"))
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, "40swapd-backup-20250101T020000Z.json.enc", entries[0].Name())
+	require.Equal(t, "40swapd-backup-20250101T030000Z.json.enc", entries[1].Name())
+
+	file, err := os.Open(filepath.Join(dir, "backups", entries[1].Name()))
+	require.NoError(t, err)
+	defer file.Close()
+	backup, err := database.ReadBackup(file, "secret")
+	require.NoError(t, err)
+	require.Equal(t, "1", backup.SchemaVersion)
+
+	db.EXPECT().Backup(ctx).Return(nil, errors.New("database is down"))
+	_, err = backups.backup(ctx)
+	require.ErrorContains(t, err, "database is down")
+}
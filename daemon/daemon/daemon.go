@@ -4,13 +4,18 @@ package daemon
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/40acres/40swap/daemon/bitcoin"
 	"github.com/40acres/40swap/daemon/database"
+	"github.com/40acres/40swap/daemon/database/models"
 	"github.com/40acres/40swap/daemon/lightning"
+	"github.com/40acres/40swap/daemon/metrics"
+	"github.com/40acres/40swap/daemon/notifier"
 	"github.com/40acres/40swap/daemon/rpc"
 	swaps "github.com/40acres/40swap/daemon/swaps"
+	"github.com/40acres/40swap/daemon/tracing"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -19,57 +24,160 @@ const MONITORING_INTERVAL_SECONDS = 10
 type Repository interface {
 	database.SwapInRepository
 	database.SwapOutRepository
+	database.SwapEventRepository
 }
 
-func Start(ctx context.Context, server *rpc.Server, db Repository, swaps swaps.ClientInterface, lightning lightning.Client, bitcoin bitcoin.Client, network lightning.Network, autoSwapService *AutoSwapService) error {
-	log.Infof("Starting 40swapd on network %s", network)
+// Config holds the clients and settings the daemon runs with. The optional
+// subsystems are disabled when left nil.
+type Config struct {
+	Server     *rpc.Server
+	Repository Repository
+	Swaps      swaps.ClientInterface
+	Lightning  lightning.Client
+	Bitcoin    bitcoin.Client
+	Network    lightning.Network
+	// NotFoundGracePeriod is how long a swap the server doesn't know about is
+	// kept before it's failed
+	NotFoundGracePeriod time.Duration
+	AutoSwap            *AutoSwapService
+	Webhooks            *notifier.Notifier
+	Scheduler           SchedulerConfig
+	// ShutdownTimeout is how long the subsystems are given to stop, it's also
+	// the drain timeout of the scheduler
+	ShutdownTimeout time.Duration
+	// Elector runs the subsystems acting on swaps only while this daemon is
+	// the leader, they always run when it's nil
+	Elector *Elector
+	Backups *Backups
+	Fiat    FiatConfig
+	// MetricsAddr is where the Prometheus metrics are served, they aren't
+	// served when it's empty
+	MetricsAddr string
+}
+
+func Start(ctx context.Context, config Config) error {
+	server := config.Server
+	log.Infof("Starting 40swapd on network %s", config.Network)
 
-	config, err := swaps.GetConfiguration(ctx)
+	swapConfig, err := config.Swaps.GetConfiguration(ctx)
 	if err != nil {
 		return err
 	}
-	if config.BitcoinNetwork != network {
-		return fmt.Errorf("network mismatch: daemon expected %s, server's got %s", network, config.BitcoinNetwork)
+	if swapConfig.BitcoinNetwork != config.Network {
+		return fmt.Errorf("network mismatch: daemon expected %s, server's got %s", config.Network, swapConfig.BitcoinNetwork)
 	}
 
-	go func() {
-		err := server.ListenAndServe()
-		if err != nil {
-			log.Fatalf("couldn't start server: %v", err)
-		}
-	}()
+	supervisor := NewSupervisor(ctx, server)
+	supervisor.Go("rpc", func(ctx context.Context) error {
+		return serveRPC(ctx, server)
+	})
+	if server.GatewayEnabled() {
+		supervisor.Go("rest", server.ServeGateway)
+	}
+	if config.MetricsAddr != "" {
+		supervisor.Go("metrics", func(ctx context.Context) error {
+			return metrics.ListenAndServe(ctx, config.MetricsAddr)
+		})
+	}
+
+	monitor := &SwapMonitor{
+		repository:      config.Repository,
+		swapClient:      config.Swaps,
+		lightningClient: config.Lightning,
+		network:         config.Network,
+		now:             time.Now,
+		bitcoin:         config.Bitcoin,
+		notifier:        config.Webhooks,
+		fiat:            config.Fiat,
 
-	// Start the auto swap loop in a goroutine if auto swap service is provided
-	if autoSwapService != nil {
-		go StartAutoSwapLoop(ctx, autoSwapService)
+		notFoundGracePeriod: config.NotFoundGracePeriod,
+	}
+	schedulerConfig := config.Scheduler
+	schedulerConfig.DrainTimeout = config.ShutdownTimeout
+	if config.AutoSwap != nil {
+		config.AutoSwap.notifier = config.Webhooks
 	}
 
-	// monitor every 10 seconds
-	for {
-		select {
-		case <-ctx.Done():
-			log.Info("Shutting down 40swapd")
+	// The subsystems acting on swaps only run on the leader
+	superviseLeader := func(supervisor *Supervisor) {
+		if config.Webhooks != nil {
+			supervisor.Go("notifier", func(ctx context.Context) error {
+				config.Webhooks.Run(ctx, notifier.DefaultPollInterval)
+
+				return nil
+			})
+		}
+
+		if config.AutoSwap != nil {
+			supervisor.Go("autoswap", func(ctx context.Context) error {
+				StartAutoSwapLoop(ctx, config.AutoSwap)
+
+				return nil
+			})
+		}
+
+		supervisor.Go("monitor", func(ctx context.Context) error {
+			NewScheduler(monitor, schedulerConfig).Run(ctx)
 
 			return nil
-		default:
-			monitor := &SwapMonitor{
-				repository:      db,
-				swapClient:      swaps,
-				lightningClient: lightning,
-				network:         network,
-				now:             time.Now,
-				bitcoin:         bitcoin,
-			}
-			monitor.MonitorSwaps(ctx)
+		})
+
+		if config.Backups != nil {
+			supervisor.Go("backup", func(ctx context.Context) error {
+				config.Backups.Run(ctx)
 
-			time.Sleep(MONITORING_INTERVAL_SECONDS * time.Second)
+				return nil
+			})
 		}
 	}
+
+	if config.Elector == nil {
+		superviseLeader(supervisor)
+	} else {
+		server.SetLeader(false)
+		supervisor.Go("election", func(ctx context.Context) error {
+			return config.Elector.Run(ctx, func(ctx context.Context) {
+				leader := supervisor.Nested(ctx)
+				superviseLeader(leader)
+				if err := leader.Wait(config.ShutdownTimeout); err != nil {
+					log.Errorf("failed to stop leader subsystems: %v", err)
+				}
+			})
+		})
+	}
+
+	err = supervisor.Wait(config.ShutdownTimeout)
+	for _, subsystem := range supervisor.Health() {
+		log.Debugf("subsystem %s: %s %s", subsystem.Name, subsystem.State, subsystem.Error)
+	}
+	log.Info("40swapd stopped")
+
+	return err
+}
+
+// serveRPC serves the gRPC API until the context is cancelled, letting the
+// calls in flight finish before returning
+func serveRPC(ctx context.Context, server *rpc.Server) error {
+	errs := make(chan error, 1)
+	go func() {
+		errs <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+		server.Stop()
+
+		return <-errs
+	}
 }
 
 // StartAutoSwapLoop runs the auto swap check every config.GetCheckInterval()
+// until the context is cancelled, then waits for the swaps it's monitoring
 func StartAutoSwapLoop(ctx context.Context, autoSwapService *AutoSwapService) {
 	log.Infof("[AutoSwap] Starting auto swap loop")
+	defer autoSwapService.monitors.Wait()
 
 	// Recover any pending auto swaps from the database
 	if err := autoSwapService.RecoverPendingAutoSwaps(ctx); err != nil {
@@ -77,19 +185,21 @@ func StartAutoSwapLoop(ctx context.Context, autoSwapService *AutoSwapService) {
 	}
 
 	for {
+		// Run the auto swap check
+		if err := autoSwapService.RunAutoSwapCheck(ctx); err != nil && ctx.Err() == nil {
+			log.Errorf("[AutoSwap] Auto swap check failed: %v", err)
+			autoSwapService.notifier.NotifyAutoSwapFailure(ctx, err)
+		}
+
+		// Wait for the configured interval
+		timer := time.NewTimer(autoSwapService.GetCheckInterval())
 		select {
 		case <-ctx.Done():
+			timer.Stop()
 			log.Info("[AutoSwap] Shutting down auto swap loop")
 
 			return
-		default:
-			// Run the auto swap check
-			if err := autoSwapService.RunAutoSwapCheck(ctx); err != nil {
-				log.Errorf("[AutoSwap] Auto swap check failed: %v", err)
-			}
-
-			// Wait for the configured interval
-			time.Sleep(autoSwapService.GetCheckInterval())
+		case <-timer.C:
 		}
 	}
 }
@@ -101,38 +211,118 @@ type SwapMonitor struct {
 	network         lightning.Network
 	now             func() time.Time
 	bitcoin         bitcoin.Client
+	// notifier is optional, nil when no webhooks are configured
+	notifier *notifier.Notifier
+	fiat     FiatConfig
+
+	// notFoundGracePeriod is how long swaps the server reports as not found
+	// are retried before they can be marked as failed.
+	notFoundGracePeriod time.Duration
+
+	// blockHeight is the last chain tip height seen by the monitor, zero
+	// while it is still unknown.
+	blockHeight atomic.Int64
+	// onNewBlock is called whenever the block height moves forward
+	onNewBlock func(height int64)
 }
 
-func (m *SwapMonitor) MonitorSwaps(ctx context.Context) {
-	swapIns, err := m.repository.GetPendingSwapIns(ctx)
-	if err != nil {
-		log.Errorf("failed to get pending swap ins: %v", err)
+// BlockHeight returns the last known chain tip height, or zero if unknown.
+func (m *SwapMonitor) BlockHeight() int64 {
+	return m.blockHeight.Load()
+}
 
-		return
+// SetBlockHeight records a new chain tip height. Heights lower than the
+// current one are ignored so that a lagging backend can't move us backwards.
+func (m *SwapMonitor) SetBlockHeight(height int64) {
+	for {
+		current := m.blockHeight.Load()
+		if height <= current {
+			return
+		}
+		if m.blockHeight.CompareAndSwap(current, height) {
+			log.Debugf("new block height: %d", height)
+			if m.onNewBlock != nil {
+				m.onNewBlock(height)
+			}
+
+			return
+		}
 	}
+}
 
-	swapOuts, err := m.repository.GetPendingSwapOuts(ctx)
+// TrackBlockHeight subscribes to new blocks and records their heights until
+// the context is cancelled.
+func (m *SwapMonitor) TrackBlockHeight(ctx context.Context) {
+	for {
+		blocks, err := m.bitcoin.SubscribeBlocks(ctx)
+		if err != nil {
+			log.Errorf("failed to subscribe to new blocks: %v", err)
+		} else {
+			for height := range blocks {
+				m.SetBlockHeight(height)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(MONITORING_INTERVAL_SECONDS * time.Second):
+		}
+	}
+}
+
+// refreshBlockHeight fetches the current chain tip height so swaps are
+// evaluated against an up-to-date height even if the subscription lags.
+func (m *SwapMonitor) refreshBlockHeight(ctx context.Context) {
+	height, err := m.bitcoin.GetBlockHeight(ctx)
 	if err != nil {
-		log.Errorf("failed to get pending swap outs: %v", err)
+		log.Warnf("failed to get block height, using last known height %d: %v", m.BlockHeight(), err)
 
 		return
 	}
 
-	for _, swapIn := range swapIns {
-		err := m.MonitorSwapIn(ctx, swapIn)
-		if err != nil {
-			log.Errorf("failed to monitor swap in: %v", err)
+	m.SetBlockHeight(height)
+}
 
-			continue
-		}
+// recordEvent stores an event in the swap history. Failing to store it is
+// logged but never interrupts the caller.
+func recordEvent(ctx context.Context, repository database.SwapEventRepository, event *models.SwapEvent) {
+	metrics.ObserveSwapEvent(event)
+	if err := repository.SaveSwapEvent(ctx, event); err != nil {
+		log.WithField("id", event.SwapID).Errorf("failed to save swap event: %v", err)
 	}
+}
 
-	for _, swapOut := range swapOuts {
-		err := m.MonitorSwapOut(ctx, swapOut)
-		if err != nil {
-			log.Errorf("failed to monitor swap out: %v", err)
+func swapInStatuses(swapIns []*models.SwapIn) []models.SwapStatus {
+	statuses := make([]models.SwapStatus, 0, len(swapIns))
+	for _, swapIn := range swapIns {
+		statuses = append(statuses, swapIn.Status)
+	}
 
-			continue
-		}
+	return statuses
+}
+
+func swapOutStatuses(swapOuts []*models.SwapOut) []models.SwapStatus {
+	statuses := make([]models.SwapStatus, 0, len(swapOuts))
+	for _, swapOut := range swapOuts {
+		statuses = append(statuses, swapOut.Status)
 	}
+
+	return statuses
+}
+
+// recordEvent stores an event in the swap history and notifies the webhooks
+// about it
+func (m *SwapMonitor) recordEvent(ctx context.Context, event *models.SwapEvent) {
+	recordEvent(ctx, m.repository, event)
+	m.notifier.NotifySwapEvent(ctx, event)
+}
+
+// traceSwap runs a monitor iteration of a single swap in its own span
+func traceSwap(ctx context.Context, name, swapID string, monitor func(context.Context) error) error {
+	ctx, span := tracing.Start(ctx, name, tracing.SwapIDKey.String(swapID))
+	err := monitor(ctx)
+	tracing.End(span, err)
+
+	return err
 }
@@ -2,16 +2,22 @@ package daemon
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/hex"
 	"errors"
 	"testing"
 	"time"
 
 	"github.com/40acres/40swap/daemon/bitcoin"
+	"github.com/40acres/40swap/daemon/database"
 	"github.com/40acres/40swap/daemon/database/models"
 	"github.com/40acres/40swap/daemon/lightning"
 	"github.com/40acres/40swap/daemon/rpc"
 	"github.com/40acres/40swap/daemon/swaps"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
 	"github.com/lightningnetwork/lnd/lntypes"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -43,6 +49,7 @@ func Test_MonitorSwapIns(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	repository := rpc.NewMockRepository(ctrl)
+	repository.EXPECT().SaveSwapEvent(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 	swapClient := swaps.NewMockClientInterface(ctrl)
 	lightningClient := lightning.NewMockClient(ctrl)
 	bitcoinClient := bitcoin.NewMockClient(ctrl)
@@ -67,6 +74,7 @@ func Test_MonitorSwapIns(t *testing.T) {
 	outcomeRefunded := models.OutcomeRefunded
 	outcomeExpired := models.OutcomeExpired
 	outcomeSuccess := models.OutcomeSuccess
+	notFoundSince := now()
 	tests := []struct {
 		name  string
 		setup func()
@@ -82,9 +90,10 @@ func Test_MonitorSwapIns(t *testing.T) {
 				SwapID: testSwapId,
 			},
 			want: &models.SwapIn{
-				SwapID:  testSwapId,
-				Outcome: &outcomeFailed,
-				Status:  models.StatusDone,
+				SwapID:        testSwapId,
+				Outcome:       &outcomeFailed,
+				Status:        models.StatusDone,
+				NotFoundSince: &notFoundSince,
 			},
 		},
 		{
@@ -213,7 +222,7 @@ func Test_MonitorSwapIns(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setup()
 			if tt.want != nil {
-				repository.EXPECT().SaveSwapIn(ctx, tt.want).Return(nil)
+				repository.EXPECT().SaveSwapInIf(ctx, tt.want, tt.req.Status).Return(nil)
 			}
 
 			err := swapMonitor.MonitorSwapIn(ctx, &tt.req)
@@ -283,8 +292,8 @@ func Test_Refund(t *testing.T) {
 					// This is a valid Bitcoin transaction in hex format
 					LockTx: stringPtr("020000000001010a8c9a4185c21121bbfce347638fd537a221d9c7509870c62c835e43471324470100000000fdffffff0267789ad0000000002251207334a2da5532326422535efcb08a3383f8aa0f0be9628f36bae448a327f246da2d1103000000000022002025f32afca1be933158d98b3ee76a1d128ca236712207d2c9b622b921946f47f5024730440220020eb2facf317185921a371c89515541c74fe62f09cacfe30e9a3cfaa813599702202e26e063c153a7f2843f54e82d93cb01202c385827a1e399674d3d04dcb78ee2012103b4a60e3f2a977725a3348b4182c0fb6fff1f22eb5b4d46284c9982c02dd323097e000000"),
 				}, nil)
-				// Mock the SaveSwapIn call that will be made after getting LockTxID from backend
-				repository.EXPECT().SaveSwapIn(ctx, gomock.Any()).Return(nil)
+				// Mock the SaveSwapInIf call that will be made after getting LockTxID from backend
+				repository.EXPECT().SaveSwapInIf(ctx, gomock.Any(), gomock.Any()).Return(nil)
 				// Mock the GetTxFromTxID call that PSBTBuilder will make
 				bitcoinClient.EXPECT().GetTxFromTxID(ctx, "fa44086e23eabeb3413b61cbc78e056c9c9712185262712db1841bb14643af6a").Return(nil, errors.New("failed to get transaction"))
 			},
@@ -326,3 +335,601 @@ func Test_Refund(t *testing.T) {
 		})
 	}
 }
+
+func Test_MonitorSwapIn_BlockHeightExpiry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repository := rpc.NewMockRepository(ctrl)
+	repository.EXPECT().SaveSwapEvent(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	swapClient := swaps.NewMockClientInterface(ctrl)
+	bitcoinClient := bitcoin.NewMockClient(ctrl)
+	now := func() time.Time {
+		return time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)
+	}
+	ctx := context.Background()
+	lockTx, claimAddress, redeemScript := refundableLockTx(t)
+	// saved is a copy of the swap in as the monitor saved it
+	var saved *models.SwapIn
+	saveSwapIn := func(_ context.Context, swap *models.SwapIn, _ models.SwapStatus) error {
+		copied := *swap
+		saved = &copied
+
+		return nil
+	}
+
+	tests := []struct {
+		name        string
+		blockHeight int64
+		setup       func()
+		req         models.SwapIn
+		wantErr     string
+		wantRefund  bool
+	}{
+		{
+			name:        "Server offline, contract not expired",
+			blockHeight: 99,
+			setup: func() {
+				swapClient.EXPECT().GetSwapIn(ctx, testSwapId).Return(nil, errors.New("connection refused"))
+			},
+			req: models.SwapIn{
+				SwapID:             testSwapId,
+				Status:             models.StatusContractFunded,
+				TimeoutBlockHeight: 100,
+				LockTxID:           "some-tx-id",
+			},
+			wantErr: "failed to get swap in",
+		},
+		{
+			name:        "Server offline, contract expired",
+			blockHeight: 100,
+			setup: func() {
+				swapClient.EXPECT().GetSwapIn(ctx, testSwapId).Return(nil, errors.New("connection refused"))
+				bitcoinClient.EXPECT().GetRecommendedFees(ctx, bitcoin.HalfHourFee).Return(int64(10), nil)
+				bitcoinClient.EXPECT().GetTxFromTxID(ctx, "some-tx-id").Return(nil, errors.New("transaction not found"))
+			},
+			req: models.SwapIn{
+				SwapID:             testSwapId,
+				Status:             models.StatusContractFunded,
+				TimeoutBlockHeight: 100,
+				RefundAddress:      validRefundAddress,
+				RefundPrivatekey:   validPrivateKeyForPsbt,
+				LockTxID:           "some-tx-id",
+			},
+			wantErr: "failed to initiate refund",
+		},
+		{
+			name:        "Server offline, contract expired and refunded",
+			blockHeight: 100,
+			setup: func() {
+				swapClient.EXPECT().GetSwapIn(ctx, testSwapId).Return(nil, errors.New("connection refused"))
+				bitcoinClient.EXPECT().GetRecommendedFees(ctx, bitcoin.HalfHourFee).Return(int64(10), nil)
+				bitcoinClient.EXPECT().GetTxFromTxID(ctx, "some-tx-id").Return(lockTx, nil)
+				bitcoinClient.EXPECT().PostRefund(ctx, gomock.Any()).Return(nil)
+				repository.EXPECT().SaveSwapInIf(ctx, gomock.Any(), gomock.Any()).DoAndReturn(saveSwapIn)
+			},
+			req: models.SwapIn{
+				SwapID:             testSwapId,
+				Status:             models.StatusContractFunded,
+				TimeoutBlockHeight: 100,
+				RefundAddress:      validRefundAddress,
+				RefundPrivatekey:   validPrivateKeyForPsbt,
+				ClaimAddress:       claimAddress,
+				RedeemScript:       redeemScript,
+				LockTxID:           "some-tx-id",
+			},
+			wantRefund: true,
+		},
+		{
+			name:        "Server offline, contract expired and refund in progress",
+			blockHeight: 120,
+			setup: func() {
+				swapClient.EXPECT().GetSwapIn(ctx, testSwapId).Return(nil, errors.New("connection refused"))
+			},
+			req: models.SwapIn{
+				SwapID:             testSwapId,
+				Status:             models.StatusContractExpired,
+				TimeoutBlockHeight: 100,
+				LockTxID:           "some-tx-id",
+				RefundRequestedAt:  now(),
+			},
+		},
+		{
+			name:        "Server reports funded contract after expiry",
+			blockHeight: 101,
+			setup: func() {
+				swapClient.EXPECT().GetSwapIn(ctx, testSwapId).Return(&swaps.SwapInResponse{
+					Status:             models.StatusContractFunded,
+					TimeoutBlockHeight: 100,
+				}, nil)
+				bitcoinClient.EXPECT().GetRecommendedFees(ctx, bitcoin.HalfHourFee).Return(int64(10), nil)
+				bitcoinClient.EXPECT().GetTxFromTxID(ctx, "some-tx-id").Return(nil, errors.New("transaction not found"))
+			},
+			req: models.SwapIn{
+				SwapID:             testSwapId,
+				Status:             models.StatusContractFunded,
+				TimeoutBlockHeight: 100,
+				RefundAddress:      validRefundAddress,
+				RefundPrivatekey:   validPrivateKeyForPsbt,
+				LockTxID:           "some-tx-id",
+			},
+			wantErr: "failed to initiate refund",
+		},
+		{
+			name:        "Server reports funded contract after expiry and it's refunded",
+			blockHeight: 101,
+			setup: func() {
+				swapClient.EXPECT().GetSwapIn(ctx, testSwapId).Return(&swaps.SwapInResponse{
+					Status:             models.StatusContractFunded,
+					TimeoutBlockHeight: 100,
+				}, nil)
+				bitcoinClient.EXPECT().GetRecommendedFees(ctx, bitcoin.HalfHourFee).Return(int64(10), nil)
+				bitcoinClient.EXPECT().GetTxFromTxID(ctx, "some-tx-id").Return(lockTx, nil)
+				bitcoinClient.EXPECT().PostRefund(ctx, gomock.Any()).Return(nil)
+				repository.EXPECT().SaveSwapInIf(ctx, gomock.Any(), gomock.Any()).DoAndReturn(saveSwapIn)
+			},
+			req: models.SwapIn{
+				SwapID:             testSwapId,
+				Status:             models.StatusContractFunded,
+				TimeoutBlockHeight: 100,
+				RefundAddress:      validRefundAddress,
+				RefundPrivatekey:   validPrivateKeyForPsbt,
+				ClaimAddress:       claimAddress,
+				RedeemScript:       redeemScript,
+				LockTxID:           "some-tx-id",
+			},
+			wantRefund: true,
+		},
+		{
+			name:        "Unknown block height trusts the server",
+			blockHeight: 0,
+			setup: func() {
+				swapClient.EXPECT().GetSwapIn(ctx, testSwapId).Return(&swaps.SwapInResponse{
+					Status:             models.StatusContractFunded,
+					TimeoutBlockHeight: 100,
+				}, nil)
+			},
+			req: models.SwapIn{
+				SwapID:             testSwapId,
+				Status:             models.StatusContractFunded,
+				TimeoutBlockHeight: 100,
+				LockTxID:           "some-tx-id",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			swapMonitor := &SwapMonitor{
+				repository: repository,
+				swapClient: swapClient,
+				bitcoin:    bitcoinClient,
+				network:    lightning.Regtest,
+				now:        now,
+			}
+			swapMonitor.SetBlockHeight(tt.blockHeight)
+			saved = nil
+			tt.setup()
+
+			err := swapMonitor.MonitorSwapIn(ctx, &tt.req)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			if tt.wantRefund {
+				require.NotNil(t, saved)
+				require.Equal(t, models.StatusContractExpired, saved.Status)
+				require.Len(t, saved.RefundTxID, 64)
+				require.Equal(t, now(), saved.RefundRequestedAt)
+			}
+		})
+	}
+}
+
+func Test_MonitorSwapIn_RefundedWhileCancelled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repository := rpc.NewMockRepository(ctrl)
+	repository.EXPECT().SaveSwapEvent(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	swapClient := swaps.NewMockClientInterface(ctrl)
+	bitcoinClient := bitcoin.NewMockClient(ctrl)
+	now := func() time.Time {
+		return time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)
+	}
+	ctx := context.Background()
+	lockTx, claimAddress, redeemScript := refundableLockTx(t)
+	swapMonitor := &SwapMonitor{
+		repository: repository,
+		swapClient: swapClient,
+		bitcoin:    bitcoinClient,
+		network:    lightning.Regtest,
+		now:        now,
+	}
+	swapMonitor.SetBlockHeight(100)
+
+	cancelled := models.OutcomeCancelled
+	var saved *models.SwapIn
+	swapClient.EXPECT().GetSwapIn(ctx, testSwapId).Return(nil, errors.New("connection refused"))
+	bitcoinClient.EXPECT().GetRecommendedFees(ctx, bitcoin.HalfHourFee).Return(int64(10), nil)
+	bitcoinClient.EXPECT().GetTxFromTxID(ctx, "some-tx-id").Return(lockTx, nil)
+	bitcoinClient.EXPECT().PostRefund(ctx, gomock.Any()).Return(nil)
+	// The swap was cancelled while the refund was broadcast
+	repository.EXPECT().SaveSwapInIf(ctx, gomock.Any(), models.StatusContractFunded).Return(database.ErrSwapChanged)
+	repository.EXPECT().GetSwapIn(ctx, testSwapId).Return(&models.SwapIn{
+		SwapID:             testSwapId,
+		Status:             models.StatusDone,
+		Outcome:            &cancelled,
+		TimeoutBlockHeight: 100,
+		LockTxID:           "some-tx-id",
+	}, nil)
+	repository.EXPECT().SaveSwapInIf(ctx, gomock.Any(), models.StatusDone).DoAndReturn(func(_ context.Context, swap *models.SwapIn, _ models.SwapStatus) error {
+		copied := *swap
+		saved = &copied
+
+		return nil
+	})
+
+	swap := &models.SwapIn{
+		SwapID:             testSwapId,
+		Status:             models.StatusContractFunded,
+		TimeoutBlockHeight: 100,
+		RefundAddress:      validRefundAddress,
+		RefundPrivatekey:   validPrivateKeyForPsbt,
+		ClaimAddress:       claimAddress,
+		RedeemScript:       redeemScript,
+		LockTxID:           "some-tx-id",
+	}
+	err := swapMonitor.MonitorSwapIn(ctx, swap)
+	require.NoError(t, err)
+
+	// The refund is kept on top of the cancellation
+	require.NotNil(t, saved)
+	require.Equal(t, models.StatusDone, saved.Status)
+	require.Equal(t, &cancelled, saved.Outcome)
+	require.Len(t, saved.RefundTxID, 64)
+	require.Equal(t, now(), saved.RefundRequestedAt)
+	require.Equal(t, saved, swap)
+}
+
+// refundableLockTx builds a lock transaction paying to a contract the refund
+// key of validPrivateKeyForPsbt can spend, along with the contract address and
+// redeem script
+func refundableLockTx(t *testing.T) (*wire.MsgTx, string, string) {
+	t.Helper()
+
+	// Script compatible with the SignFinishExtractPSBT preimage witness:
+	// OP_DROP <pubkey> OP_CHECKSIG
+	privBytes, err := hex.DecodeString(validPrivateKeyForPsbt)
+	require.NoError(t, err)
+	privKey, _ := btcec.PrivKeyFromBytes(privBytes)
+	sb := txscript.NewScriptBuilder()
+	sb.AddOp(txscript.OP_DROP)
+	sb.AddData(privKey.PubKey().SerializeCompressed())
+	sb.AddOp(txscript.OP_CHECKSIG)
+	redeemScript, err := sb.Script()
+	require.NoError(t, err)
+
+	scriptHash := sha256.Sum256(redeemScript)
+	addr, err := btcutil.NewAddressWitnessScriptHash(scriptHash[:], lightning.ToChainCfgNetwork(lightning.Regtest))
+	require.NoError(t, err)
+	pkScript, err := txscript.PayToAddrScript(addr)
+	require.NoError(t, err)
+	lockTx := wire.NewMsgTx(2)
+	lockTx.AddTxOut(wire.NewTxOut(100000, pkScript))
+
+	return lockTx, addr.String(), hex.EncodeToString(redeemScript)
+}
+
+// lockTxPayingTo builds a transaction with a single output paying to address
+func lockTxPayingTo(t *testing.T, address string) *wire.MsgTx {
+	t.Helper()
+
+	decoded, err := btcutil.DecodeAddress(address, lightning.ToChainCfgNetwork(lightning.Regtest))
+	require.NoError(t, err)
+	pkScript, err := txscript.PayToAddrScript(decoded)
+	require.NoError(t, err)
+
+	tx := wire.NewMsgTx(2)
+	tx.AddTxOut(wire.NewTxOut(100000, pkScript))
+
+	return tx
+}
+
+func Test_MonitorSwapIn_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repository := rpc.NewMockRepository(ctrl)
+	repository.EXPECT().SaveSwapEvent(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	swapClient := swaps.NewMockClientInterface(ctrl)
+	bitcoinClient := bitcoin.NewMockClient(ctrl)
+	now := func() time.Time {
+		return time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)
+	}
+	ctx := context.Background()
+	swapMonitor := &SwapMonitor{
+		repository:          repository,
+		swapClient:          swapClient,
+		bitcoin:             bitcoinClient,
+		network:             lightning.Regtest,
+		now:                 now,
+		notFoundGracePeriod: time.Hour,
+	}
+
+	firstSeen := now()
+	withinGrace := now().Add(-10 * time.Minute)
+	afterGrace := now().Add(-2 * time.Hour)
+	outcomeFailed := models.OutcomeFailed
+	tests := []struct {
+		name    string
+		setup   func()
+		req     models.SwapIn
+		want    *models.SwapIn
+		wantErr string
+	}{
+		{
+			name: "First time not found starts the grace period",
+			setup: func() {
+				swapClient.EXPECT().GetSwapIn(ctx, testSwapId).Return(nil, swaps.ErrSwapNotFound)
+			},
+			req: models.SwapIn{
+				SwapID: testSwapId,
+				Status: models.StatusContractFunded,
+			},
+			want: &models.SwapIn{
+				SwapID:        testSwapId,
+				Status:        models.StatusContractFunded,
+				NotFoundSince: &firstSeen,
+			},
+		},
+		{
+			name: "Not found within the grace period",
+			setup: func() {
+				swapClient.EXPECT().GetSwapIn(ctx, testSwapId).Return(nil, swaps.ErrSwapNotFound)
+			},
+			req: models.SwapIn{
+				SwapID:        testSwapId,
+				Status:        models.StatusContractFunded,
+				ClaimAddress:  validRefundAddress,
+				NotFoundSince: &withinGrace,
+			},
+		},
+		{
+			name: "Not found after the grace period with funds in the contract",
+			setup: func() {
+				swapClient.EXPECT().GetSwapIn(ctx, testSwapId).Return(nil, swaps.ErrSwapNotFound)
+				bitcoinClient.EXPECT().GetTxsFromAddress(ctx, validRefundAddress).
+					Return([]*wire.MsgTx{lockTxPayingTo(t, validRefundAddress)}, nil)
+			},
+			req: models.SwapIn{
+				SwapID:        testSwapId,
+				Status:        models.StatusContractFunded,
+				ClaimAddress:  validRefundAddress,
+				NotFoundSince: &afterGrace,
+			},
+		},
+		{
+			name: "Not found after the grace period with an empty contract",
+			setup: func() {
+				swapClient.EXPECT().GetSwapIn(ctx, testSwapId).Return(nil, swaps.ErrSwapNotFound)
+				bitcoinClient.EXPECT().GetTxsFromAddress(ctx, validRefundAddress).Return([]*wire.MsgTx{}, nil)
+			},
+			req: models.SwapIn{
+				SwapID:        testSwapId,
+				Status:        models.StatusCreated,
+				ClaimAddress:  validRefundAddress,
+				NotFoundSince: &afterGrace,
+			},
+			want: &models.SwapIn{
+				SwapID:        testSwapId,
+				Status:        models.StatusDone,
+				Outcome:       &outcomeFailed,
+				ClaimAddress:  validRefundAddress,
+				NotFoundSince: &afterGrace,
+			},
+		},
+		{
+			name: "Chain backend unavailable after the grace period",
+			setup: func() {
+				swapClient.EXPECT().GetSwapIn(ctx, testSwapId).Return(nil, swaps.ErrSwapNotFound)
+				bitcoinClient.EXPECT().GetTxsFromAddress(ctx, validRefundAddress).Return(nil, errors.New("backend down"))
+			},
+			req: models.SwapIn{
+				SwapID:        testSwapId,
+				Status:        models.StatusContractFunded,
+				ClaimAddress:  validRefundAddress,
+				NotFoundSince: &afterGrace,
+			},
+			wantErr: "failed to check contract address",
+		},
+		{
+			name: "Swap found again",
+			setup: func() {
+				swapClient.EXPECT().GetSwapIn(ctx, testSwapId).Return(&swaps.SwapInResponse{
+					Status: models.StatusContractFunded,
+				}, nil)
+			},
+			req: models.SwapIn{
+				SwapID:        testSwapId,
+				Status:        models.StatusContractFunded,
+				NotFoundSince: &withinGrace,
+			},
+			want: &models.SwapIn{
+				SwapID: testSwapId,
+				Status: models.StatusContractFunded,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			if tt.want != nil {
+				repository.EXPECT().SaveSwapInIf(ctx, tt.want, tt.req.Status).Return(nil)
+			}
+
+			err := swapMonitor.MonitorSwapIn(ctx, &tt.req)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_MonitorSwapIn_RecordsEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repository := rpc.NewMockRepository(ctrl)
+	swapClient := swaps.NewMockClientInterface(ctrl)
+	ctx := context.Background()
+	swapMonitor := &SwapMonitor{
+		repository: repository,
+		swapClient: swapClient,
+		network:    lightning.Regtest,
+		now:        time.Now,
+	}
+
+	statusCreated := models.StatusCreated
+	swapClient.EXPECT().GetSwapIn(ctx, testSwapId).Return(&swaps.SwapInResponse{
+		Status: models.StatusContractFunded,
+	}, nil)
+	repository.EXPECT().SaveSwapInIf(ctx, gomock.Any(), models.StatusCreated).Return(nil)
+	repository.EXPECT().SaveSwapEvent(ctx, &models.SwapEvent{
+		SwapID:         testSwapId,
+		Direction:      models.SwapDirectionIn,
+		FromStatus:     &statusCreated,
+		ToStatus:       models.StatusContractFunded,
+		LockTxID:       "lock-tx-id",
+		ServiceFeeSats: 10,
+		OnchainFeeSats: 20,
+	}).Return(errors.New("events are best effort"))
+
+	err := swapMonitor.MonitorSwapIn(ctx, &models.SwapIn{
+		SwapID:         testSwapId,
+		Status:         models.StatusCreated,
+		LockTxID:       "lock-tx-id",
+		ServiceFeeSats: 10,
+		OnchainFeeSats: 20,
+	})
+	require.NoError(t, err)
+}
+
+func Test_MonitorSwapIn_CancelledMeanwhile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repository := rpc.NewMockRepository(ctrl)
+	swapClient := swaps.NewMockClientInterface(ctrl)
+	ctx := context.Background()
+	swapMonitor := &SwapMonitor{
+		repository: repository,
+		swapClient: swapClient,
+		network:    lightning.Regtest,
+		now:        time.Now,
+	}
+
+	swapClient.EXPECT().GetSwapIn(ctx, testSwapId).Return(&swaps.SwapInResponse{
+		Status: models.StatusContractFundedUnconfirmed,
+	}, nil)
+	// The swap was cancelled after it was read, so no event is recorded
+	repository.EXPECT().SaveSwapInIf(ctx, gomock.Any(), models.StatusCreated).Return(database.ErrSwapChanged)
+
+	err := swapMonitor.MonitorSwapIn(ctx, &models.SwapIn{
+		SwapID: testSwapId,
+		Status: models.StatusCreated,
+	})
+	require.NoError(t, err)
+}
+
+func Test_MonitorSwapIn_Cancelled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repository := rpc.NewMockRepository(ctrl)
+	repository.EXPECT().SaveSwapEvent(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	bitcoinClient := bitcoin.NewMockClient(ctrl)
+	ctx := context.Background()
+	swapMonitor := &SwapMonitor{
+		repository: repository,
+		bitcoin:    bitcoinClient,
+		network:    lightning.Regtest,
+		now:        time.Now,
+	}
+	swapMonitor.SetBlockHeight(100)
+
+	cancelled := models.OutcomeCancelled
+	cancelledSwap := func(timeoutBlockHeight int64) *models.SwapIn {
+		return &models.SwapIn{
+			SwapID:             testSwapId,
+			Status:             models.StatusDone,
+			Outcome:            &cancelled,
+			ClaimAddress:       validRefundAddress,
+			TimeoutBlockHeight: timeoutBlockHeight,
+		}
+	}
+	lockTx := lockTxPayingTo(t, validRefundAddress)
+
+	t.Run("not funded", func(t *testing.T) {
+		swap := cancelledSwap(150)
+		require.True(t, swapMonitor.watchesCancelledSwapIn(swap))
+		bitcoinClient.EXPECT().GetTxsFromAddress(ctx, validRefundAddress).Return([]*wire.MsgTx{}, nil)
+
+		require.NoError(t, swapMonitor.MonitorSwapIn(ctx, swap))
+		require.Equal(t, models.StatusDone, swap.Status)
+	})
+
+	t.Run("funded after the cancel", func(t *testing.T) {
+		swap := cancelledSwap(150)
+		bitcoinClient.EXPECT().GetTxsFromAddress(ctx, validRefundAddress).Return([]*wire.MsgTx{lockTx}, nil)
+		repository.EXPECT().SaveSwapInIf(ctx, &models.SwapIn{
+			SwapID:             testSwapId,
+			Status:             models.StatusContractFundedUnconfirmed,
+			ClaimAddress:       validRefundAddress,
+			TimeoutBlockHeight: 150,
+			LockTxID:           lockTx.TxHash().String(),
+		}, models.StatusDone).Return(nil)
+
+		require.NoError(t, swapMonitor.MonitorSwapIn(ctx, swap))
+		require.False(t, swapMonitor.watchesCancelledSwapIn(swap))
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		require.False(t, swapMonitor.watchesCancelledSwapIn(cancelledSwap(99)))
+	})
+}
+
+func Test_Start_NetworkMismatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	ctx := context.Background()
+	swapClient := swaps.NewMockClientInterface(ctrl)
+	swapClient.EXPECT().GetConfiguration(ctx).Return(&swaps.ConfigurationResponse{
+		BitcoinNetwork: lightning.Mainnet,
+	}, nil)
+
+	err := Start(ctx, Config{
+		Swaps:   swapClient,
+		Network: lightning.Regtest,
+	})
+	require.ErrorContains(t, err, "network mismatch: daemon expected regtest, server's got mainnet")
+}
+
+func Test_SetBlockHeight(t *testing.T) {
+	swapMonitor := &SwapMonitor{}
+	require.Equal(t, int64(0), swapMonitor.BlockHeight())
+
+	swapMonitor.SetBlockHeight(100)
+	require.Equal(t, int64(100), swapMonitor.BlockHeight())
+
+	// A lagging backend must not move the height backwards
+	swapMonitor.SetBlockHeight(99)
+	require.Equal(t, int64(100), swapMonitor.BlockHeight())
+
+	swapMonitor.SetBlockHeight(101)
+	require.Equal(t, int64(101), swapMonitor.BlockHeight())
+}
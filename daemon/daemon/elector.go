@@ -0,0 +1,119 @@
+package daemon
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	DefaultLeaderLockID        = 4040
+	DefaultLeaderCheckInterval = 5 * time.Second
+
+	leaderUnlockTimeout = 5 * time.Second
+)
+
+//go:generate go tool mockgen -destination=mock_leader_lock.go -package=daemon . LeaderLock
+type LeaderLock interface {
+	TryLock(ctx context.Context) (bool, error)
+	// Check returns an error if the lock is no longer held
+	Check(ctx context.Context) error
+	Unlock(ctx context.Context) error
+}
+
+// Elector makes sure only one of the daemons sharing a database monitors,
+// claims and auto swaps at a time. The rest follow, campaigning for the lock
+// until the leader goes away.
+type Elector struct {
+	lock     LeaderLock
+	interval time.Duration
+	// onChange is called when this daemon becomes or stops being the leader
+	onChange func(leader bool)
+}
+
+// NewElector creates an elector that tries and checks the lock every interval
+func NewElector(lock LeaderLock, interval time.Duration, onChange func(leader bool)) *Elector {
+	if interval <= 0 {
+		interval = DefaultLeaderCheckInterval
+	}
+
+	return &Elector{lock: lock, interval: interval, onChange: onChange}
+}
+
+// Run campaigns for leadership until the context is cancelled, running lead
+// while this daemon is the leader. lead's context is cancelled as soon as the
+// lock is lost.
+func (e *Elector) Run(ctx context.Context, lead func(ctx context.Context)) error {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		acquired, err := e.lock.TryLock(ctx)
+		switch {
+		case err != nil && ctx.Err() == nil:
+			log.Warnf("failed to campaign for leadership: %v", err)
+		case acquired:
+			e.lead(ctx, lead)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (e *Elector) lead(ctx context.Context, lead func(ctx context.Context)) {
+	log.Info("This daemon is now the leader")
+	e.notify(true)
+	defer e.notify(false)
+
+	leaderCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		lead(leaderCtx)
+	}()
+
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			e.unlock(ctx)
+
+			return
+		case <-ticker.C:
+			if err := e.lock.Check(ctx); err != nil && ctx.Err() == nil {
+				log.Errorf("Lost leadership, stopping: %v", err)
+				cancel()
+				<-done
+				e.unlock(ctx)
+
+				return
+			}
+		}
+	}
+}
+
+// unlock releases the lock even if the daemon is shutting down, so another
+// daemon doesn't have to wait for the connection to time out
+func (e *Elector) unlock(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), leaderUnlockTimeout)
+	defer cancel()
+
+	if err := e.lock.Unlock(ctx); err != nil {
+		log.Warnf("failed to release the leader lock: %v", err)
+	}
+}
+
+func (e *Elector) notify(leader bool) {
+	if e.onChange != nil {
+		e.onChange(leader)
+	}
+}
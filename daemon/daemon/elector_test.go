@@ -0,0 +1,95 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestElector_LosesLeadership(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	lock := NewMockLeaderLock(ctrl)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	changes := make(chan bool, 4)
+	elector := NewElector(lock, time.Millisecond, func(leader bool) { changes <- leader })
+
+	gomock.InOrder(
+		lock.EXPECT().TryLock(gomock.Any()).Return(true, nil),
+		lock.EXPECT().Check(gomock.Any()).Return(nil),
+		lock.EXPECT().Check(gomock.Any()).Return(errors.New("connection reset")),
+		lock.EXPECT().Unlock(gomock.Any()).Return(nil),
+		// Another daemon took over
+		lock.EXPECT().TryLock(gomock.Any()).DoAndReturn(func(context.Context) (bool, error) {
+			cancel()
+
+			return false, nil
+		}),
+	)
+
+	leading := make(chan struct{})
+	err := elector.Run(ctx, func(ctx context.Context) {
+		close(leading)
+		<-ctx.Done()
+	})
+	require.NoError(t, err)
+	<-leading
+
+	require.True(t, <-changes)
+	require.False(t, <-changes)
+	require.Empty(t, changes)
+}
+
+func TestElector_Follower(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	lock := NewMockLeaderLock(ctrl)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	elector := NewElector(lock, time.Millisecond, nil)
+
+	gomock.InOrder(
+		lock.EXPECT().TryLock(gomock.Any()).Return(false, errors.New("connection refused")),
+		lock.EXPECT().TryLock(gomock.Any()).DoAndReturn(func(context.Context) (bool, error) {
+			cancel()
+
+			return false, nil
+		}),
+	)
+
+	err := elector.Run(ctx, func(ctx context.Context) {
+		t.Fatal("a follower must not lead")
+	})
+	require.NoError(t, err)
+}
+
+func TestElector_ReleasesOnShutdown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	lock := NewMockLeaderLock(ctrl)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	elector := NewElector(lock, time.Hour, nil)
+
+	lock.EXPECT().TryLock(gomock.Any()).Return(true, nil)
+	// The lock is released with a context that outlives the shutdown
+	lock.EXPECT().Unlock(gomock.Any()).DoAndReturn(func(ctx context.Context) error {
+		require.NoError(t, ctx.Err())
+
+		return nil
+	})
+
+	err := elector.Run(ctx, func(ctx context.Context) {
+		cancel()
+		<-ctx.Done()
+	})
+	require.NoError(t, err)
+}
@@ -0,0 +1,40 @@
+package daemon
+
+import (
+	"context"
+	"strings"
+
+	"github.com/40acres/40swap/daemon/price"
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+)
+
+// DefaultFiatCurrency is the currency swaps are valued in
+const DefaultFiatCurrency = "USD"
+
+// FiatConfig configures the fiat valuation of swaps, it's disabled when Source
+// is nil
+type FiatConfig struct {
+	Source   price.Source
+	Currency string
+}
+
+// recordFiatRate stores the current price of a bitcoin in the configured
+// currency on a swap that just completed. Swaps aren't held back by the price
+// source, when it fails the swap is left without a rate.
+func (m *SwapMonitor) recordFiatRate(ctx context.Context, currency *string, rate **decimal.Decimal, logger *log.Entry) {
+	if m.fiat.Source == nil || *rate != nil {
+		return
+	}
+
+	fiatCurrency := strings.ToUpper(m.fiat.Currency)
+	current, err := m.fiat.Source.Rate(ctx, fiatCurrency)
+	if err != nil {
+		logger.WithError(err).Warnf("failed to get the %s rate, the swap won't have a fiat value", fiatCurrency)
+
+		return
+	}
+
+	*currency = fiatCurrency
+	*rate = &current
+}
@@ -0,0 +1,73 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/40acres/40swap/daemon/database/models"
+	"github.com/40acres/40swap/daemon/price"
+	"github.com/40acres/40swap/daemon/rpc"
+	"github.com/40acres/40swap/daemon/swaps"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSwapMonitor_RecordsFiatRate(t *testing.T) {
+	ctx := context.Background()
+	rate := decimal.NewFromInt(65000)
+
+	tests := []struct {
+		name         string
+		rateErr      error
+		wantCurrency string
+		wantRate     *decimal.Decimal
+	}{
+		{
+			name:         "rate is recorded on completion",
+			wantCurrency: "USD",
+			wantRate:     &rate,
+		},
+		{
+			name:    "price source failure doesn't hold the swap back",
+			rateErr: errors.New("price source down"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repository := rpc.NewMockRepository(ctrl)
+			repository.EXPECT().SaveSwapEvent(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			swapClient := swaps.NewMockClientInterface(ctrl)
+			source := price.NewMockSource(ctrl)
+
+			swapMonitor := SwapMonitor{
+				repository: repository,
+				swapClient: swapClient,
+				fiat:       FiatConfig{Source: source, Currency: "usd"},
+			}
+
+			swapClient.EXPECT().GetSwapOut(ctx, "swap-id").Return(&swaps.SwapOutResponse{
+				SwapId:  "swap-id",
+				Status:  models.StatusDone,
+				Outcome: models.OutcomeRefunded,
+			}, nil)
+			source.EXPECT().Rate(ctx, "USD").Return(rate, tt.rateErr)
+			repository.EXPECT().SaveSwapOutIf(ctx, gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, swap *models.SwapOut, _ models.SwapStatus, _ ...models.PaymentStatus) error {
+				require.Equal(t, models.StatusDone, swap.Status)
+				require.Equal(t, tt.wantCurrency, swap.FiatCurrency)
+				require.Equal(t, tt.wantRate, swap.FiatRate)
+
+				return nil
+			})
+
+			err := swapMonitor.MonitorSwapOut(ctx, &models.SwapOut{
+				SwapID: "swap-id",
+				Status: models.StatusContractRefundedUnconfirmed,
+			})
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/40acres/40swap/daemon/daemon (interfaces: Backupper)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mock_backupper.go -package=daemon . Backupper
+//
+
+// Package daemon is a generated GoMock package.
+package daemon
+
+import (
+	context "context"
+	reflect "reflect"
+
+	database "github.com/40acres/40swap/daemon/database"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBackupper is a mock of Backupper interface.
+type MockBackupper struct {
+	ctrl     *gomock.Controller
+	recorder *MockBackupperMockRecorder
+	isgomock struct{}
+}
+
+// MockBackupperMockRecorder is the mock recorder for MockBackupper.
+type MockBackupperMockRecorder struct {
+	mock *MockBackupper
+}
+
+// NewMockBackupper creates a new mock instance.
+func NewMockBackupper(ctrl *gomock.Controller) *MockBackupper {
+	mock := &MockBackupper{ctrl: ctrl}
+	mock.recorder = &MockBackupperMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBackupper) EXPECT() *MockBackupperMockRecorder {
+	return m.recorder
+}
+
+// Backup mocks base method.
+func (m *MockBackupper) Backup(ctx context.Context) (*database.Backup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Backup", ctx)
+	ret0, _ := ret[0].(*database.Backup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Backup indicates an expected call of Backup.
+func (mr *MockBackupperMockRecorder) Backup(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Backup", reflect.TypeOf((*MockBackupper)(nil).Backup), ctx)
+}
@@ -0,0 +1,68 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/40acres/40swap/daemon/bitcoin"
+	log "github.com/sirupsen/logrus"
+)
+
+// DefaultNotFoundGracePeriod is how long a swap the server doesn't know about
+// is retried before checking the chain and giving up on it.
+const DefaultNotFoundGracePeriod = time.Hour
+
+// markNotFound records the first time the server reported the swap as not
+// found and reports whether this is that first time.
+func (m *SwapMonitor) markNotFound(notFoundSince **time.Time) bool {
+	if *notFoundSince != nil {
+		return false
+	}
+
+	now := m.now()
+	*notFoundSince = &now
+
+	return true
+}
+
+// keepNotFoundSwap decides whether a swap the server doesn't know about should
+// stay pending. It's kept during the grace period and, after that, for as long
+// as its contract address holds funds.
+func (m *SwapMonitor) keepNotFoundSwap(ctx context.Context, notFoundSince time.Time, contractAddress string, logger *log.Entry) (bool, error) {
+	elapsed := m.now().Sub(notFoundSince)
+	if elapsed < m.notFoundGracePeriod {
+		logger.Warnf("swap not found in the server for %s, will retry until %s",
+			elapsed.Round(time.Second), notFoundSince.Add(m.notFoundGracePeriod).Format(time.RFC3339))
+
+		return true, nil
+	}
+
+	if contractAddress == "" {
+		return false, nil
+	}
+
+	txs, err := m.bitcoin.GetTxsFromAddress(ctx, contractAddress)
+	if err != nil {
+		return false, fmt.Errorf("failed to check contract address %s: %w", contractAddress, err)
+	}
+
+	if bitcoin.HasUnspentOutputToAddress(txs, contractAddress, m.network) {
+		logger.Errorf("swap not found in the server but contract address %s still holds funds, keeping it pending. "+
+			"Use '40swapd recover --offline' to get them back", contractAddress)
+
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// notFoundMessage describes the outcome of a swap not found in the server for
+// its history.
+func notFoundMessage(kept bool) string {
+	if kept {
+		return "swap not found in the server, retrying"
+	}
+
+	return "swap not found in the server, marked as failed"
+}
@@ -108,6 +108,31 @@ func (p *PSBTBuilder) BuildClaimPSBT(ctx context.Context, swap *models.SwapOut,
 		return nil, fmt.Errorf("failed to parse lock transaction: %w", err)
 	}
 
+	return p.buildClaimPSBT(swap, lockTx, int(swapInfo.TimeoutBlockHeight), feeRate, logger)
+}
+
+// BuildClaimPSBTFromLockTx builds a claim PSBT for a swap out spending the given
+// lock transaction, using only the contract data stored locally
+func (p *PSBTBuilder) BuildClaimPSBTFromLockTx(swap *models.SwapOut, lockTx *wire.MsgTx, feeRate int64, logger *log.Entry) (*psbt.Packet, error) {
+	logger.Info("Attempting to build claim PSBT from local data")
+
+	if swap.ContractAddress == "" {
+		return nil, fmt.Errorf("contract address not available for local construction")
+	}
+	if swap.RefundPublicKey == "" {
+		return nil, fmt.Errorf("refund public key not available for local construction")
+	}
+	if swap.PreImage == nil {
+		return nil, fmt.Errorf("preimage not available")
+	}
+	if swap.TimeoutBlockHeight == 0 {
+		return nil, fmt.Errorf("timeout block height not available for local construction")
+	}
+
+	return p.buildClaimPSBT(swap, lockTx, int(swap.TimeoutBlockHeight), feeRate, logger)
+}
+
+func (p *PSBTBuilder) buildClaimPSBT(swap *models.SwapOut, lockTx *wire.MsgTx, timeoutBlockHeight int, feeRate int64, logger *log.Entry) (*psbt.Packet, error) {
 	// Get the claim keys
 	claimPrivateKey, err := bitcoin.ParsePrivateKey(swap.ClaimPrivateKey)
 	if err != nil {
@@ -126,7 +151,7 @@ func (p *PSBTBuilder) BuildClaimPSBT(ctx context.Context, swap *models.SwapOut,
 		swap.PreImage[:],
 		claimPublicKey,
 		refundPublicKey,
-		int(swapInfo.TimeoutBlockHeight),
+		timeoutBlockHeight,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build redeem script: %w", err)
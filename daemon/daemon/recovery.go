@@ -0,0 +1,232 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/40acres/40swap/daemon/bitcoin"
+	"github.com/40acres/40swap/daemon/database/models"
+	"github.com/40acres/40swap/daemon/lightning"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/lntypes"
+	log "github.com/sirupsen/logrus"
+)
+
+// Actions reported by the offline recovery for each swap
+const (
+	RecoveryActionClaimed  = "CLAIMED"
+	RecoveryActionRefunded = "REFUNDED"
+	RecoveryActionSkipped  = "SKIPPED"
+	RecoveryActionFailed   = "FAILED"
+)
+
+// RecoveryResult describes what the offline recovery did for a single swap
+type RecoveryResult struct {
+	SwapID string `json:"swapId"`
+	Type   string `json:"type"`
+	Action string `json:"action"`
+	TxID   string `json:"txId,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// OfflineRecovery claims swap outs and refunds swap ins using only the local
+// database and a chain backend. It never contacts the swap server, so it can
+// be used to get the funds back if the server disappears.
+type OfflineRecovery struct {
+	repository Repository
+	bitcoin    bitcoin.Client
+	network    lightning.Network
+	now        func() time.Time
+}
+
+// NewOfflineRecovery creates a new OfflineRecovery
+func NewOfflineRecovery(repository Repository, bitcoin bitcoin.Client, network lightning.Network) *OfflineRecovery {
+	return &OfflineRecovery{
+		repository: repository,
+		bitcoin:    bitcoin,
+		network:    network,
+		now:        time.Now,
+	}
+}
+
+// Recover goes through every pending swap and tries to get its funds back
+func (r *OfflineRecovery) Recover(ctx context.Context) ([]RecoveryResult, error) {
+	swapIns, err := r.repository.GetPendingSwapIns(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending swap ins: %w", err)
+	}
+
+	swapOuts, err := r.repository.GetPendingSwapOuts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending swap outs: %w", err)
+	}
+
+	height, err := r.bitcoin.GetBlockHeight(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block height: %w", err)
+	}
+
+	feeRate, err := r.bitcoin.GetRecommendedFees(ctx, bitcoin.HalfHourFee)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recommended fees: %w", err)
+	}
+	if feeRate > 200 {
+		return nil, fmt.Errorf("recommended fee rate is too high: %d", feeRate)
+	}
+
+	results := make([]RecoveryResult, 0, len(swapIns)+len(swapOuts))
+	for _, swapIn := range swapIns {
+		results = append(results, r.recoverSwapIn(ctx, swapIn, height, feeRate))
+	}
+	for _, swapOut := range swapOuts {
+		results = append(results, r.recoverSwapOut(ctx, swapOut, feeRate))
+	}
+
+	return results, nil
+}
+
+func (r *OfflineRecovery) recoverSwapIn(ctx context.Context, swap *models.SwapIn, height, feeRate int64) RecoveryResult {
+	logger := log.WithField("id", swap.SwapID)
+	result := RecoveryResult{
+		SwapID: swap.SwapID,
+		Type:   "IN",
+	}
+
+	switch {
+	case claimedByServer(swap):
+		return skipped(result, "contract already claimed by the server")
+	case swap.RefundTxID != "":
+		return skipped(result, fmt.Sprintf("refund already broadcast in tx %s", swap.RefundTxID))
+	case swap.ClaimAddress == "" || swap.RedeemScript == "":
+		return skipped(result, "contract details not available locally")
+	case swap.TimeoutBlockHeight == 0:
+		return skipped(result, "timeout block height not available locally")
+	case height < swap.TimeoutBlockHeight:
+		return skipped(result, fmt.Sprintf("contract expires at block %d (current block %d)", swap.TimeoutBlockHeight, height))
+	}
+
+	if swap.LockTxID == "" {
+		lockTx, err := r.findLockTx(ctx, swap.ClaimAddress)
+		if err != nil {
+			return failed(result, err)
+		}
+		if lockTx == nil {
+			return skipped(result, "no lock transaction found for the contract address")
+		}
+		swap.LockTxID = lockTx.TxHash().String()
+	}
+
+	psbtBuilder := NewPSBTBuilder(r.bitcoin, r.network)
+	pkt, err := psbtBuilder.BuildRefundPSBT(ctx, swap, feeRate, logger)
+	if err != nil {
+		return failed(result, fmt.Errorf("failed to build refund PSBT: %w", err))
+	}
+
+	txId, err := psbtBuilder.SignAndBroadcastPSBT(ctx, pkt, swap.RefundPrivatekey, &lntypes.Preimage{}, logger)
+	if err != nil {
+		return failed(result, err)
+	}
+
+	previousStatus := swap.Status
+	swap.Status = models.StatusContractExpired
+	swap.RefundRequestedAt = r.now()
+	swap.RefundTxID = txId
+	if err := r.repository.SaveSwapIn(ctx, swap); err != nil {
+		logger.Errorf("refund broadcast in tx %s but the swap couldn't be saved: %v", txId, err)
+	}
+
+	event := models.NewSwapInEvent(swap, &previousStatus)
+	event.Message = fmt.Sprintf("refunded by the offline recovery at block %d", height)
+	recordEvent(ctx, r.repository, event)
+
+	result.Action = RecoveryActionRefunded
+	result.TxID = txId
+
+	return result
+}
+
+func (r *OfflineRecovery) recoverSwapOut(ctx context.Context, swap *models.SwapOut, feeRate int64) RecoveryResult {
+	logger := log.WithField("id", swap.SwapID)
+	result := RecoveryResult{
+		SwapID: swap.SwapID,
+		Type:   "OUT",
+	}
+
+	switch {
+	case swap.TxID != "":
+		return skipped(result, fmt.Sprintf("claim already broadcast in tx %s", swap.TxID))
+	case swap.ContractAddress == "":
+		return skipped(result, "contract address not available locally")
+	}
+
+	lockTx, err := r.findLockTx(ctx, swap.ContractAddress)
+	if err != nil {
+		return failed(result, err)
+	}
+	if lockTx == nil {
+		return skipped(result, "no lock transaction found for the contract address")
+	}
+
+	psbtBuilder := NewPSBTBuilder(r.bitcoin, r.network)
+	pkt, err := psbtBuilder.BuildClaimPSBTFromLockTx(swap, lockTx, feeRate, logger)
+	if err != nil {
+		return failed(result, fmt.Errorf("failed to build claim PSBT: %w", err))
+	}
+
+	txId, err := psbtBuilder.SignAndBroadcastPSBT(ctx, pkt, swap.ClaimPrivateKey, swap.PreImage, logger)
+	if err != nil {
+		return failed(result, err)
+	}
+
+	swap.TxID = txId
+	if err := r.repository.SaveSwapOut(ctx, swap); err != nil {
+		logger.Errorf("claim broadcast in tx %s but the swap couldn't be saved: %v", txId, err)
+	}
+
+	event := models.NewSwapOutEvent(swap, &swap.Status)
+	event.Message = "claimed by the offline recovery"
+	recordEvent(ctx, r.repository, event)
+
+	result.Action = RecoveryActionClaimed
+	result.TxID = txId
+
+	return result
+}
+
+// claimedByServer reports whether the server paid the invoice of the swap in
+// and claimed its contract, so there's nothing left to refund
+func claimedByServer(swap *models.SwapIn) bool {
+	switch swap.Status {
+	case models.StatusInvoicePaid, models.StatusContractClaimedUnconfirmed:
+		return true
+	case models.StatusDone:
+		return swap.Outcome != nil && *swap.Outcome == models.OutcomeSuccess
+	default:
+		return false
+	}
+}
+
+// findLockTx looks for a transaction funding the given contract address
+func (r *OfflineRecovery) findLockTx(ctx context.Context, address string) (*wire.MsgTx, error) {
+	txs, err := r.bitcoin.GetTxsFromAddress(ctx, address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions for address %s: %w", address, err)
+	}
+
+	return bitcoin.FindTxPayingToAddress(txs, address, r.network), nil
+}
+
+func skipped(result RecoveryResult, reason string) RecoveryResult {
+	result.Action = RecoveryActionSkipped
+	result.Reason = reason
+
+	return result
+}
+
+func failed(result RecoveryResult, err error) RecoveryResult {
+	result.Action = RecoveryActionFailed
+	result.Reason = err.Error()
+
+	return result
+}
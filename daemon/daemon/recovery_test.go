@@ -0,0 +1,225 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/40acres/40swap/daemon/bitcoin"
+	"github.com/40acres/40swap/daemon/database/models"
+	"github.com/40acres/40swap/daemon/lightning"
+	"github.com/40acres/40swap/daemon/rpc"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/lntypes"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestOfflineRecovery_Recover(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repository := rpc.NewMockRepository(ctrl)
+	bitcoinClient := bitcoin.NewMockClient(ctrl)
+	ctx := context.Background()
+	recovery := NewOfflineRecovery(repository, bitcoinClient, lightning.Regtest)
+	recovery.now = func() time.Time {
+		return time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	expectChain := func(height, feeRate int64) {
+		bitcoinClient.EXPECT().GetBlockHeight(ctx).Return(height, nil)
+		bitcoinClient.EXPECT().GetRecommendedFees(ctx, bitcoin.HalfHourFee).Return(feeRate, nil)
+	}
+
+	tests := []struct {
+		name    string
+		setup   func()
+		want    []RecoveryResult
+		wantErr bool
+	}{
+		{
+			name: "error getting pending swaps",
+			setup: func() {
+				repository.EXPECT().GetPendingSwapIns(ctx).Return(nil, errors.New("db error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "fee rate too high",
+			setup: func() {
+				repository.EXPECT().GetPendingSwapIns(ctx).Return(nil, nil)
+				repository.EXPECT().GetPendingSwapOuts(ctx).Return(nil, nil)
+				expectChain(100, 500)
+			},
+			wantErr: true,
+		},
+		{
+			name: "swap in not expired yet",
+			setup: func() {
+				repository.EXPECT().GetPendingSwapIns(ctx).Return([]*models.SwapIn{
+					{
+						SwapID:             "swap_in",
+						ClaimAddress:       "bcrt1qaddress",
+						RedeemScript:       "deadbeef",
+						TimeoutBlockHeight: 150,
+					},
+				}, nil)
+				repository.EXPECT().GetPendingSwapOuts(ctx).Return(nil, nil)
+				expectChain(100, 10)
+			},
+			want: []RecoveryResult{
+				{SwapID: "swap_in", Type: "IN", Action: RecoveryActionSkipped, Reason: "contract expires at block 150 (current block 100)"},
+			},
+		},
+		{
+			name: "swap in claimed by the server",
+			setup: func() {
+				repository.EXPECT().GetPendingSwapIns(ctx).Return([]*models.SwapIn{
+					{
+						SwapID:             "swap_in_paid",
+						Status:             models.StatusInvoicePaid,
+						ClaimAddress:       "bcrt1qaddress",
+						RedeemScript:       "deadbeef",
+						TimeoutBlockHeight: 90,
+					},
+					{
+						SwapID:             "swap_in_claimed",
+						Status:             models.StatusContractClaimedUnconfirmed,
+						ClaimAddress:       "bcrt1qaddress",
+						RedeemScript:       "deadbeef",
+						TimeoutBlockHeight: 90,
+					},
+				}, nil)
+				repository.EXPECT().GetPendingSwapOuts(ctx).Return(nil, nil)
+				expectChain(100, 10)
+			},
+			want: []RecoveryResult{
+				{SwapID: "swap_in_paid", Type: "IN", Action: RecoveryActionSkipped, Reason: "contract already claimed by the server"},
+				{SwapID: "swap_in_claimed", Type: "IN", Action: RecoveryActionSkipped, Reason: "contract already claimed by the server"},
+			},
+		},
+		{
+			name: "swap in already refunded",
+			setup: func() {
+				repository.EXPECT().GetPendingSwapIns(ctx).Return([]*models.SwapIn{
+					{
+						SwapID:     "swap_in",
+						RefundTxID: "refund_tx",
+					},
+				}, nil)
+				repository.EXPECT().GetPendingSwapOuts(ctx).Return(nil, nil)
+				expectChain(100, 10)
+			},
+			want: []RecoveryResult{
+				{SwapID: "swap_in", Type: "IN", Action: RecoveryActionSkipped, Reason: "refund already broadcast in tx refund_tx"},
+			},
+		},
+		{
+			name: "swap in without a lock transaction on chain",
+			setup: func() {
+				repository.EXPECT().GetPendingSwapIns(ctx).Return([]*models.SwapIn{
+					{
+						SwapID:             "swap_in",
+						ClaimAddress:       "bcrt1qaddress",
+						RedeemScript:       "deadbeef",
+						TimeoutBlockHeight: 90,
+					},
+				}, nil)
+				repository.EXPECT().GetPendingSwapOuts(ctx).Return(nil, nil)
+				expectChain(100, 10)
+				bitcoinClient.EXPECT().GetTxsFromAddress(ctx, "bcrt1qaddress").Return([]*wire.MsgTx{}, nil)
+			},
+			want: []RecoveryResult{
+				{SwapID: "swap_in", Type: "IN", Action: RecoveryActionSkipped, Reason: "no lock transaction found for the contract address"},
+			},
+		},
+		{
+			name: "swap out failing to look up the lock transaction",
+			setup: func() {
+				repository.EXPECT().GetPendingSwapIns(ctx).Return(nil, nil)
+				repository.EXPECT().GetPendingSwapOuts(ctx).Return([]*models.SwapOut{
+					{
+						SwapID:          "swap_out",
+						ContractAddress: "bcrt1qcontract",
+					},
+					{
+						SwapID: "swap_out_claimed",
+						TxID:   "claim_tx",
+					},
+				}, nil)
+				expectChain(100, 10)
+				bitcoinClient.EXPECT().GetTxsFromAddress(ctx, "bcrt1qcontract").Return(nil, errors.New("backend down"))
+			},
+			want: []RecoveryResult{
+				{SwapID: "swap_out", Type: "OUT", Action: RecoveryActionFailed, Reason: "failed to get transactions for address bcrt1qcontract: backend down"},
+				{SwapID: "swap_out_claimed", Type: "OUT", Action: RecoveryActionSkipped, Reason: "claim already broadcast in tx claim_tx"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			got, err := recovery.Recover(ctx)
+			if tt.wantErr {
+				require.Error(t, err)
+
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPSBTBuilder_BuildClaimPSBTFromLockTx(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	builder := NewPSBTBuilder(bitcoin.NewMockClient(ctrl), lightning.Regtest)
+	logger := log.WithField("test", "BuildClaimPSBTFromLockTx")
+
+	tests := []struct {
+		name string
+		swap *models.SwapOut
+	}{
+		{
+			name: "missing contract address",
+			swap: &models.SwapOut{
+				SwapID:             "test-swap",
+				PreImage:           &lntypes.Preimage{},
+				RefundPublicKey:    "deadbeef",
+				TimeoutBlockHeight: 100,
+			},
+		},
+		{
+			name: "missing preimage",
+			swap: &models.SwapOut{
+				SwapID:             "test-swap",
+				ContractAddress:    "bcrt1qcontract",
+				RefundPublicKey:    "deadbeef",
+				TimeoutBlockHeight: 100,
+			},
+		},
+		{
+			name: "missing timeout block height",
+			swap: &models.SwapOut{
+				SwapID:          "test-swap",
+				ContractAddress: "bcrt1qcontract",
+				PreImage:        &lntypes.Preimage{},
+				RefundPublicKey: "deadbeef",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := builder.BuildClaimPSBTFromLockTx(tt.swap, wire.NewMsgTx(2), 10, logger)
+			require.Error(t, err)
+		})
+	}
+}
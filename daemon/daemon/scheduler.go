@@ -0,0 +1,345 @@
+package daemon
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/40acres/40swap/daemon/database/models"
+	"github.com/40acres/40swap/daemon/lightning"
+	"github.com/40acres/40swap/daemon/metrics"
+	"github.com/lightningnetwork/lnd/zpay32"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	DefaultMaxConcurrentSwaps = 8
+	DefaultMinPollInterval    = 5 * time.Second
+	DefaultMaxPollInterval    = time.Minute
+)
+
+// SchedulerConfig holds how often and how many swaps are monitored at once
+type SchedulerConfig struct {
+	// MaxConcurrentSwaps bounds the swaps being processed at the same time
+	MaxConcurrentSwaps int
+	// MinPollInterval is the wait between polls of a swap whose status just
+	// changed. It doubles every time the status stays the same, up to
+	// MaxPollInterval.
+	MinPollInterval time.Duration
+	MaxPollInterval time.Duration
+	// DrainTimeout is how long a poll in flight, which may be claiming or
+	// refunding a swap, keeps running after the scheduler is stopped
+	DrainTimeout time.Duration
+}
+
+type swapKey struct {
+	direction models.SwapDirection
+	id        string
+}
+
+type swapWorker struct {
+	key swapKey
+	// wake is buffered so triggers never block and pile up into one poll
+	wake chan struct{}
+	// watching is set while the lightning node is watched for the invoice. The
+	// watch is started again for every new attempt to pay it, watchedAttempt
+	// being the last one watched.
+	watching       atomic.Bool
+	watched        bool
+	watchedAttempt int64
+}
+
+// Scheduler monitors every pending swap in its own worker. Workers poll the
+// server with an adaptive interval and are woken early by new blocks and by
+// lightning invoice and payment updates.
+type Scheduler struct {
+	monitor *SwapMonitor
+	config  SchedulerConfig
+	slots   chan struct{}
+
+	mu      sync.Mutex
+	workers map[swapKey]*swapWorker
+	wg      sync.WaitGroup
+}
+
+// NewScheduler creates a scheduler for the monitor, filling the unset settings
+// with defaults
+func NewScheduler(monitor *SwapMonitor, config SchedulerConfig) *Scheduler {
+	if config.MaxConcurrentSwaps <= 0 {
+		config.MaxConcurrentSwaps = DefaultMaxConcurrentSwaps
+	}
+	if config.MinPollInterval <= 0 {
+		config.MinPollInterval = DefaultMinPollInterval
+	}
+	if config.MaxPollInterval < config.MinPollInterval {
+		config.MaxPollInterval = max(DefaultMaxPollInterval, config.MinPollInterval)
+	}
+	if config.DrainTimeout <= 0 {
+		config.DrainTimeout = DefaultShutdownTimeout
+	}
+
+	scheduler := &Scheduler{
+		monitor: monitor,
+		config:  config,
+		slots:   make(chan struct{}, config.MaxConcurrentSwaps),
+		workers: make(map[swapKey]*swapWorker),
+	}
+	monitor.onNewBlock = func(int64) { scheduler.WakeAll() }
+
+	return scheduler
+}
+
+// Run looks for new pending swaps every MONITORING_INTERVAL_SECONDS and starts
+// a worker for each of them. It returns once the context is cancelled and all
+// workers have finished their polls in flight and stopped watching their swaps.
+func (s *Scheduler) Run(ctx context.Context) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.monitor.TrackBlockHeight(ctx)
+	}()
+
+	ticker := time.NewTicker(MONITORING_INTERVAL_SECONDS * time.Second)
+	defer ticker.Stop()
+
+	for {
+		s.monitor.refreshBlockHeight(ctx)
+		s.sync(ctx)
+
+		select {
+		case <-ctx.Done():
+			s.wg.Wait()
+
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// WakeAll makes every worker poll its swap right away
+func (s *Scheduler) WakeAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, worker := range s.workers {
+		worker.trigger()
+	}
+}
+
+// sync starts a worker for every pending swap that doesn't have one yet
+func (s *Scheduler) sync(ctx context.Context) {
+	swapIns, err := s.monitor.repository.GetPendingSwapIns(ctx)
+	if err != nil {
+		log.Errorf("failed to get pending swap ins: %v", err)
+		metrics.MonitorError(models.SwapDirectionIn)
+
+		return
+	}
+
+	// The contract of a cancelled swap in may still be funded until it expires
+	cancelledSwapIns, err := s.monitor.repository.GetCancelledSwapIns(ctx, s.monitor.BlockHeight())
+	if err != nil {
+		log.Errorf("failed to get cancelled swap ins: %v", err)
+		metrics.MonitorError(models.SwapDirectionIn)
+
+		return
+	}
+
+	swapOuts, err := s.monitor.repository.GetPendingSwapOuts(ctx)
+	if err != nil {
+		log.Errorf("failed to get pending swap outs: %v", err)
+		metrics.MonitorError(models.SwapDirectionOut)
+
+		return
+	}
+
+	metrics.SetPendingSwaps(models.SwapDirectionIn, swapInStatuses(swapIns))
+	metrics.SetPendingSwaps(models.SwapDirectionOut, swapOutStatuses(swapOuts))
+
+	for _, swapIn := range append(swapIns, cancelledSwapIns...) {
+		s.startWorker(ctx, swapKey{direction: models.SwapDirectionIn, id: swapIn.SwapID})
+	}
+	for _, swapOut := range swapOuts {
+		s.startWorker(ctx, swapKey{direction: models.SwapDirectionOut, id: swapOut.SwapID})
+	}
+}
+
+func (s *Scheduler) startWorker(ctx context.Context, key swapKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.workers[key]; ok {
+		return
+	}
+
+	worker := &swapWorker{key: key, wake: make(chan struct{}, 1)}
+	s.workers[key] = worker
+	s.wg.Add(1)
+	go s.runWorker(ctx, worker)
+}
+
+func (s *Scheduler) stopWorker(key swapKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.workers, key)
+}
+
+// runWorker polls a swap until it's done or the context is cancelled
+func (s *Scheduler) runWorker(ctx context.Context, worker *swapWorker) {
+	defer s.wg.Done()
+	defer s.stopWorker(worker.key)
+
+	// Cancelling stops the lightning watcher when the swap is done
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	logger := log.WithFields(log.Fields{"id": worker.key.id, "direction": worker.key.direction})
+	interval := s.config.MinPollInterval
+	for {
+		done, changed, err := s.poll(ctx, worker)
+		switch {
+		case ctx.Err() != nil:
+			return
+		case err != nil:
+			logger.Errorf("failed to monitor swap: %v", err)
+			metrics.MonitorError(worker.key.direction)
+		case done:
+			logger.Debug("swap is done, stopping its worker")
+
+			return
+		}
+		interval = s.nextInterval(interval, changed)
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return
+		case <-worker.wake:
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+// nextInterval backs off while a swap doesn't change and polls it often again
+// as soon as it does
+func (s *Scheduler) nextInterval(current time.Duration, changed bool) time.Duration {
+	if changed {
+		return s.config.MinPollInterval
+	}
+
+	return min(current*2, s.config.MaxPollInterval)
+}
+
+// poll monitors the swap once, reporting whether it's done and whether its
+// status, or the status of its payment, changed. A poll that already started
+// is given DrainTimeout to finish once the context is cancelled, so claims and
+// refunds aren't cut halfway.
+func (s *Scheduler) poll(ctx context.Context, worker *swapWorker) (bool, bool, error) {
+	select {
+	case s.slots <- struct{}{}:
+	case <-ctx.Done():
+		return false, false, ctx.Err()
+	}
+	defer func() { <-s.slots }()
+	defer metrics.ObserveMonitorIteration(time.Now())
+
+	switch worker.key.direction {
+	case models.SwapDirectionIn:
+		swap, err := s.monitor.repository.GetSwapIn(ctx, worker.key.id)
+		if err != nil {
+			return false, false, fmt.Errorf("failed to get swap in: %w", err)
+		}
+		if swap.Status == models.StatusDone && !s.monitor.watchesCancelledSwapIn(swap) {
+			return true, false, nil
+		}
+		if swap.Status != models.StatusDone {
+			s.watchLightning(ctx, worker, swap.PaymentRequest, 0)
+		}
+
+		previous := swap.Status
+		drainCtx, cancel := drainContext(ctx, s.config.DrainTimeout)
+		defer cancel()
+		err = traceSwap(drainCtx, "SwapMonitor.MonitorSwapIn", swap.SwapID, func(ctx context.Context) error {
+			return s.monitor.MonitorSwapIn(ctx, swap)
+		})
+
+		return swap.Status == models.StatusDone && !s.monitor.watchesCancelledSwapIn(swap), swap.Status != previous, err
+	default:
+		swap, err := s.monitor.repository.GetSwapOut(ctx, worker.key.id)
+		if err != nil {
+			return false, false, fmt.Errorf("failed to get swap out: %w", err)
+		}
+		if swap.Status == models.StatusDone {
+			return true, false, nil
+		}
+		// There is no payment to watch until the monitor sends it
+		if swap.PaymentStatus != models.PaymentPending {
+			s.watchLightning(ctx, worker, swap.PaymentRequest, swap.PaymentAttempts)
+		}
+
+		previous, previousPayment := swap.Status, swap.PaymentStatus
+		drainCtx, cancel := drainContext(ctx, s.config.DrainTimeout)
+		defer cancel()
+		err = traceSwap(drainCtx, "SwapMonitor.MonitorSwapOut", swap.SwapID, func(ctx context.Context) error {
+			return s.monitor.MonitorSwapOut(ctx, swap)
+		})
+
+		return swap.Status == models.StatusDone, swap.Status != previous || swap.PaymentStatus != previousPayment, err
+	}
+}
+
+// watchLightning wakes the worker once the lightning node settles the invoice
+// of a swap in or finishes the attempt to pay the invoice of a swap out. Each
+// invoice is watched once per payment attempt.
+func (s *Scheduler) watchLightning(ctx context.Context, worker *swapWorker, paymentRequest string, attempt int64) {
+	if paymentRequest == "" || worker.watching.Load() || (worker.watched && worker.watchedAttempt == attempt) {
+		return
+	}
+	worker.watched = true
+	worker.watchedAttempt = attempt
+
+	invoice, err := zpay32.Decode(paymentRequest, lightning.ToChainCfgNetwork(s.monitor.network))
+	if err != nil || invoice.PaymentHash == nil {
+		log.WithField("id", worker.key.id).Warnf("failed to decode invoice, not watching it: %v", err)
+
+		return
+	}
+	hash := *invoice.PaymentHash
+
+	worker.watching.Store(true)
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		var err error
+		if worker.key.direction == models.SwapDirectionIn {
+			_, err = s.monitor.lightningClient.MonitorPaymentReception(ctx, hash[:])
+		} else {
+			_, _, err = s.monitor.lightningClient.MonitorPaymentRequest(ctx, hex.EncodeToString(hash[:]))
+		}
+		// Cleared before waking the worker, so the poll can watch a new attempt
+		worker.watching.Store(false)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			log.WithField("id", worker.key.id).Debugf("stopped watching invoice: %v", err)
+		}
+
+		worker.trigger()
+	}()
+}
+
+func (w *swapWorker) trigger() {
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
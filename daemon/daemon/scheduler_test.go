@@ -0,0 +1,105 @@
+package daemon
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/40acres/40swap/daemon/database/models"
+	"github.com/40acres/40swap/daemon/lightning"
+	"github.com/40acres/40swap/daemon/rpc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestScheduler_nextInterval(t *testing.T) {
+	scheduler := NewScheduler(&SwapMonitor{}, SchedulerConfig{
+		MinPollInterval: time.Second,
+		MaxPollInterval: 10 * time.Second,
+	})
+
+	require.Equal(t, 2*time.Second, scheduler.nextInterval(time.Second, false))
+	require.Equal(t, 8*time.Second, scheduler.nextInterval(4*time.Second, false))
+	require.Equal(t, 10*time.Second, scheduler.nextInterval(8*time.Second, false))
+	require.Equal(t, time.Second, scheduler.nextInterval(10*time.Second, true))
+}
+
+func TestScheduler_sync(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	ctx := context.Background()
+	repository := rpc.NewMockRepository(ctrl)
+	scheduler := NewScheduler(&SwapMonitor{repository: repository}, SchedulerConfig{})
+
+	repository.EXPECT().GetPendingSwapIns(ctx).Return([]*models.SwapIn{{SwapID: "in"}}, nil)
+	repository.EXPECT().GetCancelledSwapIns(ctx, int64(0)).Return([]*models.SwapIn{{SwapID: "cancelled"}}, nil)
+	repository.EXPECT().GetPendingSwapOuts(ctx).Return([]*models.SwapOut{{SwapID: "out"}}, nil)
+	// The swaps finished since they were listed, so their workers stop
+	// without monitoring them
+	repository.EXPECT().GetSwapIn(gomock.Any(), "in").Return(&models.SwapIn{SwapID: "in", Status: models.StatusDone}, nil)
+	repository.EXPECT().GetSwapIn(gomock.Any(), "cancelled").Return(&models.SwapIn{SwapID: "cancelled", Status: models.StatusDone}, nil)
+	repository.EXPECT().GetSwapOut(gomock.Any(), "out").Return(&models.SwapOut{SwapID: "out", Status: models.StatusDone}, nil)
+
+	scheduler.sync(ctx)
+	scheduler.wg.Wait()
+
+	require.Empty(t, scheduler.workers)
+}
+
+func TestScheduler_WakeOnNewBlock(t *testing.T) {
+	monitor := &SwapMonitor{}
+	scheduler := NewScheduler(monitor, SchedulerConfig{})
+	worker := &swapWorker{key: swapKey{direction: models.SwapDirectionIn, id: "in"}, wake: make(chan struct{}, 1)}
+	scheduler.workers[worker.key] = worker
+
+	monitor.SetBlockHeight(100)
+	// A second block before the worker wakes up must not block
+	monitor.SetBlockHeight(101)
+	require.Len(t, worker.wake, 1)
+
+	<-worker.wake
+	// Blocks lower than the current one are ignored
+	monitor.SetBlockHeight(99)
+	require.Empty(t, worker.wake)
+}
+
+func TestScheduler_watchLightningRetriedPayment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	lightningClient := lightning.NewMockClient(ctrl)
+	scheduler := NewScheduler(&SwapMonitor{lightningClient: lightningClient, network: lightning.Regtest}, SchedulerConfig{})
+	worker := &swapWorker{key: swapKey{direction: models.SwapDirectionOut, id: "out"}, wake: make(chan struct{}, 1)}
+	invoice := lightning.CreateMockInvoice(t, 1000)
+	hash := hex.EncodeToString(lightning.TestPaymentHash[:])
+
+	waitForWake := func() {
+		t.Helper()
+		select {
+		case <-worker.wake:
+		case <-time.After(time.Second):
+			t.Fatal("worker was not woken by the lightning node")
+		}
+		scheduler.wg.Wait()
+	}
+
+	// The first payment attempt fails
+	lightningClient.EXPECT().MonitorPaymentRequest(gomock.Any(), hash).Return("", int64(0), lightning.ErrPaymentFailed)
+	scheduler.watchLightning(ctx, worker, invoice, 1)
+	waitForWake()
+
+	// The failed attempt isn't watched again
+	scheduler.watchLightning(ctx, worker, invoice, 1)
+	scheduler.wg.Wait()
+	require.Empty(t, worker.wake)
+
+	// The retried payment is
+	lightningClient.EXPECT().MonitorPaymentRequest(gomock.Any(), hash).Return("preimage", int64(1), nil)
+	scheduler.watchLightning(ctx, worker, invoice, 2)
+	waitForWake()
+}
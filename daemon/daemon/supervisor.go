@@ -0,0 +1,180 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// DefaultShutdownTimeout is how long in-flight work may keep running once the
+// daemon starts shutting down
+const DefaultShutdownTimeout = 30 * time.Second
+
+// ErrShutdownTimeout is returned when some subsystems didn't stop in time
+var ErrShutdownTimeout = errors.New("timed out waiting for subsystems to stop")
+
+type SubsystemState string
+
+const (
+	SubsystemRunning SubsystemState = "RUNNING"
+	SubsystemStopped SubsystemState = "STOPPED"
+	SubsystemFailed  SubsystemState = "FAILED"
+)
+
+// SubsystemHealth is the state of a subsystem owned by the supervisor
+type SubsystemHealth struct {
+	Name  string
+	State SubsystemState
+	// Error is the error the subsystem failed with, if any
+	Error string
+	Since time.Time
+}
+
+// HealthReporter is told whenever a subsystem starts or stops serving
+type HealthReporter interface {
+	SetServingStatus(service string, serving bool)
+}
+
+// Supervisor owns the long running goroutines of the daemon. The first
+// subsystem to fail cancels the context of the rest, and shutting down waits
+// for all of them to stop.
+type Supervisor struct {
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+	wg     sync.WaitGroup
+	// health is optional, nil when nobody is interested in the subsystems
+	health HealthReporter
+	// nested supervisors don't report the overall serving status of the daemon
+	nested bool
+
+	mu         sync.Mutex
+	subsystems map[string]*SubsystemHealth
+}
+
+// NewSupervisor creates a supervisor whose subsystems stop when ctx is
+// cancelled
+func NewSupervisor(ctx context.Context, health HealthReporter) *Supervisor {
+	ctx, cancel := context.WithCancelCause(ctx)
+
+	return &Supervisor{
+		ctx:        ctx,
+		cancel:     cancel,
+		health:     health,
+		subsystems: make(map[string]*SubsystemHealth),
+	}
+}
+
+// Nested creates a supervisor for subsystems that only run while ctx is alive,
+// like the ones of the leader. Their health is reported, but stopping them
+// doesn't mark the daemon as not serving.
+func (s *Supervisor) Nested(ctx context.Context) *Supervisor {
+	nested := NewSupervisor(ctx, s.health)
+	nested.nested = true
+
+	return nested
+}
+
+// Go runs a subsystem in its own goroutine. A subsystem returning an error
+// while the daemon is running shuts the whole daemon down.
+func (s *Supervisor) Go(name string, run func(ctx context.Context) error) {
+	s.setState(name, SubsystemRunning, nil)
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		err := run(s.ctx)
+		if err != nil && s.ctx.Err() == nil {
+			log.Errorf("subsystem %s failed: %v", name, err)
+			s.setState(name, SubsystemFailed, err)
+			s.cancel(fmt.Errorf("subsystem %s failed: %w", name, err))
+
+			return
+		}
+		s.setState(name, SubsystemStopped, nil)
+	}()
+}
+
+// Wait blocks until the context is cancelled or a subsystem fails, then gives
+// the subsystems up to timeout to finish their in-flight work. It returns the
+// error of the failed subsystem, if any.
+func (s *Supervisor) Wait(timeout time.Duration) error {
+	<-s.ctx.Done()
+	log.Info("Stopping subsystems")
+	if s.health != nil && !s.nested {
+		s.health.SetServingStatus("", false)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	var err error
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		for _, subsystem := range s.Health() {
+			if subsystem.State == SubsystemRunning {
+				log.Warnf("subsystem %s didn't stop in %s", subsystem.Name, timeout)
+			}
+		}
+		err = ErrShutdownTimeout
+	}
+
+	// The parent context being cancelled is a clean shutdown
+	if cause := context.Cause(s.ctx); !errors.Is(cause, context.Canceled) {
+		return errors.Join(cause, err)
+	}
+
+	return err
+}
+
+// Health returns the state of every subsystem sorted by name
+func (s *Supervisor) Health() []SubsystemHealth {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	health := make([]SubsystemHealth, 0, len(s.subsystems))
+	for _, subsystem := range s.subsystems {
+		health = append(health, *subsystem)
+	}
+	sort.Slice(health, func(i, j int) bool { return health[i].Name < health[j].Name })
+
+	return health
+}
+
+func (s *Supervisor) setState(name string, state SubsystemState, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	subsystem := &SubsystemHealth{Name: name, State: state, Since: time.Now()}
+	if err != nil {
+		subsystem.Error = err.Error()
+	}
+	s.subsystems[name] = subsystem
+
+	if s.health != nil {
+		s.health.SetServingStatus(name, state == SubsystemRunning)
+	}
+}
+
+// drainContext returns a context that outlives ctx by the grace period, so work
+// in flight when shutting down, like a claim or a refund, gets to finish
+func drainContext(ctx context.Context, grace time.Duration) (context.Context, context.CancelFunc) {
+	drain, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	stop := context.AfterFunc(ctx, func() {
+		timer := time.AfterFunc(grace, cancel)
+		context.AfterFunc(drain, func() { timer.Stop() })
+	})
+
+	return drain, func() {
+		stop()
+		cancel()
+	}
+}
@@ -0,0 +1,118 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeHealthReporter struct {
+	mu      sync.Mutex
+	serving map[string]bool
+}
+
+func (f *fakeHealthReporter) SetServingStatus(service string, serving bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.serving[service] = serving
+}
+
+func TestSupervisor_FailureStopsEverything(t *testing.T) {
+	health := &fakeHealthReporter{serving: map[string]bool{}}
+	supervisor := NewSupervisor(context.Background(), health)
+
+	stopped := make(chan struct{})
+	supervisor.Go("monitor", func(ctx context.Context) error {
+		<-ctx.Done()
+		close(stopped)
+
+		return nil
+	})
+	supervisor.Go("rpc", func(ctx context.Context) error {
+		return errors.New("address already in use")
+	})
+
+	err := supervisor.Wait(time.Second)
+	require.ErrorContains(t, err, "subsystem rpc failed: address already in use")
+	<-stopped
+
+	require.Equal(t, []SubsystemHealth{
+		{Name: "monitor", State: SubsystemStopped},
+		{Name: "rpc", State: SubsystemFailed, Error: "address already in use"},
+	}, withoutSince(supervisor.Health()))
+	require.Equal(t, map[string]bool{"": false, "monitor": false, "rpc": false}, health.serving)
+}
+
+func TestSupervisor_Nested(t *testing.T) {
+	health := &fakeHealthReporter{serving: map[string]bool{"": true}}
+	supervisor := NewSupervisor(context.Background(), health)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	leader := supervisor.Nested(ctx)
+	leader.Go("monitor", func(ctx context.Context) error {
+		<-ctx.Done()
+
+		return nil
+	})
+	require.True(t, health.serving["monitor"])
+
+	// Losing the leadership stops the leader subsystems, not the daemon
+	cancel()
+	require.NoError(t, leader.Wait(time.Second))
+	require.Equal(t, map[string]bool{"": true, "monitor": false}, health.serving)
+}
+
+func TestSupervisor_Shutdown(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	supervisor := NewSupervisor(ctx, nil)
+
+	supervisor.Go("notifier", func(ctx context.Context) error {
+		<-ctx.Done()
+
+		return ctx.Err()
+	})
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+	supervisor.Go("stuck", func(ctx context.Context) error {
+		<-release
+
+		return nil
+	})
+	require.Equal(t, SubsystemRunning, supervisor.Health()[0].State)
+
+	cancel()
+	err := supervisor.Wait(100 * time.Millisecond)
+	require.ErrorIs(t, err, ErrShutdownTimeout)
+	require.Equal(t, []SubsystemHealth{
+		{Name: "notifier", State: SubsystemStopped},
+		{Name: "stuck", State: SubsystemRunning},
+	}, withoutSince(supervisor.Health()))
+}
+
+func TestDrainContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	drain, stop := drainContext(ctx, 50*time.Millisecond)
+	t.Cleanup(stop)
+
+	cancel()
+	require.NoError(t, drain.Err())
+
+	select {
+	case <-drain.Done():
+	case <-time.After(time.Second):
+		t.Fatal("drain context was not cancelled after the grace period")
+	}
+}
+
+func withoutSince(health []SubsystemHealth) []SubsystemHealth {
+	for i := range health {
+		health[i].Since = time.Time{}
+	}
+
+	return health
+}
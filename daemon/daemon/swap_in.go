@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	"github.com/40acres/40swap/daemon/bitcoin"
+	"github.com/40acres/40swap/daemon/database"
 	"github.com/40acres/40swap/daemon/database/models"
 	"github.com/40acres/40swap/daemon/lightning"
 	"github.com/40acres/40swap/daemon/swaps"
@@ -18,31 +19,35 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
+// saveRefundAttempts is how many times a refund is saved on top of a swap in
+// that keeps changing before giving up
+const saveRefundAttempts = 3
+
 func (m *SwapMonitor) MonitorSwapIn(ctx context.Context, currentSwap *models.SwapIn) error {
-	logger := log.WithField("id", currentSwap.SwapID)
+	logger := log.WithContext(ctx).WithField("id", currentSwap.SwapID)
 	logger.Info("processing swap")
+	if m.watchesCancelledSwapIn(currentSwap) {
+		return m.watchCancelledSwapIn(ctx, currentSwap, logger)
+	}
+	previousStatus := currentSwap.Status
 
 	newSwap, err := m.swapClient.GetSwapIn(ctx, currentSwap.SwapID)
 	switch {
 	case errors.Is(err, swaps.ErrSwapNotFound):
-		logger.Warn("swap not found")
-
-		outcome := models.OutcomeFailed
-		currentSwap.Outcome = &outcome
-		currentSwap.Status = models.StatusDone
+		return m.handleSwapInNotFound(ctx, currentSwap, logger)
+	case err != nil:
+		// The server is unreachable, but if the contract has already expired
+		// we don't need it to get our funds back.
+		if m.canRefundLocally(currentSwap, currentSwap.Status) {
+			logger.WithError(err).Warn("failed to get swap in from server but the contract has expired, refunding")
 
-		err := m.repository.SaveSwapIn(ctx, currentSwap)
-		if err != nil {
-			return fmt.Errorf("failed to save swap in: %w", err)
+			return m.refundExpiredSwapIn(ctx, currentSwap)
 		}
 
-		return nil
-	case err != nil:
 		return fmt.Errorf("failed to get swap in: %w", err)
 	}
 
 	newStatus := models.SwapStatus(newSwap.Status)
-	changed := currentSwap.Status != newStatus
 
 	// Update contract information from backend if available
 	contractChanged := false
@@ -62,6 +67,24 @@ func (m *SwapMonitor) MonitorSwapIn(ctx context.Context, currentSwap *models.Swa
 		}
 	}
 
+	// Don't rely on the server to tell us the contract expired
+	message := ""
+	if newStatus != models.StatusContractExpired && m.canRefundLocally(currentSwap, newStatus) {
+		logger.Warnf("server reports status %s but the contract expired at block %d (current block %d)",
+			newStatus, currentSwap.TimeoutBlockHeight, m.BlockHeight())
+		message = fmt.Sprintf("server reported status %s but the contract expired at block %d (current block %d)",
+			newStatus, currentSwap.TimeoutBlockHeight, m.BlockHeight())
+		newStatus = models.StatusContractExpired
+	}
+	changed := currentSwap.Status != newStatus
+	refunded := false
+
+	if currentSwap.NotFoundSince != nil {
+		logger.Info("swap found in the server again")
+		currentSwap.NotFoundSince = nil
+		contractChanged = true
+	}
+
 	switch newStatus {
 	case models.StatusCreated:
 		// Do nothing
@@ -82,6 +105,7 @@ func (m *SwapMonitor) MonitorSwapIn(ctx context.Context, currentSwap *models.Swa
 	case models.StatusContractClaimedUnconfirmed:
 		logger.Debug("40swap has paid your lightning invoice and claimed the on-chain funds, waiting for confirmation")
 	case models.StatusDone:
+		m.recordFiatRate(ctx, &currentSwap.FiatCurrency, &currentSwap.FiatRate, logger)
 		switch models.SwapOutcome(newSwap.Outcome) {
 		case models.OutcomeRefunded:
 			outcome := models.OutcomeRefunded
@@ -107,13 +131,11 @@ func (m *SwapMonitor) MonitorSwapIn(ctx context.Context, currentSwap *models.Swa
 		log.Debug("the refund has been sent, waiting for on-chain confirmation")
 	case models.StatusContractExpired:
 		if currentSwap.RefundRequestedAt.IsZero() { // check refund was requested
-			currentSwap.RefundRequestedAt = m.now()
 			log.Info("on-chain contract expired. initiating a refund")
-			txId, err := m.InitiateRefund(ctx, currentSwap)
-			if err != nil {
-				return fmt.Errorf("failed to initiate refund: %w", err)
+			if err := m.requestRefund(ctx, currentSwap); err != nil {
+				return err
 			}
-			currentSwap.RefundTxID = txId
+			refunded = true
 		} else {
 			log.Debug("on-chain contract expired. Refund is in-progress")
 		}
@@ -121,10 +143,25 @@ func (m *SwapMonitor) MonitorSwapIn(ctx context.Context, currentSwap *models.Swa
 
 	if changed || contractChanged {
 		currentSwap.Status = newStatus
-		err := m.repository.SaveSwapIn(ctx, currentSwap)
-		if err != nil {
+		// Only saved if it wasn't cancelled in the meantime
+		err := m.repository.SaveSwapInIf(ctx, currentSwap, previousStatus)
+		switch {
+		case errors.Is(err, database.ErrSwapChanged):
+			logger.Info("swap changed while processing it, leaving it to the next poll")
+			if refunded {
+				return m.saveRefund(ctx, currentSwap)
+			}
+
+			return nil
+		case err != nil:
 			return fmt.Errorf("failed to save swap in: %w", err)
 		}
+
+		if changed {
+			event := models.NewSwapInEvent(currentSwap, &previousStatus)
+			event.Message = message
+			m.recordEvent(ctx, event)
+		}
 	}
 
 	logger.Debug("swap in processed")
@@ -132,6 +169,191 @@ func (m *SwapMonitor) MonitorSwapIn(ctx context.Context, currentSwap *models.Swa
 	return nil
 }
 
+// handleSwapInNotFound keeps a swap in unknown to the server pending until the
+// grace period is over and its contract holds no funds, then marks it failed.
+func (m *SwapMonitor) handleSwapInNotFound(ctx context.Context, currentSwap *models.SwapIn, logger *log.Entry) error {
+	// The funds are ours again once the contract expires, server or not
+	if m.canRefundLocally(currentSwap, currentSwap.Status) {
+		logger.Warn("swap not found in the server but the contract has expired, refunding")
+
+		return m.refundExpiredSwapIn(ctx, currentSwap)
+	}
+
+	previousStatus := currentSwap.Status
+	firstTime := m.markNotFound(&currentSwap.NotFoundSince)
+	keep, err := m.keepNotFoundSwap(ctx, *currentSwap.NotFoundSince, currentSwap.ClaimAddress, logger)
+	if err != nil {
+		return err
+	}
+	if keep && !firstTime {
+		return nil
+	}
+
+	if !keep {
+		logger.Warn("swap not found in the server, marking it as failed")
+		outcome := models.OutcomeFailed
+		currentSwap.Outcome = &outcome
+		currentSwap.Status = models.StatusDone
+	}
+
+	err = m.repository.SaveSwapInIf(ctx, currentSwap, previousStatus)
+	switch {
+	case errors.Is(err, database.ErrSwapChanged):
+		logger.Info("swap changed while processing it, leaving it to the next poll")
+
+		return nil
+	case err != nil:
+		return fmt.Errorf("failed to save swap in: %w", err)
+	}
+
+	event := models.NewSwapInEvent(currentSwap, &previousStatus)
+	event.Message = notFoundMessage(keep)
+	m.recordEvent(ctx, event)
+
+	return nil
+}
+
+// watchesCancelledSwapIn reports whether a swap in is cancelled but its
+// contract, which the user may still fund, hasn't expired yet
+func (m *SwapMonitor) watchesCancelledSwapIn(swap *models.SwapIn) bool {
+	return swap.Status == models.StatusDone &&
+		swap.Outcome != nil && *swap.Outcome == models.OutcomeCancelled &&
+		swap.ClaimAddress != "" && swap.TimeoutBlockHeight >= m.BlockHeight()
+}
+
+// watchCancelledSwapIn looks for a lockup sent to the contract of a cancelled
+// swap in. A funded contract is monitored again, so it's refunded once it
+// expires like the contract of any other swap in.
+func (m *SwapMonitor) watchCancelledSwapIn(ctx context.Context, swap *models.SwapIn, logger *log.Entry) error {
+	txs, err := m.bitcoin.GetTxsFromAddress(ctx, swap.ClaimAddress)
+	if err != nil {
+		return fmt.Errorf("failed to check contract address %s: %w", swap.ClaimAddress, err)
+	}
+	lockTx := bitcoin.FindTxPayingToAddress(txs, swap.ClaimAddress, m.network)
+	if lockTx == nil || !bitcoin.HasUnspentOutputToAddress(txs, swap.ClaimAddress, m.network) {
+		logger.Debugf("cancelled swap not funded, watching its contract until block %d", swap.TimeoutBlockHeight)
+
+		return nil
+	}
+
+	logger.Warnf("contract of the cancelled swap funded in tx %s, it will be refunded once it expires", lockTx.TxHash())
+	previousStatus := swap.Status
+	swap.Status = models.StatusContractFundedUnconfirmed
+	swap.Outcome = nil
+	swap.LockTxID = lockTx.TxHash().String()
+	err = m.repository.SaveSwapInIf(ctx, swap, previousStatus)
+	switch {
+	case errors.Is(err, database.ErrSwapChanged):
+		logger.Info("swap changed while processing it, leaving it to the next poll")
+
+		return nil
+	case err != nil:
+		return fmt.Errorf("failed to save swap in: %w", err)
+	}
+
+	event := models.NewSwapInEvent(swap, &previousStatus)
+	event.Message = fmt.Sprintf("contract funded after the swap was cancelled, refunded once it expires at block %d", swap.TimeoutBlockHeight)
+	m.recordEvent(ctx, event)
+
+	return nil
+}
+
+// canRefundLocally reports whether the swap in timeout has been reached
+// according to the block height tracked by the monitor and the contract may
+// have been funded, so a refund can be attempted without the server.
+func (m *SwapMonitor) canRefundLocally(swap *models.SwapIn, status models.SwapStatus) bool {
+	height := m.BlockHeight()
+	if height == 0 || swap.TimeoutBlockHeight == 0 || height < swap.TimeoutBlockHeight {
+		return false
+	}
+
+	switch status {
+	case models.StatusContractFundedUnconfirmed, models.StatusContractFunded, models.StatusContractExpired:
+		return true
+	case models.StatusCreated:
+		// Only worth trying if we know about a lock transaction
+		return swap.LockTxID != ""
+	default:
+		return false
+	}
+}
+
+// refundExpiredSwapIn refunds a swap in whose contract expired without
+// involving the swap server and persists the result.
+func (m *SwapMonitor) refundExpiredSwapIn(ctx context.Context, swap *models.SwapIn) error {
+	if !swap.RefundRequestedAt.IsZero() {
+		log.WithField("id", swap.SwapID).Debug("on-chain contract expired. Refund is in-progress")
+
+		return nil
+	}
+
+	previousStatus := swap.Status
+	if err := m.requestRefund(ctx, swap); err != nil {
+		return err
+	}
+
+	swap.Status = models.StatusContractExpired
+	err := m.repository.SaveSwapInIf(ctx, swap, previousStatus)
+	switch {
+	case errors.Is(err, database.ErrSwapChanged):
+		log.WithField("id", swap.SwapID).Infof("swap changed while refunding it in tx %s, saving the refund on top of it", swap.RefundTxID)
+
+		return m.saveRefund(ctx, swap)
+	case err != nil:
+		return fmt.Errorf("failed to save swap in: %w", err)
+	}
+
+	event := models.NewSwapInEvent(swap, &previousStatus)
+	event.Message = fmt.Sprintf("contract expired at block %d (current block %d), refunded without the server",
+		swap.TimeoutBlockHeight, m.BlockHeight())
+	m.recordEvent(ctx, event)
+
+	return nil
+}
+
+// saveRefund persists the refund of a swap in that changed while it was being
+// refunded on top of its current state, the refund tx is already broadcast so
+// it can't be requested again
+func (m *SwapMonitor) saveRefund(ctx context.Context, swap *models.SwapIn) error {
+	for range saveRefundAttempts {
+		current, err := m.repository.GetSwapIn(ctx, swap.SwapID)
+		if err != nil {
+			return fmt.Errorf("failed to get swap in to save refund tx %s: %w", swap.RefundTxID, err)
+		}
+		current.RefundTxID = swap.RefundTxID
+		current.RefundRequestedAt = swap.RefundRequestedAt
+		err = m.repository.SaveSwapInIf(ctx, current, current.Status)
+		switch {
+		case errors.Is(err, database.ErrSwapChanged):
+			continue
+		case err != nil:
+			return fmt.Errorf("failed to save refund tx %s: %w", swap.RefundTxID, err)
+		}
+		*swap = *current
+
+		return nil
+	}
+
+	return fmt.Errorf("failed to save refund tx %s: %w", swap.RefundTxID, database.ErrSwapChanged)
+}
+
+// requestRefund marks the refund as requested and broadcasts the refund tx.
+func (m *SwapMonitor) requestRefund(ctx context.Context, swap *models.SwapIn) error {
+	swap.RefundRequestedAt = m.now()
+	txId, err := m.InitiateRefund(ctx, swap)
+	if err != nil {
+		event := models.NewSwapInEvent(swap, &swap.Status)
+		event.Message = "refund failed"
+		event.Error = err.Error()
+		m.recordEvent(ctx, event)
+
+		return fmt.Errorf("failed to initiate refund: %w", err)
+	}
+	swap.RefundTxID = txId
+
+	return nil
+}
+
 func (m *SwapMonitor) InitiateRefund(ctx context.Context, swap *models.SwapIn) (string, error) {
 	logger := log.WithFields(log.Fields{
 		"swap_id": swap.SwapID,
@@ -166,8 +388,9 @@ func (m *SwapMonitor) InitiateRefund(ctx context.Context, swap *models.SwapIn) (
 			swap.LockTxID = txId
 			logger.Debugf("Retrieved lock transaction ID from backend: %s", txId)
 
-			// Save the updated swap with the lock transaction ID
-			err = m.repository.SaveSwapIn(ctx, swap)
+			// Save the updated swap with the lock transaction ID, unless it
+			// changed since it was read
+			err = m.repository.SaveSwapInIf(ctx, swap, swap.Status)
 			if err != nil {
 				return "", fmt.Errorf("failed to save swap with lock tx id: %w", err)
 			}
@@ -2,35 +2,34 @@ package daemon
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/40acres/40swap/daemon/bitcoin"
+	"github.com/40acres/40swap/daemon/database"
 	"github.com/40acres/40swap/daemon/database/models"
+	"github.com/40acres/40swap/daemon/lightning"
 	"github.com/40acres/40swap/daemon/swaps"
+	"github.com/lightningnetwork/lnd/zpay32"
 	decodepay "github.com/nbd-wtf/ln-decodepay"
 	log "github.com/sirupsen/logrus"
 )
 
+// paymentTrackTimeout is how long a poll waits for the result of a payment in
+// flight before leaving it for the next one
+const paymentTrackTimeout = 5 * time.Second
+
 func (m *SwapMonitor) MonitorSwapOut(ctx context.Context, currentSwap *models.SwapOut) error {
-	logger := log.WithField("id", currentSwap.SwapID)
+	logger := log.WithContext(ctx).WithField("id", currentSwap.SwapID)
 	logger.Info("processing swap out")
+	previousStatus := currentSwap.Status
 
 	newSwap, err := m.swapClient.GetSwapOut(ctx, currentSwap.SwapID)
 	switch {
 	case errors.Is(err, swaps.ErrSwapNotFound):
-		logger.Warn("swap not found")
-
-		outcome := models.OutcomeFailed
-		currentSwap.Outcome = &outcome
-		currentSwap.Status = models.StatusDone
-
-		err := m.repository.SaveSwapOut(ctx, currentSwap)
-		if err != nil {
-			return fmt.Errorf("failed to save swap out: %w", err)
-		}
-
-		return nil
+		return m.handleSwapOutNotFound(ctx, currentSwap, logger)
 	case err != nil:
 		return fmt.Errorf("failed to get swap out: %w", err)
 	}
@@ -55,6 +54,12 @@ func (m *SwapMonitor) MonitorSwapOut(ctx context.Context, currentSwap *models.Sw
 		}
 	}
 
+	if currentSwap.NotFoundSince != nil {
+		logger.Info("swap found in the server again")
+		currentSwap.NotFoundSince = nil
+		contractChanged = true
+	}
+
 	switch newStatus {
 	case models.StatusCreated:
 		logger.Debug("waiting for payment")
@@ -68,6 +73,12 @@ func (m *SwapMonitor) MonitorSwapOut(ctx context.Context, currentSwap *models.Sw
 		logger.Debug("contract funded confirmed, claiming on-chain tx")
 		tx, err := m.ClaimSwapOut(ctx, currentSwap)
 		if err != nil {
+			event := models.NewSwapOutEvent(currentSwap, &previousStatus)
+			event.ToStatus = newStatus
+			event.Message = "claim failed"
+			event.Error = err.Error()
+			m.recordEvent(ctx, event)
+
 			return fmt.Errorf("failed to claim swap out: %w", err)
 		}
 		// Save the transaction ID
@@ -77,6 +88,7 @@ func (m *SwapMonitor) MonitorSwapOut(ctx context.Context, currentSwap *models.Sw
 	case models.StatusDone:
 		// Once it gets to DONE, we update the outcome
 		currentSwap.Outcome = &newSwap.Outcome
+		m.recordFiatRate(ctx, &currentSwap.FiatCurrency, &currentSwap.FiatRate, logger)
 
 		// Only calculate fees for successful swaps
 		// For refunded/failed/expired swaps, the payment never completed successfully
@@ -98,12 +110,41 @@ func (m *SwapMonitor) MonitorSwapOut(ctx context.Context, currentSwap *models.Sw
 		logger.Debug("contract refunded unconfirmed")
 	}
 
-	if changed || contractChanged {
-		currentSwap.Status = newStatus
-		err := m.repository.SaveSwapOut(ctx, currentSwap)
+	paymentChanged := false
+	switch currentSwap.PaymentStatus {
+	case models.PaymentUnknown:
+		paymentChanged, err = m.checkSwapOutPayment(ctx, currentSwap, logger)
 		if err != nil {
+			return err
+		}
+	case models.PaymentPending, models.PaymentInFlight:
+		paymentChanged, err = m.paySwapOut(ctx, currentSwap, newStatus, logger)
+		switch {
+		case errors.Is(err, database.ErrSwapChanged):
+			logger.Info("swap changed while paying its invoice, leaving it to the next poll")
+
+			return nil
+		case err != nil:
+			return err
+		}
+	}
+
+	if changed || contractChanged || paymentChanged {
+		currentSwap.Status = newStatus
+		// Only saved if it wasn't cancelled in the meantime
+		err := m.repository.SaveSwapOutIf(ctx, currentSwap, previousStatus)
+		switch {
+		case errors.Is(err, database.ErrSwapChanged):
+			logger.Info("swap changed while processing it, leaving it to the next poll")
+
+			return nil
+		case err != nil:
 			return fmt.Errorf("failed to save swap out: %w", err)
 		}
+
+		if changed {
+			m.recordEvent(ctx, models.NewSwapOutEvent(currentSwap, &previousStatus))
+		}
 	}
 
 	logger.Debug("swap out processed")
@@ -111,6 +152,164 @@ func (m *SwapMonitor) MonitorSwapOut(ctx context.Context, currentSwap *models.Sw
 	return nil
 }
 
+// checkSwapOutPayment asks the lightning node about the payment of a swap out
+// created before payments were tracked. A payment the node knows about is
+// followed as any other, the rest are given up on so they're never sent again.
+// It reports whether the payment changed.
+func (m *SwapMonitor) checkSwapOutPayment(ctx context.Context, swap *models.SwapOut, logger *log.Entry) (bool, error) {
+	invoice, err := zpay32.Decode(swap.PaymentRequest, lightning.ToChainCfgNetwork(m.network))
+	if err != nil {
+		return false, fmt.Errorf("failed to decode invoice: %w", err)
+	}
+
+	trackCtx, cancel := context.WithTimeout(ctx, paymentTrackTimeout)
+	defer cancel()
+
+	_, offchainFees, err := m.lightningClient.MonitorPaymentRequest(trackCtx, hex.EncodeToString(invoice.PaymentHash[:]))
+	switch {
+	case err == nil:
+		logger.Info("invoice paid")
+		swap.PaymentStatus = models.PaymentSucceeded
+		swap.OffchainFeeSats = offchainFees
+		m.recordPaymentEvent(ctx, swap, "invoice paid")
+	case errors.Is(err, lightning.ErrPaymentFailed), errors.Is(err, lightning.ErrPaymentNotInitiated):
+		logger.Warnf("invoice wasn't paid before payments were tracked, not paying it: %v", err)
+		swap.PaymentStatus = models.PaymentFailed
+		swap.PaymentError = err.Error()
+		m.recordPaymentEvent(ctx, swap, "invoice not paid, it wasn't paid before payments were tracked")
+	case trackCtx.Err() != nil && ctx.Err() == nil:
+		logger.Info("invoice payment in flight")
+		swap.PaymentStatus = models.PaymentInFlight
+	default:
+		return false, fmt.Errorf("failed to track invoice payment: %w", err)
+	}
+
+	return true, nil
+}
+
+// paySwapOut moves the payment of the invoice of a swap out forward: a payment
+// in flight is tracked and a pending one is sent to the lightning node, so the
+// payments that fail are retried until the invoice expires. It reports whether
+// the payment changed.
+func (m *SwapMonitor) paySwapOut(ctx context.Context, swap *models.SwapOut, serverStatus models.SwapStatus, logger *log.Entry) (bool, error) {
+	invoice, err := zpay32.Decode(swap.PaymentRequest, lightning.ToChainCfgNetwork(m.network))
+	if err != nil {
+		return false, fmt.Errorf("failed to decode invoice: %w", err)
+	}
+	previous := swap.PaymentStatus
+
+	if swap.PaymentStatus == models.PaymentInFlight {
+		trackCtx, cancel := context.WithTimeout(ctx, paymentTrackTimeout)
+		defer cancel()
+
+		_, offchainFees, err := m.lightningClient.MonitorPaymentRequest(trackCtx, hex.EncodeToString(invoice.PaymentHash[:]))
+		switch {
+		case err == nil:
+			logger.Info("invoice paid")
+			swap.PaymentStatus = models.PaymentSucceeded
+			swap.PaymentError = ""
+			swap.OffchainFeeSats = offchainFees
+			m.recordPaymentEvent(ctx, swap, "invoice paid")
+
+			return true, nil
+		case errors.Is(err, lightning.ErrPaymentFailed), errors.Is(err, lightning.ErrPaymentNotInitiated):
+			logger.Warnf("invoice payment failed: %v", err)
+			swap.PaymentStatus = models.PaymentPending
+			swap.PaymentError = err.Error()
+			m.recordPaymentEvent(ctx, swap, "invoice payment failed")
+		case trackCtx.Err() != nil && ctx.Err() == nil:
+			logger.Debug("invoice payment in flight")
+
+			return false, nil
+		default:
+			return false, fmt.Errorf("failed to track invoice payment: %w", err)
+		}
+	}
+
+	switch {
+	case serverStatus == models.StatusDone || serverStatus == models.StatusContractExpired:
+		logger.Warn("swap is over, not paying its invoice")
+		swap.PaymentStatus = models.PaymentFailed
+		m.recordPaymentEvent(ctx, swap, "invoice not paid, the swap is over")
+
+		return true, nil
+	case serverStatus != models.StatusCreated:
+		// The server got a payment, the node will report it when tracked
+		return swap.PaymentStatus != previous, nil
+	case m.now().After(invoice.Timestamp.Add(invoice.Expiry())):
+		logger.Warn("invoice expired, not paying it")
+		swap.PaymentStatus = models.PaymentFailed
+		m.recordPaymentEvent(ctx, swap, "invoice not paid, it expired")
+
+		return true, nil
+	}
+
+	// Saved as in flight before paying, so a payment interrupted by a restart
+	// is tracked instead of sent twice. The save fails if the swap was
+	// cancelled since it was read, and then it isn't paid.
+	swap.PaymentStatus = models.PaymentInFlight
+	swap.PaymentAttempts++
+	if err := m.repository.SaveSwapOutIf(ctx, swap, swap.Status, previous); err != nil {
+		return false, fmt.Errorf("failed to save swap out: %w", err)
+	}
+
+	logger.Infof("paying invoice, attempt %d", swap.PaymentAttempts)
+	if err := m.lightningClient.PayInvoice(ctx, swap.PaymentRequest, swap.MaxRoutingFeeRatio); err != nil {
+		// Tracking the payment tells whether the node started it
+		logger.Warnf("failed to pay invoice: %v", err)
+		swap.PaymentError = err.Error()
+		m.recordPaymentEvent(ctx, swap, "invoice payment failed")
+	}
+
+	return true, nil
+}
+
+// recordPaymentEvent records a change of the payment of a swap out, which
+// doesn't change its status
+func (m *SwapMonitor) recordPaymentEvent(ctx context.Context, swap *models.SwapOut, message string) {
+	event := models.NewSwapOutEvent(swap, &swap.Status)
+	event.Message = message
+	event.Error = swap.PaymentError
+	m.recordEvent(ctx, event)
+}
+
+// handleSwapOutNotFound keeps a swap out unknown to the server pending until
+// the grace period is over and its contract holds no funds, then marks it failed.
+func (m *SwapMonitor) handleSwapOutNotFound(ctx context.Context, currentSwap *models.SwapOut, logger *log.Entry) error {
+	previousStatus := currentSwap.Status
+	firstTime := m.markNotFound(&currentSwap.NotFoundSince)
+	keep, err := m.keepNotFoundSwap(ctx, *currentSwap.NotFoundSince, currentSwap.ContractAddress, logger)
+	if err != nil {
+		return err
+	}
+	if keep && !firstTime {
+		return nil
+	}
+
+	if !keep {
+		logger.Warn("swap not found in the server, marking it as failed")
+		outcome := models.OutcomeFailed
+		currentSwap.Outcome = &outcome
+		currentSwap.Status = models.StatusDone
+	}
+
+	err = m.repository.SaveSwapOutIf(ctx, currentSwap, previousStatus)
+	switch {
+	case errors.Is(err, database.ErrSwapChanged):
+		logger.Info("swap changed while processing it, leaving it to the next poll")
+
+		return nil
+	case err != nil:
+		return fmt.Errorf("failed to save swap out: %w", err)
+	}
+
+	event := models.NewSwapOutEvent(currentSwap, &previousStatus)
+	event.Message = notFoundMessage(keep)
+	m.recordEvent(ctx, event)
+
+	return nil
+}
+
 func (m *SwapMonitor) ClaimSwapOut(ctx context.Context, swap *models.SwapOut) (string, error) {
 	logger := log.WithField("id", swap.SwapID)
 
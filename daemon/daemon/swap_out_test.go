@@ -2,16 +2,23 @@ package daemon
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/40acres/40swap/daemon/bitcoin"
+	"github.com/40acres/40swap/daemon/database"
 	"github.com/40acres/40swap/daemon/database/models"
 	"github.com/40acres/40swap/daemon/lightning"
 	"github.com/40acres/40swap/daemon/rpc"
 	"github.com/40acres/40swap/daemon/swaps"
+	"github.com/btcsuite/btcd/wire"
 	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/zpay32"
+	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
@@ -140,6 +147,7 @@ func TestSwapMonitor_MonitorSwapOut(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	repository := rpc.NewMockRepository(ctrl)
+	repository.EXPECT().SaveSwapEvent(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 	swapClient := swaps.NewMockClientInterface(ctrl)
 	bitcoinClient := bitcoin.NewMockClient(ctrl)
 	now := func() time.Time {
@@ -171,7 +179,7 @@ func TestSwapMonitor_MonitorSwapOut(t *testing.T) {
 			name: "get swap not found",
 			setup: func() *SwapMonitor {
 				swapClient.EXPECT().GetSwapOut(ctx, gomock.Any()).Return(nil, swaps.ErrSwapNotFound)
-				repository.EXPECT().SaveSwapOut(ctx, gomock.Any()).Return(nil)
+				repository.EXPECT().SaveSwapOutIf(ctx, gomock.Any(), gomock.Any()).Return(nil)
 
 				return &swapMonitor
 			},
@@ -186,7 +194,7 @@ func TestSwapMonitor_MonitorSwapOut(t *testing.T) {
 			name: "get swap not found fail saving",
 			setup: func() *SwapMonitor {
 				swapClient.EXPECT().GetSwapOut(ctx, gomock.Any()).Return(nil, swaps.ErrSwapNotFound)
-				repository.EXPECT().SaveSwapOut(ctx, gomock.Any()).Return(errors.New("error saving swap out"))
+				repository.EXPECT().SaveSwapOutIf(ctx, gomock.Any(), gomock.Any()).Return(errors.New("error saving swap out"))
 
 				return &swapMonitor
 			},
@@ -197,6 +205,22 @@ func TestSwapMonitor_MonitorSwapOut(t *testing.T) {
 			wantErr: true,
 			err:     errors.New("failed to save swap out: error saving swap out"),
 		},
+		{
+			name: "get swap not found cancelled meanwhile",
+			setup: func() *SwapMonitor {
+				swapClient.EXPECT().GetSwapOut(ctx, gomock.Any()).Return(nil, swaps.ErrSwapNotFound)
+				repository.EXPECT().SaveSwapOutIf(ctx, gomock.Any(), models.StatusCreated).Return(database.ErrSwapChanged)
+
+				return &swapMonitor
+			},
+			args: args{
+				ctx: ctx,
+				currentSwap: models.SwapOut{
+					Status: models.StatusCreated,
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "get swap failed",
 			setup: func() *SwapMonitor {
@@ -368,6 +392,7 @@ func TestSwapMonitor_MonitorSwapOut_StatusDone_Outcomes(t *testing.T) {
 			defer ctrl.Finish()
 
 			repository := rpc.NewMockRepository(ctrl)
+			repository.EXPECT().SaveSwapEvent(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 			swapClient := swaps.NewMockClientInterface(ctrl)
 			bitcoinClient := bitcoin.NewMockClient(ctrl)
 			lightningClient := lightning.NewMockClient(ctrl)
@@ -405,7 +430,7 @@ func TestSwapMonitor_MonitorSwapOut_StatusDone_Outcomes(t *testing.T) {
 					lightningClient.EXPECT().MonitorPaymentRequest(gomock.Any(), gomock.Any()).Return("preimage", tt.expectedOffchain, nil)
 					bitcoinClient.EXPECT().GetFeeFromTxId(ctx, "test-tx-id").Return(tt.expectedOnchain, nil)
 					// Mock repository save for success case
-					repository.EXPECT().SaveSwapOut(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, swap *models.SwapOut) error {
+					repository.EXPECT().SaveSwapOutIf(ctx, gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, swap *models.SwapOut, _ models.SwapStatus, _ ...models.PaymentStatus) error {
 						// Verify the fees were set correctly
 						require.Equal(t, tt.expectedOffchain, swap.OffchainFeeSats)
 						require.Equal(t, tt.expectedOnchain, swap.OnchainFeeSats)
@@ -417,7 +442,7 @@ func TestSwapMonitor_MonitorSwapOut_StatusDone_Outcomes(t *testing.T) {
 				}
 			} else {
 				// For non-success outcomes, expect repository save with zero fees
-				repository.EXPECT().SaveSwapOut(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, swap *models.SwapOut) error {
+				repository.EXPECT().SaveSwapOutIf(ctx, gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, swap *models.SwapOut, _ models.SwapStatus, _ ...models.PaymentStatus) error {
 					// Verify the fees were set correctly
 					require.Equal(t, tt.expectedOffchain, swap.OffchainFeeSats)
 					require.Equal(t, tt.expectedOnchain, swap.OnchainFeeSats)
@@ -439,3 +464,322 @@ func TestSwapMonitor_MonitorSwapOut_StatusDone_Outcomes(t *testing.T) {
 		})
 	}
 }
+
+func TestSwapMonitor_MonitorSwapOut_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repository := rpc.NewMockRepository(ctrl)
+	repository.EXPECT().SaveSwapEvent(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	swapClient := swaps.NewMockClientInterface(ctrl)
+	bitcoinClient := bitcoin.NewMockClient(ctrl)
+	now := func() time.Time {
+		return time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)
+	}
+	ctx := context.Background()
+	swapMonitor := SwapMonitor{
+		repository:          repository,
+		swapClient:          swapClient,
+		bitcoin:             bitcoinClient,
+		network:             lightning.Regtest,
+		now:                 now,
+		notFoundGracePeriod: time.Hour,
+	}
+
+	withinGrace := now().Add(-10 * time.Minute)
+	afterGrace := now().Add(-2 * time.Hour)
+	tests := []struct {
+		name  string
+		setup func()
+		swap  models.SwapOut
+	}{
+		{
+			name: "within the grace period",
+			setup: func() {
+				swapClient.EXPECT().GetSwapOut(ctx, gomock.Any()).Return(nil, swaps.ErrSwapNotFound)
+			},
+			swap: models.SwapOut{
+				Status:          models.StatusContractFunded,
+				ContractAddress: validRefundAddress,
+				NotFoundSince:   &withinGrace,
+			},
+		},
+		{
+			name: "after the grace period with funds in the contract",
+			setup: func() {
+				swapClient.EXPECT().GetSwapOut(ctx, gomock.Any()).Return(nil, swaps.ErrSwapNotFound)
+				bitcoinClient.EXPECT().GetTxsFromAddress(ctx, validRefundAddress).
+					Return([]*wire.MsgTx{lockTxPayingTo(t, validRefundAddress)}, nil)
+			},
+			swap: models.SwapOut{
+				Status:          models.StatusContractFunded,
+				ContractAddress: validRefundAddress,
+				NotFoundSince:   &afterGrace,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			// No expectations on the repository: the swap must be left untouched
+			err := swapMonitor.MonitorSwapOut(ctx, &tt.swap)
+			require.NoError(t, err)
+			require.Equal(t, models.StatusContractFunded, tt.swap.Status)
+			require.Nil(t, tt.swap.Outcome)
+		})
+	}
+}
+
+func TestSwapMonitor_paySwapOut(t *testing.T) {
+	ctx := context.Background()
+	invoice := lightning.CreateMockInvoice(t, 1000)
+	expiredInvoice := lightning.CreateMockInvoice(t, 1000, func(invoice *zpay32.Invoice) {
+		invoice.Timestamp = time.Now().Add(-2 * time.Hour)
+	})
+	paymentHash := hex.EncodeToString(lightning.TestPaymentHash[:])
+
+	tests := []struct {
+		name          string
+		paymentStatus models.PaymentStatus
+		invoice       string
+		serverStatus  models.SwapStatus
+		setup         func(lightningClient *lightning.MockClient, repository *rpc.MockRepository)
+		wantChanged   bool
+		wantStatus    models.PaymentStatus
+		wantAttempts  int64
+		wantError     string
+		wantFees      int64
+	}{
+		{
+			name:          "pending payment is sent",
+			paymentStatus: models.PaymentPending,
+			serverStatus:  models.StatusCreated,
+			setup: func(lightningClient *lightning.MockClient, repository *rpc.MockRepository) {
+				repository.EXPECT().SaveSwapOutIf(ctx, gomock.Any(), models.StatusCreated, models.PaymentPending).DoAndReturn(func(_ context.Context, swap *models.SwapOut, _ models.SwapStatus, _ ...models.PaymentStatus) error {
+					require.Equal(t, models.PaymentInFlight, swap.PaymentStatus)
+
+					return nil
+				})
+				lightningClient.EXPECT().PayInvoice(ctx, invoice, 0.005).Return(nil)
+			},
+			wantChanged:  true,
+			wantStatus:   models.PaymentInFlight,
+			wantAttempts: 1,
+		},
+		{
+			name:          "payment the node rejects is tracked",
+			paymentStatus: models.PaymentPending,
+			serverStatus:  models.StatusCreated,
+			setup: func(lightningClient *lightning.MockClient, repository *rpc.MockRepository) {
+				repository.EXPECT().SaveSwapOutIf(ctx, gomock.Any(), models.StatusCreated, models.PaymentPending).Return(nil)
+				lightningClient.EXPECT().PayInvoice(ctx, invoice, 0.005).Return(errors.New("no route"))
+			},
+			wantChanged:  true,
+			wantStatus:   models.PaymentInFlight,
+			wantAttempts: 1,
+			wantError:    "no route",
+		},
+		{
+			name:          "swap cancelled while paying is not paid",
+			paymentStatus: models.PaymentPending,
+			serverStatus:  models.StatusCreated,
+			setup: func(lightningClient *lightning.MockClient, repository *rpc.MockRepository) {
+				// No PayInvoice expectation: the invoice must not be paid
+				repository.EXPECT().SaveSwapOutIf(ctx, gomock.Any(), models.StatusCreated, models.PaymentPending).
+					Return(database.ErrSwapChanged)
+			},
+			wantError: "failed to save swap out: swap changed since it was read",
+		},
+		{
+			name:          "payment in flight succeeds",
+			paymentStatus: models.PaymentInFlight,
+			serverStatus:  models.StatusInvoicePaymentIntentReceived,
+			setup: func(lightningClient *lightning.MockClient, repository *rpc.MockRepository) {
+				lightningClient.EXPECT().MonitorPaymentRequest(gomock.Any(), paymentHash).Return("preimage", int64(3), nil)
+			},
+			wantChanged: true,
+			wantStatus:  models.PaymentSucceeded,
+			wantFees:    3,
+		},
+		{
+			name:          "failed payment is retried",
+			paymentStatus: models.PaymentInFlight,
+			serverStatus:  models.StatusCreated,
+			setup: func(lightningClient *lightning.MockClient, repository *rpc.MockRepository) {
+				lightningClient.EXPECT().MonitorPaymentRequest(gomock.Any(), paymentHash).
+					Return("", int64(0), fmt.Errorf("%w: FAILURE_REASON_NO_ROUTE", lightning.ErrPaymentFailed))
+				repository.EXPECT().SaveSwapOutIf(ctx, gomock.Any(), models.StatusCreated, models.PaymentInFlight).Return(nil)
+				lightningClient.EXPECT().PayInvoice(ctx, invoice, 0.005).Return(nil)
+			},
+			wantChanged:  true,
+			wantStatus:   models.PaymentInFlight,
+			wantAttempts: 1,
+			wantError:    "payment failed: FAILURE_REASON_NO_ROUTE",
+		},
+		{
+			name:          "payment the node never started is sent again",
+			paymentStatus: models.PaymentInFlight,
+			serverStatus:  models.StatusCreated,
+			setup: func(lightningClient *lightning.MockClient, repository *rpc.MockRepository) {
+				lightningClient.EXPECT().MonitorPaymentRequest(gomock.Any(), paymentHash).
+					Return("", int64(0), lightning.ErrPaymentNotInitiated)
+				repository.EXPECT().SaveSwapOutIf(ctx, gomock.Any(), models.StatusCreated, models.PaymentInFlight).Return(nil)
+				lightningClient.EXPECT().PayInvoice(ctx, invoice, 0.005).Return(nil)
+			},
+			wantChanged:  true,
+			wantStatus:   models.PaymentInFlight,
+			wantAttempts: 1,
+			wantError:    "payment not initiated",
+		},
+		{
+			name:          "tracking error is returned",
+			paymentStatus: models.PaymentInFlight,
+			serverStatus:  models.StatusCreated,
+			setup: func(lightningClient *lightning.MockClient, repository *rpc.MockRepository) {
+				lightningClient.EXPECT().MonitorPaymentRequest(gomock.Any(), paymentHash).
+					Return("", int64(0), errors.New("connection refused"))
+			},
+			wantStatus: models.PaymentInFlight,
+			wantError:  "failed to track invoice payment: connection refused",
+		},
+		{
+			name:          "expired invoice is not paid",
+			paymentStatus: models.PaymentPending,
+			invoice:       expiredInvoice,
+			serverStatus:  models.StatusCreated,
+			setup:         func(lightningClient *lightning.MockClient, repository *rpc.MockRepository) {},
+			wantChanged:   true,
+			wantStatus:    models.PaymentFailed,
+		},
+		{
+			name:          "invoice of a swap that is over is not paid",
+			paymentStatus: models.PaymentPending,
+			serverStatus:  models.StatusDone,
+			setup:         func(lightningClient *lightning.MockClient, repository *rpc.MockRepository) {},
+			wantChanged:   true,
+			wantStatus:    models.PaymentFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			lightningClient := lightning.NewMockClient(ctrl)
+			repository := rpc.NewMockRepository(ctrl)
+			repository.EXPECT().SaveSwapEvent(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			tt.setup(lightningClient, repository)
+			monitor := SwapMonitor{
+				repository:      repository,
+				lightningClient: lightningClient,
+				network:         lightning.Regtest,
+				now:             time.Now,
+			}
+
+			swap := &models.SwapOut{
+				SwapID:             "swap_id",
+				Status:             models.StatusCreated,
+				PaymentRequest:     invoice,
+				MaxRoutingFeeRatio: 0.005,
+				PaymentStatus:      tt.paymentStatus,
+			}
+			if tt.invoice != "" {
+				swap.PaymentRequest = tt.invoice
+			}
+
+			changed, err := monitor.paySwapOut(ctx, swap, tt.serverStatus, log.WithField("id", swap.SwapID))
+			if strings.HasPrefix(tt.wantError, "failed to") {
+				require.EqualError(t, err, tt.wantError)
+
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantChanged, changed)
+			require.Equal(t, tt.wantStatus, swap.PaymentStatus)
+			require.Equal(t, tt.wantAttempts, swap.PaymentAttempts)
+			require.Equal(t, tt.wantError, swap.PaymentError)
+			require.Equal(t, tt.wantFees, swap.OffchainFeeSats)
+		})
+	}
+}
+
+func TestSwapMonitor_checkSwapOutPayment(t *testing.T) {
+	ctx := context.Background()
+	invoice := lightning.CreateMockInvoice(t, 1000)
+	paymentHash := hex.EncodeToString(lightning.TestPaymentHash[:])
+
+	tests := []struct {
+		name        string
+		trackErr    error
+		wantChanged bool
+		wantStatus  models.PaymentStatus
+		wantError   string
+		wantFees    int64
+	}{
+		{
+			name:        "paid invoice",
+			wantChanged: true,
+			wantStatus:  models.PaymentSucceeded,
+			wantFees:    3,
+		},
+		{
+			name:        "payment the node never sent is not sent",
+			trackErr:    fmt.Errorf("%w: payment isn't initiated", lightning.ErrPaymentNotInitiated),
+			wantChanged: true,
+			wantStatus:  models.PaymentFailed,
+			wantError:   "payment not initiated: payment isn't initiated",
+		},
+		{
+			name:        "failed payment is not retried",
+			trackErr:    fmt.Errorf("%w: no route", lightning.ErrPaymentFailed),
+			wantChanged: true,
+			wantStatus:  models.PaymentFailed,
+			wantError:   "payment failed: no route",
+		},
+		{
+			name:       "tracking error is returned",
+			trackErr:   errors.New("connection refused"),
+			wantStatus: models.PaymentUnknown,
+			wantError:  "failed to track invoice payment: connection refused",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			lightningClient := lightning.NewMockClient(ctrl)
+			repository := rpc.NewMockRepository(ctrl)
+			repository.EXPECT().SaveSwapEvent(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			monitor := SwapMonitor{
+				repository:      repository,
+				lightningClient: lightningClient,
+				network:         lightning.Regtest,
+				now:             time.Now,
+			}
+
+			var fees int64
+			if tt.trackErr == nil {
+				fees = 3
+			}
+			lightningClient.EXPECT().MonitorPaymentRequest(gomock.Any(), paymentHash).Return("", fees, tt.trackErr)
+
+			swap := &models.SwapOut{
+				SwapID:         "swap_id",
+				Status:         models.StatusCreated,
+				PaymentRequest: invoice,
+				PaymentStatus:  models.PaymentUnknown,
+			}
+			changed, err := monitor.checkSwapOutPayment(ctx, swap, log.WithField("id", swap.SwapID))
+			if strings.HasPrefix(tt.wantError, "failed to") {
+				require.EqualError(t, err, tt.wantError)
+
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantChanged, changed)
+			require.Equal(t, tt.wantStatus, swap.PaymentStatus)
+			require.Equal(t, tt.wantError, swap.PaymentError)
+			require.Equal(t, tt.wantFees, swap.OffchainFeeSats)
+			require.Zero(t, swap.PaymentAttempts)
+		})
+	}
+}
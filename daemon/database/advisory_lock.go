@@ -0,0 +1,99 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// ErrLockNotHeld is returned when checking a lock that isn't held
+var ErrLockNotHeld = errors.New("advisory lock not held")
+
+// AdvisoryLock is a Postgres session advisory lock. It is held on a dedicated
+// connection, so it's released by the server as soon as that connection is
+// lost.
+type AdvisoryLock struct {
+	db   *sql.DB
+	id   int64
+	conn *sql.Conn
+}
+
+// NewAdvisoryLock creates a lock on the database identified by id. Every
+// process using the same id competes for the same lock.
+func (d *Database) NewAdvisoryLock(id int64) (*AdvisoryLock, error) {
+	db, err := d.orm.DB()
+	if err != nil {
+		return nil, fmt.Errorf("could not get database connection: %w", err)
+	}
+
+	return &AdvisoryLock{db: db, id: id}, nil
+}
+
+// TryLock acquires the lock without waiting, reporting whether it was acquired
+func (l *AdvisoryLock) TryLock(ctx context.Context) (bool, error) {
+	if l.conn == nil {
+		conn, err := l.db.Conn(ctx)
+		if err != nil {
+			return false, fmt.Errorf("could not get a connection for the lock: %w", err)
+		}
+		l.conn = conn
+	}
+
+	var acquired bool
+	err := l.conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", l.id).Scan(&acquired)
+	if err != nil {
+		l.reset()
+
+		return false, fmt.Errorf("could not try the advisory lock: %w", err)
+	}
+
+	return acquired, nil
+}
+
+// Check returns an error if the lock is no longer held, like when the
+// connection holding it was lost
+func (l *AdvisoryLock) Check(ctx context.Context) error {
+	if l.conn == nil {
+		return ErrLockNotHeld
+	}
+
+	var held bool
+	err := l.conn.QueryRowContext(ctx,
+		// Postgres splits bigint keys in the high and low halves
+		"SELECT EXISTS (SELECT 1 FROM pg_locks WHERE locktype = 'advisory' AND pid = pg_backend_pid() AND granted AND classid = $1::bigint::oid AND objid = $2::bigint::oid AND objsubid = 1)",
+		uint32(l.id>>32), uint32(l.id), // nolint:gosec
+	).Scan(&held)
+	if err != nil {
+		l.reset()
+
+		return fmt.Errorf("could not check the advisory lock: %w", err)
+	}
+	if !held {
+		return ErrLockNotHeld
+	}
+
+	return nil
+}
+
+// Unlock releases the lock and its connection
+func (l *AdvisoryLock) Unlock(ctx context.Context) error {
+	if l.conn == nil {
+		return nil
+	}
+	defer l.reset()
+
+	_, err := l.conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", l.id)
+	if err != nil {
+		return fmt.Errorf("could not release the advisory lock: %w", err)
+	}
+
+	return nil
+}
+
+func (l *AdvisoryLock) reset() {
+	if l.conn != nil {
+		_ = l.conn.Close()
+		l.conn = nil
+	}
+}
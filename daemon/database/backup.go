@@ -0,0 +1,334 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/40acres/40swap/daemon/database/models"
+	"github.com/go-gormigrate/gormigrate/v2"
+	"golang.org/x/crypto/scrypt"
+	"gorm.io/gorm"
+)
+
+const backupFormatVersion = 1
+
+var (
+	ErrSchemaMismatch    = errors.New("backup schema doesn't match the database")
+	ErrDatabaseNotEmpty  = errors.New("database already has swaps")
+	ErrBackupEncrypted   = errors.New("backup is encrypted, a passphrase is needed")
+	ErrWrongPassphrase   = errors.New("wrong passphrase or corrupted backup")
+	encryptedBackupMagic = []byte("40swapd-encrypted-backup-v1\n")
+)
+
+// Backup is a logical dump of the swap tables. It holds the claim and refund
+// keys of the swaps, so keep it safe.
+type Backup struct {
+	FormatVersion int `json:"formatVersion"`
+	// SchemaVersion is the last migration applied to the database it was taken
+	// from, it can only be restored into a database at the same version
+	SchemaVersion string              `json:"schemaVersion"`
+	CreatedAt     time.Time           `json:"createdAt"`
+	SwapIns       []*models.SwapIn    `json:"swapIns"`
+	SwapOuts      []*models.SwapOut   `json:"swapOuts"`
+	SwapEvents    []*models.SwapEvent `json:"swapEvents"`
+}
+
+// Backup dumps every swap and its events. They are read in a single
+// transaction, so the backup is consistent even while swaps are updated.
+// Webhook notifications are left out, they are only an outbox.
+func (d *Database) Backup(ctx context.Context) (*Backup, error) {
+	version, err := NewMigrator(d.orm).Version()
+	if err != nil {
+		return nil, fmt.Errorf("could not get schema version: %w", err)
+	}
+
+	backup := Backup{
+		FormatVersion: backupFormatVersion,
+		SchemaVersion: version,
+		CreatedAt:     time.Now().UTC(),
+	}
+
+	var opts []*sql.TxOptions
+	if d.Dialect() == DialectPostgres {
+		// A snapshot of the database as of the first query
+		opts = append(opts, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	}
+	err = d.orm.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Order("id").Find(&backup.SwapIns).Error; err != nil {
+			return fmt.Errorf("could not read swap ins: %w", err)
+		}
+		if err := tx.Order("id").Find(&backup.SwapOuts).Error; err != nil {
+			return fmt.Errorf("could not read swap outs: %w", err)
+		}
+		if err := tx.Order("id").Find(&backup.SwapEvents).Error; err != nil {
+			return fmt.Errorf("could not read swap events: %w", err)
+		}
+
+		return nil
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &backup, nil
+}
+
+// Restore loads a backup into an empty database migrated to the schema
+// version the backup was taken at.
+func (d *Database) Restore(ctx context.Context, backup *Backup) error {
+	if backup.FormatVersion != backupFormatVersion {
+		return fmt.Errorf("unsupported backup format version %d", backup.FormatVersion)
+	}
+	if !slices.ContainsFunc(migrations, func(m *gormigrate.Migration) bool { return m.ID == backup.SchemaVersion }) {
+		return fmt.Errorf("%w: backup is at unknown version %q, it was taken by a newer 40swapd", ErrSchemaMismatch, backup.SchemaVersion)
+	}
+	version, err := NewMigrator(d.orm).Version()
+	if err != nil {
+		return fmt.Errorf("could not get schema version: %w", err)
+	}
+	if version != backup.SchemaVersion {
+		return fmt.Errorf("%w: backup is at %q, database at %q", ErrSchemaMismatch, backup.SchemaVersion, version)
+	}
+
+	return d.orm.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, table := range []any{&models.SwapIn{}, &models.SwapOut{}, &models.SwapEvent{}} {
+			var count int64
+			if err := tx.Model(table).Count(&count).Error; err != nil {
+				return fmt.Errorf("could not count swaps: %w", err)
+			}
+			if count > 0 {
+				return ErrDatabaseNotEmpty
+			}
+		}
+
+		if len(backup.SwapIns) > 0 {
+			if err := tx.CreateInBatches(backup.SwapIns, 100).Error; err != nil {
+				return fmt.Errorf("could not restore swap ins: %w", err)
+			}
+		}
+		if len(backup.SwapOuts) > 0 {
+			if err := tx.CreateInBatches(backup.SwapOuts, 100).Error; err != nil {
+				return fmt.Errorf("could not restore swap outs: %w", err)
+			}
+		}
+		if len(backup.SwapEvents) > 0 {
+			if err := tx.CreateInBatches(backup.SwapEvents, 100).Error; err != nil {
+				return fmt.Errorf("could not restore swap events: %w", err)
+			}
+		}
+
+		// updated_at is only written on updates
+		for _, swap := range backup.SwapIns {
+			if err := tx.Model(swap).UpdateColumn("updated_at", swap.UpdatedAt).Error; err != nil {
+				return fmt.Errorf("could not restore swap in %s: %w", swap.SwapID, err)
+			}
+		}
+		for _, swap := range backup.SwapOuts {
+			if err := tx.Model(swap).UpdateColumn("updated_at", swap.UpdatedAt).Error; err != nil {
+				return fmt.Errorf("could not restore swap out %s: %w", swap.SwapID, err)
+			}
+		}
+
+		// Postgres sequences don't move when ids are given, SQLite's do
+		for _, table := range []string{models.TableNameSwapIn, models.TableNameSwapOut, models.TableNameSwapEvent} {
+			err := execPostgres(tx, fmt.Sprintf(
+				"SELECT setval(pg_get_serial_sequence('%[1]s', 'id'), COALESCE((SELECT MAX(id) FROM %[1]s), 0) + 1, false)", table))
+			if err != nil {
+				return fmt.Errorf("could not reset %s sequence: %w", table, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// Redacted returns a copy of the backup without the keys and preimages of the
+// swaps, safe to share.
+func (b *Backup) Redacted() *Backup {
+	redacted := *b
+	redacted.SwapIns = make([]*models.SwapIn, len(b.SwapIns))
+	for i, swap := range b.SwapIns {
+		swap := *swap
+		swap.RefundPrivatekey = ""
+		swap.PreImage = nil
+		redacted.SwapIns[i] = &swap
+	}
+	redacted.SwapOuts = make([]*models.SwapOut, len(b.SwapOuts))
+	for i, swap := range b.SwapOuts {
+		swap := *swap
+		swap.ClaimPrivateKey = ""
+		swap.PreImage = nil
+		redacted.SwapOuts[i] = &swap
+	}
+
+	return &redacted
+}
+
+// WriteBackup writes the backup as JSON, encrypted with the passphrase unless
+// it's empty.
+func WriteBackup(w io.Writer, backup *Backup, passphrase string) error {
+	data, err := json.MarshalIndent(backup, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not encode backup: %w", err)
+	}
+
+	if passphrase != "" {
+		data, err = encryptBackup(data, passphrase)
+		if err != nil {
+			return err
+		}
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("could not write backup: %w", err)
+	}
+
+	return nil
+}
+
+// WriteBackupFile writes the backup to path, only readable by the user. The
+// file is replaced atomically, so a failed write never leaves half a backup.
+func WriteBackupFile(path string, backup *Backup, passphrase string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("could not create backup file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := WriteBackup(tmp, backup, passphrase); err != nil {
+		tmp.Close()
+
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+
+		return fmt.Errorf("could not write backup: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("could not write backup: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("could not write backup: %w", err)
+	}
+
+	return nil
+}
+
+// ReadBackup reads a backup written by WriteBackup. The passphrase is only
+// needed for encrypted backups.
+func ReadBackup(r io.Reader, passphrase string) (*Backup, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("could not read backup: %w", err)
+	}
+
+	if bytes.HasPrefix(data, encryptedBackupMagic) {
+		if passphrase == "" {
+			return nil, ErrBackupEncrypted
+		}
+
+		data, err = decryptBackup(data, passphrase)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var backup Backup
+	if err := json.Unmarshal(data, &backup); err != nil {
+		return nil, fmt.Errorf("could not decode backup: %w", err)
+	}
+
+	return &backup, nil
+}
+
+const (
+	backupSaltSize = 16
+	backupKeySize  = 32
+)
+
+// backupKey derives the encryption key from the passphrase with scrypt
+func backupKey(passphrase string, salt []byte) ([]byte, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, 1<<15, 8, 1, backupKeySize)
+	if err != nil {
+		return nil, fmt.Errorf("could not derive backup key: %w", err)
+	}
+
+	return key, nil
+}
+
+func backupCipher(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := backupKey(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("could not create backup cipher: %w", err)
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// encryptBackup encrypts the data with AES-GCM, the result is the magic
+// header followed by the salt, the nonce and the ciphertext
+func encryptBackup(data []byte, passphrase string) ([]byte, error) {
+	salt := make([]byte, backupSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("could not generate salt: %w", err)
+	}
+
+	aead, err := backupCipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("could not generate nonce: %w", err)
+	}
+
+	out := append(slices.Clone(encryptedBackupMagic), salt...)
+	out = append(out, nonce...)
+
+	return aead.Seal(out, nonce, data, encryptedBackupMagic), nil
+}
+
+func decryptBackup(data []byte, passphrase string) ([]byte, error) {
+	data = data[len(encryptedBackupMagic):]
+	if len(data) < backupSaltSize {
+		return nil, ErrWrongPassphrase
+	}
+	salt, data := data[:backupSaltSize], data[backupSaltSize:]
+
+	aead, err := backupCipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < aead.NonceSize() {
+		return nil, ErrWrongPassphrase
+	}
+	nonce, ciphertext := data[:aead.NonceSize()], data[aead.NonceSize():]
+
+	plaintext, err := aead.Open(nil, nonce, ciphertext, encryptedBackupMagic)
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+
+	return plaintext, nil
+}
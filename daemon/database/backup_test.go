@@ -0,0 +1,117 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/40acres/40swap/daemon/database/models"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/stretchr/testify/require"
+)
+
+func newSQLiteDatabase(t *testing.T, migrate bool) *Database {
+	db, close, err := NewSQLite(filepath.Join(t.TempDir(), "40swapd.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, close())
+	})
+	if migrate {
+		require.NoError(t, db.MigrateDatabase())
+	}
+
+	return db
+}
+
+func TestBackupRestore(t *testing.T) {
+	ctx := context.Background()
+	db := newSQLiteDatabase(t, true)
+
+	preimage := lntypes.Preimage{1, 2, 3}
+	swapIn := &models.SwapIn{
+		SwapID:           "in",
+		AmountSats:       1000,
+		Status:           models.StatusCreated,
+		SourceChain:      models.Bitcoin,
+		RefundPrivatekey: "refund-key",
+		PaymentRequest:   "lnbc",
+	}
+	require.NoError(t, db.SaveSwapIn(ctx, swapIn))
+	swapIn.Status = models.StatusContractFunded
+	require.NoError(t, db.SaveSwapIn(ctx, swapIn))
+	require.NoError(t, db.SaveSwapOut(ctx, &models.SwapOut{
+		SwapID:           "out",
+		Status:           models.StatusCreated,
+		DestinationChain: models.Bitcoin,
+		ClaimPrivateKey:  "claim-key",
+		PaymentRequest:   "lnbc",
+		PreImage:         &preimage,
+	}))
+	require.NoError(t, db.SaveSwapEvent(ctx, models.NewSwapInEvent(swapIn, nil)))
+
+	backup, err := db.Backup(ctx)
+	require.NoError(t, err)
+	require.Equal(t, migrations[len(migrations)-1].ID, backup.SchemaVersion)
+	require.Len(t, backup.SwapIns, 1)
+	require.Len(t, backup.SwapOuts, 1)
+	require.Len(t, backup.SwapEvents, 1)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteBackup(&buf, backup, "secret"))
+	require.NotContains(t, buf.String(), "claim-key")
+
+	_, err = ReadBackup(bytes.NewReader(buf.Bytes()), "")
+	require.ErrorIs(t, err, ErrBackupEncrypted)
+	_, err = ReadBackup(bytes.NewReader(buf.Bytes()), "wrong")
+	require.ErrorIs(t, err, ErrWrongPassphrase)
+	read, err := ReadBackup(bytes.NewReader(buf.Bytes()), "secret")
+	require.NoError(t, err)
+
+	t.Run("restore", func(t *testing.T) {
+		restored := newSQLiteDatabase(t, true)
+		require.NoError(t, restored.Restore(ctx, read))
+
+		got, err := restored.GetSwapIn(ctx, "in")
+		require.NoError(t, err)
+		require.Equal(t, "refund-key", got.RefundPrivatekey)
+		require.Equal(t, models.StatusContractFunded, got.Status)
+		require.WithinDuration(t, backup.SwapIns[0].UpdatedAt, got.UpdatedAt, time.Millisecond)
+
+		out, err := restored.GetSwapOut(ctx, "out")
+		require.NoError(t, err)
+		require.Equal(t, &preimage, out.PreImage)
+
+		events, err := restored.GetSwapEvents(ctx, "in")
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+
+		// New swaps don't collide with the restored ids
+		require.NoError(t, restored.SaveSwapIn(ctx, &models.SwapIn{
+			SwapID:           "new",
+			Status:           models.StatusCreated,
+			SourceChain:      models.Bitcoin,
+			RefundPrivatekey: "key",
+			PaymentRequest:   "lnbc",
+		}))
+
+		require.ErrorIs(t, restored.Restore(ctx, read), ErrDatabaseNotEmpty)
+	})
+
+	t.Run("schema mismatch", func(t *testing.T) {
+		restored := newSQLiteDatabase(t, false)
+		require.ErrorIs(t, restored.Restore(ctx, read), ErrSchemaMismatch)
+
+		read.SchemaVersion = "999_from_the_future"
+		require.ErrorIs(t, restored.Restore(ctx, read), ErrSchemaMismatch)
+	})
+
+	t.Run("redacted", func(t *testing.T) {
+		redacted := backup.Redacted()
+		require.Empty(t, redacted.SwapIns[0].RefundPrivatekey)
+		require.Empty(t, redacted.SwapOuts[0].ClaimPrivateKey)
+		require.Nil(t, redacted.SwapOuts[0].PreImage)
+		require.Equal(t, "claim-key", backup.SwapOuts[0].ClaimPrivateKey)
+	})
+}
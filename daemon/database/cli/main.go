@@ -28,6 +28,16 @@ func main() {
 		Name:  "database",
 		Usage: "Database operations",
 		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "db-type",
+				Usage: "Database engine: postgres or sqlite",
+				Value: database.DialectPostgres,
+			},
+			&cli.StringFlag{
+				Name:  "db-sqlite-path",
+				Usage: "Path to the SQLite database file",
+				Value: "./.data/40swapd.db",
+			},
 			&cli.StringFlag{
 				Name:  "db-host",
 				Usage: "Database host",
@@ -156,6 +166,137 @@ func main() {
 					return nil
 				},
 			},
+			{
+				Name:  "backup",
+				Usage: "Back up the swaps, including their claim and refund keys",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "out",
+						Usage:    "File to write the backup to",
+						Required: true,
+					},
+					&backupPassphrase,
+				},
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					db, closeDb, err := StartDatabase(cmd)
+					if err != nil {
+						return fmt.Errorf("❌ Could not connect to database: %w", err)
+					}
+					defer func() {
+						if err := closeDb(); err != nil {
+							log.Errorf("❌ Could not close database: %v", err)
+						}
+					}()
+
+					backup, err := db.Backup(ctx)
+					if err != nil {
+						return fmt.Errorf("❌ Could not back up database: %w", err)
+					}
+					if cmd.String("passphrase") == "" {
+						log.Warn("⚠️ Writing an unencrypted backup, keep it safe as it holds the swap keys")
+					}
+					if err := database.WriteBackupFile(cmd.String("out"), backup, cmd.String("passphrase")); err != nil {
+						return err
+					}
+					log.Infof("✅ Backed up %d swap ins and %d swap outs", len(backup.SwapIns), len(backup.SwapOuts))
+
+					return nil
+				},
+			},
+			{
+				Name:  "restore",
+				Usage: "Restore a backup into an empty database",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "in",
+						Usage:    "Backup file to restore",
+						Required: true,
+					},
+					&backupPassphrase,
+				},
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					file, err := os.Open(cmd.String("in"))
+					if err != nil {
+						return fmt.Errorf("❌ Could not open backup: %w", err)
+					}
+					defer file.Close()
+
+					backup, err := database.ReadBackup(file, cmd.String("passphrase"))
+					if err != nil {
+						return fmt.Errorf("❌ Could not read backup: %w", err)
+					}
+
+					db, closeDb, err := StartDatabase(cmd)
+					if err != nil {
+						return fmt.Errorf("❌ Could not connect to database: %w", err)
+					}
+					defer func() {
+						if err := closeDb(); err != nil {
+							log.Errorf("❌ Could not close database: %v", err)
+						}
+					}()
+
+					// A new database is brought to the version of the backup,
+					// the daemon migrates it further once it starts
+					version, err := db.SchemaVersion()
+					if err != nil {
+						return err
+					}
+					if version == "" {
+						if err := db.MigrateTo(backup.SchemaVersion); err != nil {
+							return fmt.Errorf("❌ Could not migrate database to the backup version: %w", err)
+						}
+					}
+
+					if err := db.Restore(ctx, backup); err != nil {
+						return fmt.Errorf("❌ Could not restore backup: %w", err)
+					}
+					log.Infof("✅ Restored %d swap ins and %d swap outs", len(backup.SwapIns), len(backup.SwapOuts))
+
+					return nil
+				},
+			},
+			{
+				Name:  "export",
+				Usage: "Export the swaps without their keys",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "format",
+						Usage: "Output format, only json is supported",
+						Value: "json",
+					},
+					&cli.StringFlag{
+						Name:  "out",
+						Usage: "File to write the export to, stdout when empty",
+					},
+				},
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					if cmd.String("format") != "json" {
+						return fmt.Errorf("❌ Unsupported export format %q", cmd.String("format"))
+					}
+
+					db, closeDb, err := StartDatabase(cmd)
+					if err != nil {
+						return fmt.Errorf("❌ Could not connect to database: %w", err)
+					}
+					defer func() {
+						if err := closeDb(); err != nil {
+							log.Errorf("❌ Could not close database: %v", err)
+						}
+					}()
+
+					backup, err := db.Backup(ctx)
+					if err != nil {
+						return fmt.Errorf("❌ Could not export database: %w", err)
+					}
+
+					if cmd.String("out") == "" {
+						return database.WriteBackup(os.Stdout, backup.Redacted(), "")
+					}
+
+					return database.WriteBackupFile(cmd.String("out"), backup.Redacted(), "")
+				},
+			},
 			{
 				Name:  "help",
 				Usage: "Show help",
@@ -177,6 +318,10 @@ func main() {
 }
 
 func StartDatabase(cmd *cli.Command) (*database.Database, func() error, error) {
+	if cmd.String("db-type") == database.DialectSQLite {
+		return database.NewSQLite(cmd.String("db-sqlite-path"))
+	}
+
 	port, err := validatePort(cmd.Int("db-port"))
 	if err != nil {
 		return nil, nil, err
@@ -197,3 +342,9 @@ func StartDatabase(cmd *cli.Command) (*database.Database, func() error, error) {
 
 	return db, closeDb, nil
 }
+
+var backupPassphrase = cli.StringFlag{
+	Name:    "passphrase",
+	Usage:   "Passphrase the backup is encrypted with, unencrypted when empty",
+	Sources: cli.NewValueSourceChain(cli.EnvVar("40SWAPD_BACKUP_PASSPHRASE")),
+}
@@ -3,8 +3,11 @@ package database
 import (
 	"errors"
 	"fmt"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/40acres/40swap/daemon/database/gen"
 	"github.com/40acres/40swap/daemon/database/models"
@@ -13,7 +16,9 @@ import (
 	embeddedpostgres "github.com/fergusstrange/embedded-postgres"
 	_ "github.com/lib/pq"
 	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
+	_ "modernc.org/sqlite"
 )
 
 type errorOnlyWriter struct {
@@ -100,6 +105,35 @@ func New(username, password, database string, port uint32, dataPath, host string
 	return &db, close, nil
 }
 
+// NewSQLite opens, creating it if needed, the SQLite database at path. It's a
+// lighter alternative to Postgres for small nodes.
+func NewSQLite(path string) (*Database, func() error, error) {
+	models.RegisterPreimageSerializer()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, nil, fmt.Errorf("could not create database directory: %w", err)
+	}
+
+	// SQLite allows a single writer, wait for it instead of failing. Times are
+	// stored as text, in UTC so that they compare and sort as times.
+	dsn := path + "?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_pragma=foreign_keys(1)&_time_format=sqlite"
+	orm, err := gorm.Open(sqlite.New(sqlite.Config{DriverName: "sqlite", DSN: dsn}), &gorm.Config{
+		NowFunc: func() time.Time { return time.Now().UTC() },
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not open SQLite database: %w", err)
+	}
+	log.Info("✅ DB connected")
+
+	db := Database{
+		dataPath: path,
+		orm:      orm,
+		query:    gen.Use(orm),
+	}
+
+	return &db, db.close, nil
+}
+
 func (d *Database) getHost() string {
 	host := "localhost"
 	if d.host != "embedded" {
@@ -130,6 +164,11 @@ func (d *Database) ORM() *gorm.DB {
 	return d.orm
 }
 
+// Dialect is the name of the database engine, DialectPostgres or DialectSQLite
+func (d *Database) Dialect() string {
+	return d.orm.Dialector.Name()
+}
+
 func (d *Database) MigrateDatabase() error {
 	err := NewMigrator(d.orm).Migrate()
 	if err != nil {
@@ -144,6 +183,11 @@ func (d *Database) MigrateTo(to string) error {
 	return NewMigrator(d.orm).MigrateTo(to)
 }
 
+// SchemaVersion is the ID of the last migration applied to the database
+func (d *Database) SchemaVersion() (string, error) {
+	return NewMigrator(d.orm).Version()
+}
+
 func (d *Database) Rollback() error {
 	return NewMigrator(d.orm).Rollback()
 }
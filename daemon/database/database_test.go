@@ -1,10 +1,17 @@
 package database
 
 import (
+	"context"
+	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/40acres/40swap/daemon/database/models"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
 )
 
 func TestGetConnection(t *testing.T) {
@@ -61,3 +68,336 @@ func TestDatabaseOperations(t *testing.T) {
 		require.NotNil(t, orm)
 	})
 }
+
+func TestSQLite(t *testing.T) {
+	ctx := context.Background()
+	db, close, err := NewSQLite(filepath.Join(t.TempDir(), "data", "40swapd.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, close())
+	})
+	require.Equal(t, DialectSQLite, db.Dialect())
+	require.NoError(t, db.MigrateDatabase())
+
+	// Every migration rolls back on SQLite too
+	require.NoError(t, db.Reset())
+	require.False(t, db.ORM().Migrator().HasTable("swap_ins"))
+	require.NoError(t, db.MigrateDatabase())
+
+	outcome := models.OutcomeSuccess
+	swapIn := &models.SwapIn{
+		SwapID:           "in",
+		AmountSats:       1000,
+		Status:           models.StatusCreated,
+		SourceChain:      models.Bitcoin,
+		RefundPrivatekey: "key",
+		PaymentRequest:   "lnbc",
+	}
+	require.NoError(t, db.SaveSwapIn(ctx, swapIn))
+	swapIn.Status = models.StatusDone
+	swapIn.Outcome = &outcome
+	rate := decimal.RequireFromString("65432.1")
+	swapIn.FiatCurrency = "USD"
+	swapIn.FiatRate = &rate
+	require.NoError(t, db.SaveSwapIn(ctx, swapIn))
+
+	got, err := db.GetSwapIn(ctx, "in")
+	require.NoError(t, err)
+	require.Equal(t, models.StatusDone, got.Status)
+	require.Equal(t, &outcome, got.Outcome)
+	require.Equal(t, "USD", got.FiatCurrency)
+	require.True(t, rate.Equal(*got.FiatRate))
+	require.WithinDuration(t, time.Now(), got.CreatedAt, time.Minute)
+
+	pending, err := db.GetPendingSwapIns(ctx)
+	require.NoError(t, err)
+	require.Empty(t, pending)
+
+	finished, err := db.GetFinishedSwapIns(ctx, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, finished, 1)
+	finished, err = db.GetFinishedSwapIns(ctx, time.Now().Add(time.Hour), time.Now().Add(2*time.Hour))
+	require.NoError(t, err)
+	require.Empty(t, finished)
+
+	require.NoError(t, db.SaveSwapOut(ctx, &models.SwapOut{
+		SwapID:           "out",
+		Status:           models.StatusCreated,
+		DestinationChain: models.Bitcoin,
+		PaymentRequest:   "lnbc",
+		IsAutoSwap:       true,
+	}))
+	autoSwaps, err := db.GetPendingAutoSwapOuts(ctx)
+	require.NoError(t, err)
+	require.Len(t, autoSwaps, 1)
+
+	// Enum columns only accept the enum values
+	err = db.SaveSwapOut(ctx, &models.SwapOut{SwapID: "bad", Status: "UNKNOWN", DestinationChain: models.Bitcoin})
+	require.ErrorContains(t, err, "CHECK constraint failed")
+
+	// Client request IDs are unique, swaps without one aren't affected
+	clientRequestID := "request-1"
+	require.NoError(t, db.SaveSwapOut(ctx, &models.SwapOut{
+		SwapID:           "idempotent",
+		Status:           models.StatusCreated,
+		DestinationChain: models.Bitcoin,
+		ClientRequestID:  &clientRequestID,
+		Label:            "rebalance",
+	}))
+	byRequest, err := db.GetSwapOutByClientRequestID(ctx, clientRequestID)
+	require.NoError(t, err)
+	require.Equal(t, "idempotent", byRequest.SwapID)
+	require.Equal(t, "rebalance", byRequest.Label)
+	err = db.SaveSwapOut(ctx, &models.SwapOut{
+		SwapID:           "duplicate",
+		Status:           models.StatusCreated,
+		DestinationChain: models.Bitcoin,
+		ClientRequestID:  &clientRequestID,
+	})
+	require.ErrorContains(t, err, "UNIQUE constraint failed")
+	_, err = db.GetSwapInByClientRequestID(ctx, clientRequestID)
+	require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	// Swaps can be cancelled
+	cancelled := models.OutcomeCancelled
+	require.NoError(t, db.SaveSwapOut(ctx, &models.SwapOut{
+		SwapID:           "cancelled",
+		Status:           models.StatusDone,
+		Outcome:          &cancelled,
+		DestinationChain: models.Bitcoin,
+	}))
+	require.NoError(t, db.SaveSwapEvent(ctx, &models.SwapEvent{
+		SwapID:    "cancelled",
+		Direction: models.SwapDirectionOut,
+		ToStatus:  models.StatusDone,
+		Outcome:   &cancelled,
+	}))
+
+	// Conditional saves only apply to a swap that hasn't changed since it was read
+	require.NoError(t, db.SaveSwapOut(ctx, &models.SwapOut{
+		SwapID:           "conditional",
+		Status:           models.StatusCreated,
+		DestinationChain: models.Bitcoin,
+		PaymentStatus:    models.PaymentPending,
+	}))
+	paying, err := db.GetSwapOut(ctx, "conditional")
+	require.NoError(t, err)
+	cancelling, err := db.GetSwapOut(ctx, "conditional")
+	require.NoError(t, err)
+	paying.PaymentStatus = models.PaymentInFlight
+	require.NoError(t, db.SaveSwapOutIf(ctx, paying, models.StatusCreated, models.PaymentPending))
+	cancelling.Status = models.StatusDone
+	cancelling.Outcome = &cancelled
+	err = db.SaveSwapOutIf(ctx, cancelling, models.StatusCreated, models.PaymentPending)
+	require.ErrorIs(t, err, ErrSwapChanged)
+	stored, err := db.GetSwapOut(ctx, "conditional")
+	require.NoError(t, err)
+	require.Equal(t, models.StatusCreated, stored.Status)
+	require.Equal(t, models.PaymentInFlight, stored.PaymentStatus)
+	require.Nil(t, stored.Outcome)
+
+	require.NoError(t, db.SaveSwapIn(ctx, &models.SwapIn{
+		SwapID:           "conditional",
+		Status:           models.StatusCreated,
+		SourceChain:      models.Bitcoin,
+		RefundPrivatekey: "key",
+		PaymentRequest:   "lnbc",
+	}))
+	polled, err := db.GetSwapIn(ctx, "conditional")
+	require.NoError(t, err)
+	cancellingIn, err := db.GetSwapIn(ctx, "conditional")
+	require.NoError(t, err)
+	cancellingIn.Status = models.StatusDone
+	cancellingIn.Outcome = &cancelled
+	require.NoError(t, db.SaveSwapInIf(ctx, cancellingIn, models.StatusCreated))
+	polled.Status = models.StatusContractFundedUnconfirmed
+	err = db.SaveSwapInIf(ctx, polled, models.StatusCreated)
+	require.ErrorIs(t, err, ErrSwapChanged)
+	storedIn, err := db.GetSwapIn(ctx, "conditional")
+	require.NoError(t, err)
+	require.Equal(t, models.StatusDone, storedIn.Status)
+	require.Equal(t, &cancelled, storedIn.Outcome)
+
+	// Cancelled swap ins are watched until their contract expires
+	require.NoError(t, db.SaveSwapIn(ctx, &models.SwapIn{
+		SwapID:             "cancelled",
+		Status:             models.StatusDone,
+		Outcome:            &cancelled,
+		SourceChain:        models.Bitcoin,
+		ClaimAddress:       "bcrt1qcontract",
+		TimeoutBlockHeight: 150,
+		RefundPrivatekey:   "key",
+		PaymentRequest:     "lnbc",
+	}))
+	watched, err := db.GetCancelledSwapIns(ctx, 150)
+	require.NoError(t, err)
+	require.Len(t, watched, 1)
+	require.Equal(t, "cancelled", watched[0].SwapID)
+	watched, err = db.GetCancelledSwapIns(ctx, 151)
+	require.NoError(t, err)
+	require.Empty(t, watched)
+
+	now := time.Now()
+	require.NoError(t, db.SaveWebhookNotifications(ctx, []*models.WebhookNotification{
+		{URL: "http://due", EventType: "swap", Payload: "{}", Status: models.NotificationPending, NextAttemptAt: now.Add(-time.Minute)},
+		{URL: "http://later", EventType: "swap", Payload: "{}", Status: models.NotificationPending, NextAttemptAt: now.Add(time.Hour)},
+	}))
+	due, err := db.GetDueWebhookNotifications(ctx, now, 10)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	require.Equal(t, "http://due", due[0].URL)
+}
+
+func TestSQLite_AddPaymentStatusToSwapOut(t *testing.T) {
+	db, close, err := NewSQLite(filepath.Join(t.TempDir(), "40swapd.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, close())
+	})
+	require.NoError(t, db.MigrateTo("16_add_client_request_id_and_label_to_swaps"))
+
+	tests := []struct {
+		status  models.SwapStatus
+		outcome any
+		want    models.PaymentStatus
+	}{
+		{status: models.StatusCreated, want: models.PaymentUnknown},
+		{status: models.StatusInvoicePaymentIntentReceived, want: models.PaymentInFlight},
+		{status: models.StatusContractFundedUnconfirmed, want: models.PaymentInFlight},
+		{status: models.StatusContractFunded, want: models.PaymentInFlight},
+		{status: models.StatusContractClaimedUnconfirmed, want: models.PaymentSucceeded},
+		{status: models.StatusContractRefundedUnconfirmed, want: models.PaymentFailed},
+		{status: models.StatusContractExpired, want: models.PaymentFailed},
+		{status: models.StatusDone, outcome: models.OutcomeSuccess, want: models.PaymentSucceeded},
+		{status: models.StatusDone, outcome: models.OutcomeFailed, want: models.PaymentFailed},
+		{status: models.StatusDone, outcome: models.OutcomeExpired, want: models.PaymentFailed},
+		{status: models.StatusDone, outcome: models.OutcomeRefunded, want: models.PaymentFailed},
+		{status: models.StatusDone, outcome: nil, want: models.PaymentFailed},
+	}
+	for i, tt := range tests {
+		err := db.ORM().Table("swap_outs").Create(map[string]any{
+			"swap_id":               fmt.Sprintf("swap-%d", i),
+			"status":                tt.status,
+			"outcome":               tt.outcome,
+			"amount_sats":           1000,
+			"destination_address":   "bc1q",
+			"service_fee_sats":      0,
+			"destination_chain":     models.Bitcoin,
+			"claim_private_key":     "key",
+			"payment_request":       "lnbc",
+			"max_routing_fee_ratio": 0.005,
+		}).Error
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, db.MigrateTo("17_add_payment_status_to_swap_out"))
+
+	for i, tt := range tests {
+		var got models.PaymentStatus
+		err := db.ORM().Table("swap_outs").Select("payment_status").
+			Where("swap_id = ?", fmt.Sprintf("swap-%d", i)).Scan(&got).Error
+		require.NoError(t, err)
+		require.Equal(t, tt.want, got, "status %s, outcome %v", tt.status, tt.outcome)
+	}
+}
+
+func TestSQLite_AddCancelledOutcome(t *testing.T) {
+	ctx := context.Background()
+	db, close, err := NewSQLite(filepath.Join(t.TempDir(), "40swapd.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, close())
+	})
+	// Databases created before have the outcome columns without the value
+	require.NoError(t, db.MigrateTo("17_add_payment_status_to_swap_out"))
+	orm := db.ORM()
+
+	cancelled := models.OutcomeCancelled
+	swapInModel := &models.SwapIn{
+		SwapID:           "in",
+		Status:           models.StatusDone,
+		Outcome:          &cancelled,
+		SourceChain:      models.Bitcoin,
+		RefundPrivatekey: "key",
+		PaymentRequest:   "lnbc",
+	}
+	// Columns added by later migrations don't exist yet
+	require.ErrorContains(t, orm.Omit("InvoiceGenerated").Create(swapInModel).Error, "CHECK constraint failed")
+
+	require.NoError(t, db.MigrateTo("18_add_cancelled_outcome"))
+	require.NoError(t, orm.Omit("InvoiceGenerated").Create(swapInModel).Error)
+	require.NoError(t, db.SaveSwapOut(ctx, &models.SwapOut{
+		SwapID:           "out",
+		Status:           models.StatusDone,
+		Outcome:          &cancelled,
+		DestinationChain: models.Bitcoin,
+	}))
+	require.NoError(t, db.SaveSwapEvent(ctx, &models.SwapEvent{
+		SwapID:    "in",
+		Direction: models.SwapDirectionIn,
+		ToStatus:  models.StatusDone,
+		Outcome:   &cancelled,
+	}))
+
+	// The tables keep their indexes and constraints
+	require.True(t, orm.Migrator().HasIndex("swap_ins", "idx_swap_ins_client_request_id"))
+	require.True(t, orm.Migrator().HasIndex("swap_outs", "idx_swap_outs_client_request_id"))
+	require.True(t, orm.Migrator().HasIndex("swap_events", "idx_swap_events_swap_id"))
+	unknown := models.SwapOutcome("UNKNOWN")
+	err = db.SaveSwapOut(ctx, &models.SwapOut{SwapID: "bad", Status: models.StatusDone, Outcome: &unknown, DestinationChain: models.Bitcoin})
+	require.ErrorContains(t, err, "CHECK constraint failed")
+
+	// Rolling back fails the cancelled swaps and restores the constraint
+	require.NoError(t, db.Rollback())
+	got, err := db.GetSwapOut(ctx, "out")
+	require.NoError(t, err)
+	require.Equal(t, models.OutcomeFailed, *got.Outcome)
+	err = db.SaveSwapOut(ctx, &models.SwapOut{SwapID: "cancelled", Status: models.StatusDone, Outcome: &cancelled, DestinationChain: models.Bitcoin})
+	require.ErrorContains(t, err, "CHECK constraint failed")
+	require.True(t, orm.Migrator().HasIndex("swap_outs", "idx_swap_outs_client_request_id"))
+}
+
+func TestSQLite_AddInvoiceGeneratedToSwapIn(t *testing.T) {
+	ctx := context.Background()
+	db, close, err := NewSQLite(filepath.Join(t.TempDir(), "40swapd.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, close())
+	})
+	require.NoError(t, db.MigrateTo("18_add_cancelled_outcome"))
+
+	err = db.ORM().Table("swap_ins").Create(map[string]any{
+		"swap_id":           "before",
+		"status":            models.StatusCreated,
+		"amount_sats":       1000,
+		"source_chain":      models.Bitcoin,
+		"refund_privatekey": "key",
+		"payment_request":   "lnbc",
+		"onchain_fee_sats":  0,
+		"service_fee_sats":  0,
+	}).Error
+	require.NoError(t, err)
+
+	require.NoError(t, db.MigrateTo("19_add_invoice_generated_to_swap_in"))
+
+	// Nobody knows who created the invoices of the swaps created before
+	before, err := db.GetSwapIn(ctx, "before")
+	require.NoError(t, err)
+	require.False(t, before.InvoiceGenerated)
+
+	require.NoError(t, db.SaveSwapIn(ctx, &models.SwapIn{
+		SwapID:           "after",
+		Status:           models.StatusCreated,
+		SourceChain:      models.Bitcoin,
+		RefundPrivatekey: "key",
+		PaymentRequest:   "lnbc",
+		InvoiceGenerated: true,
+	}))
+	after, err := db.GetSwapIn(ctx, "after")
+	require.NoError(t, err)
+	require.True(t, after.InvoiceGenerated)
+
+	require.NoError(t, db.Rollback())
+	require.False(t, db.ORM().Migrator().HasColumn("swap_ins", "invoice_generated"))
+	require.True(t, db.ORM().Migrator().HasIndex("swap_ins", "idx_swap_ins_client_request_id"))
+}
@@ -0,0 +1,112 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+const (
+	DialectPostgres = "postgres"
+	DialectSQLite   = "sqlite"
+)
+
+// The following are the column types of the migrations whose definition
+// depends on the dialect. Postgres has native enum types, while SQLite stores
+// enums as text restricted to the enum values by a check constraint.
+type (
+	chainEnum         string
+	swapStatusEnum    string
+	swapOutcomeEnum   string
+	swapDirectionEnum string
+
+	// cancellableSwapOutcomeEnum is the outcome since migration 18 added the
+	// cancelled outcome
+	cancellableSwapOutcomeEnum string
+	// timestamptz is a timestamp with time zone, SQLite only reads columns
+	// declared as datetime back as times
+	timestamptz time.Time
+)
+
+func (chainEnum) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return enumDataType(db, field, "chain_enum", "bitcoin", "liquid")
+}
+
+func (swapStatusEnum) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return enumDataType(db, field, "swap_status",
+		"CREATED",
+		"INVOICE_PAYMENT_INTENT_RECEIVED",
+		"CONTRACT_FUNDED_UNCONFIRMED",
+		"CONTRACT_FUNDED",
+		"INVOICE_PAID",
+		"CONTRACT_CLAIMED_UNCONFIRMED",
+		"DONE",
+		"CONTRACT_REFUNDED_UNCONFIRMED",
+		"CONTRACT_EXPIRED",
+	)
+}
+
+func (swapOutcomeEnum) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return enumDataType(db, field, "swap_outcome", "FAILED", "SUCCESS", "REFUNDED", "EXPIRED")
+}
+
+func (cancellableSwapOutcomeEnum) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return enumDataType(db, field, "swap_outcome", "FAILED", "SUCCESS", "REFUNDED", "EXPIRED", "CANCELLED")
+}
+
+func (swapDirectionEnum) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return enumDataType(db, field, "swap_direction", "IN", "OUT")
+}
+
+func (timestamptz) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	if db.Dialector.Name() == DialectSQLite {
+		return "datetime"
+	}
+
+	return "timestamp with time zone"
+}
+
+func enumDataType(db *gorm.DB, field *schema.Field, name string, values ...string) string {
+	if db.Dialector.Name() != DialectSQLite {
+		return name
+	}
+
+	return fmt.Sprintf("text CHECK (%s IN ('%s'))", field.DBName, strings.Join(values, "', '"))
+}
+
+// execPostgres runs statements that only make sense on Postgres, like
+// creating enum types, and skips them on other dialects.
+func execPostgres(tx *gorm.DB, sql string) error {
+	if tx.Dialector.Name() != DialectPostgres {
+		return nil
+	}
+
+	return tx.Exec(sql).Error
+}
+
+// alterSQLiteColumn changes a column of a table to its type in the model.
+// SQLite can't alter columns, so the migrator recreates the table, which drops
+// its indexes, and they are created again.
+func alterSQLiteColumn(tx *gorm.DB, model any, table, column string) error {
+	var indexes []string
+	err := tx.Raw("SELECT sql FROM sqlite_master WHERE type = 'index' AND tbl_name = ? AND sql IS NOT NULL", table).
+		Scan(&indexes).Error
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Table(table).Migrator().AlterColumn(model, column); err != nil {
+		return err
+	}
+
+	for _, index := range indexes {
+		if err := tx.Exec(index).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -16,39 +16,49 @@ import (
 )
 
 var (
-	Q       = new(Query)
-	SwapIn  *swapIn
-	SwapOut *swapOut
+	Q                   = new(Query)
+	SwapEvent           *swapEvent
+	SwapIn              *swapIn
+	SwapOut             *swapOut
+	WebhookNotification *webhookNotification
 )
 
 func SetDefault(db *gorm.DB, opts ...gen.DOOption) {
 	*Q = *Use(db, opts...)
+	SwapEvent = &Q.SwapEvent
 	SwapIn = &Q.SwapIn
 	SwapOut = &Q.SwapOut
+	WebhookNotification = &Q.WebhookNotification
 }
 
 func Use(db *gorm.DB, opts ...gen.DOOption) *Query {
 	return &Query{
-		db:      db,
-		SwapIn:  newSwapIn(db, opts...),
-		SwapOut: newSwapOut(db, opts...),
+		db:                  db,
+		SwapEvent:           newSwapEvent(db, opts...),
+		SwapIn:              newSwapIn(db, opts...),
+		SwapOut:             newSwapOut(db, opts...),
+		WebhookNotification: newWebhookNotification(db, opts...),
 	}
 }
 
 type Query struct {
 	db *gorm.DB
 
-	SwapIn  swapIn
-	SwapOut swapOut
+	SwapEvent           swapEvent
+	SwapIn              swapIn
+	SwapOut             swapOut
+	WebhookNotification webhookNotification
 }
 
 func (q *Query) Available() bool { return q.db != nil }
 
 func (q *Query) clone(db *gorm.DB) *Query {
 	return &Query{
-		db:      db,
-		SwapIn:  q.SwapIn.clone(db),
-		SwapOut: q.SwapOut.clone(db),
+		db:                  db,
+		SwapEvent:           q.SwapEvent.clone(db),
+		SwapIn:              q.SwapIn.clone(db),
+		SwapOut:             q.SwapOut.clone(db),
+		WebhookNotification: q.WebhookNotification.clone(db),
 	}
 }
 
@@ -62,21 +72,27 @@ func (q *Query) WriteDB() *Query {
 
 func (q *Query) ReplaceDB(db *gorm.DB) *Query {
 	return &Query{
-		db:      db,
-		SwapIn:  q.SwapIn.replaceDB(db),
-		SwapOut: q.SwapOut.replaceDB(db),
+		db:                  db,
+		SwapEvent:           q.SwapEvent.replaceDB(db),
+		SwapIn:              q.SwapIn.replaceDB(db),
+		SwapOut:             q.SwapOut.replaceDB(db),
+		WebhookNotification: q.WebhookNotification.replaceDB(db),
 	}
 }
 
 type queryCtx struct {
-	SwapIn  ISwapInDo
-	SwapOut ISwapOutDo
+	SwapEvent           ISwapEventDo
+	SwapIn              ISwapInDo
+	SwapOut             ISwapOutDo
+	WebhookNotification IWebhookNotificationDo
 }
 
 func (q *Query) WithContext(ctx context.Context) *queryCtx {
 	return &queryCtx{
-		SwapIn:  q.SwapIn.WithContext(ctx),
-		SwapOut: q.SwapOut.WithContext(ctx),
+		SwapEvent:           q.SwapEvent.WithContext(ctx),
+		SwapIn:              q.SwapIn.WithContext(ctx),
+		SwapOut:             q.SwapOut.WithContext(ctx),
+		WebhookNotification: q.WebhookNotification.WithContext(ctx),
 	}
 }
 
@@ -0,0 +1,446 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package gen
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+
+	"gorm.io/plugin/dbresolver"
+
+	"github.com/40acres/40swap/daemon/database/models"
+)
+
+func newSwapEvent(db *gorm.DB, opts ...gen.DOOption) swapEvent {
+	_swapEvent := swapEvent{}
+
+	_swapEvent.swapEventDo.UseDB(db, opts...)
+	_swapEvent.swapEventDo.UseModel(&models.SwapEvent{})
+
+	tableName := _swapEvent.swapEventDo.TableName()
+	_swapEvent.ALL = field.NewAsterisk(tableName)
+	_swapEvent.ID = field.NewInt64(tableName, "id")
+	_swapEvent.SwapID = field.NewString(tableName, "swap_id")
+	_swapEvent.Direction = field.NewField(tableName, "direction")
+	_swapEvent.FromStatus = field.NewField(tableName, "from_status")
+	_swapEvent.ToStatus = field.NewField(tableName, "to_status")
+	_swapEvent.Outcome = field.NewField(tableName, "outcome")
+	_swapEvent.LockTxID = field.NewString(tableName, "lock_tx_id")
+	_swapEvent.ClaimTxID = field.NewString(tableName, "claim_tx_id")
+	_swapEvent.RefundTxID = field.NewString(tableName, "refund_tx_id")
+	_swapEvent.ServiceFeeSats = field.NewInt64(tableName, "service_fee_sats")
+	_swapEvent.OnchainFeeSats = field.NewInt64(tableName, "onchain_fee_sats")
+	_swapEvent.OffchainFeeSats = field.NewInt64(tableName, "offchain_fee_sats")
+	_swapEvent.Message = field.NewString(tableName, "message")
+	_swapEvent.Error = field.NewString(tableName, "error")
+	_swapEvent.CreatedAt = field.NewTime(tableName, "created_at")
+
+	_swapEvent.fillFieldMap()
+
+	return _swapEvent
+}
+
+type swapEvent struct {
+	swapEventDo swapEventDo
+
+	ALL             field.Asterisk
+	ID              field.Int64
+	SwapID          field.String
+	Direction       field.Field
+	FromStatus      field.Field
+	ToStatus        field.Field
+	Outcome         field.Field
+	LockTxID        field.String
+	ClaimTxID       field.String
+	RefundTxID      field.String
+	ServiceFeeSats  field.Int64
+	OnchainFeeSats  field.Int64
+	OffchainFeeSats field.Int64
+	Message         field.String
+	Error           field.String
+	CreatedAt       field.Time
+
+	fieldMap map[string]field.Expr
+}
+
+func (s swapEvent) Table(newTableName string) *swapEvent {
+	s.swapEventDo.UseTable(newTableName)
+	return s.updateTableName(newTableName)
+}
+
+func (s swapEvent) As(alias string) *swapEvent {
+	s.swapEventDo.DO = *(s.swapEventDo.As(alias).(*gen.DO))
+	return s.updateTableName(alias)
+}
+
+func (s *swapEvent) updateTableName(table string) *swapEvent {
+	s.ALL = field.NewAsterisk(table)
+	s.ID = field.NewInt64(table, "id")
+	s.SwapID = field.NewString(table, "swap_id")
+	s.Direction = field.NewField(table, "direction")
+	s.FromStatus = field.NewField(table, "from_status")
+	s.ToStatus = field.NewField(table, "to_status")
+	s.Outcome = field.NewField(table, "outcome")
+	s.LockTxID = field.NewString(table, "lock_tx_id")
+	s.ClaimTxID = field.NewString(table, "claim_tx_id")
+	s.RefundTxID = field.NewString(table, "refund_tx_id")
+	s.ServiceFeeSats = field.NewInt64(table, "service_fee_sats")
+	s.OnchainFeeSats = field.NewInt64(table, "onchain_fee_sats")
+	s.OffchainFeeSats = field.NewInt64(table, "offchain_fee_sats")
+	s.Message = field.NewString(table, "message")
+	s.Error = field.NewString(table, "error")
+	s.CreatedAt = field.NewTime(table, "created_at")
+
+	s.fillFieldMap()
+
+	return s
+}
+
+func (s *swapEvent) WithContext(ctx context.Context) ISwapEventDo {
+	return s.swapEventDo.WithContext(ctx)
+}
+
+func (s swapEvent) TableName() string { return s.swapEventDo.TableName() }
+
+func (s swapEvent) Alias() string { return s.swapEventDo.Alias() }
+
+func (s swapEvent) Columns(cols ...field.Expr) gen.Columns { return s.swapEventDo.Columns(cols...) }
+
+func (s *swapEvent) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
+	_f, ok := s.fieldMap[fieldName]
+	if !ok || _f == nil {
+		return nil, false
+	}
+	_oe, ok := _f.(field.OrderExpr)
+	return _oe, ok
+}
+
+func (s *swapEvent) fillFieldMap() {
+	s.fieldMap = make(map[string]field.Expr, 15)
+	s.fieldMap["id"] = s.ID
+	s.fieldMap["swap_id"] = s.SwapID
+	s.fieldMap["direction"] = s.Direction
+	s.fieldMap["from_status"] = s.FromStatus
+	s.fieldMap["to_status"] = s.ToStatus
+	s.fieldMap["outcome"] = s.Outcome
+	s.fieldMap["lock_tx_id"] = s.LockTxID
+	s.fieldMap["claim_tx_id"] = s.ClaimTxID
+	s.fieldMap["refund_tx_id"] = s.RefundTxID
+	s.fieldMap["service_fee_sats"] = s.ServiceFeeSats
+	s.fieldMap["onchain_fee_sats"] = s.OnchainFeeSats
+	s.fieldMap["offchain_fee_sats"] = s.OffchainFeeSats
+	s.fieldMap["message"] = s.Message
+	s.fieldMap["error"] = s.Error
+	s.fieldMap["created_at"] = s.CreatedAt
+}
+
+func (s swapEvent) clone(db *gorm.DB) swapEvent {
+	s.swapEventDo.ReplaceConnPool(db.Statement.ConnPool)
+	return s
+}
+
+func (s swapEvent) replaceDB(db *gorm.DB) swapEvent {
+	s.swapEventDo.ReplaceDB(db)
+	return s
+}
+
+type swapEventDo struct{ gen.DO }
+
+type ISwapEventDo interface {
+	gen.SubQuery
+	Debug() ISwapEventDo
+	WithContext(ctx context.Context) ISwapEventDo
+	WithResult(fc func(tx gen.Dao)) gen.ResultInfo
+	ReplaceDB(db *gorm.DB)
+	ReadDB() ISwapEventDo
+	WriteDB() ISwapEventDo
+	As(alias string) gen.Dao
+	Session(config *gorm.Session) ISwapEventDo
+	Columns(cols ...field.Expr) gen.Columns
+	Clauses(conds ...clause.Expression) ISwapEventDo
+	Not(conds ...gen.Condition) ISwapEventDo
+	Or(conds ...gen.Condition) ISwapEventDo
+	Select(conds ...field.Expr) ISwapEventDo
+	Where(conds ...gen.Condition) ISwapEventDo
+	Order(conds ...field.Expr) ISwapEventDo
+	Distinct(cols ...field.Expr) ISwapEventDo
+	Omit(cols ...field.Expr) ISwapEventDo
+	Join(table schema.Tabler, on ...field.Expr) ISwapEventDo
+	LeftJoin(table schema.Tabler, on ...field.Expr) ISwapEventDo
+	RightJoin(table schema.Tabler, on ...field.Expr) ISwapEventDo
+	Group(cols ...field.Expr) ISwapEventDo
+	Having(conds ...gen.Condition) ISwapEventDo
+	Limit(limit int) ISwapEventDo
+	Offset(offset int) ISwapEventDo
+	Count() (count int64, err error)
+	Scopes(funcs ...func(gen.Dao) gen.Dao) ISwapEventDo
+	Unscoped() ISwapEventDo
+	Create(values ...*models.SwapEvent) error
+	CreateInBatches(values []*models.SwapEvent, batchSize int) error
+	Save(values ...*models.SwapEvent) error
+	First() (*models.SwapEvent, error)
+	Take() (*models.SwapEvent, error)
+	Last() (*models.SwapEvent, error)
+	Find() ([]*models.SwapEvent, error)
+	FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*models.SwapEvent, err error)
+	FindInBatches(result *[]*models.SwapEvent, batchSize int, fc func(tx gen.Dao, batch int) error) error
+	Pluck(column field.Expr, dest interface{}) error
+	Delete(...*models.SwapEvent) (info gen.ResultInfo, err error)
+	Update(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	Updates(value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumn(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumnSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	UpdateColumns(value interface{}) (info gen.ResultInfo, err error)
+	UpdateFrom(q gen.SubQuery) gen.Dao
+	Attrs(attrs ...field.AssignExpr) ISwapEventDo
+	Assign(attrs ...field.AssignExpr) ISwapEventDo
+	Joins(fields ...field.RelationField) ISwapEventDo
+	Preload(fields ...field.RelationField) ISwapEventDo
+	FirstOrInit() (*models.SwapEvent, error)
+	FirstOrCreate() (*models.SwapEvent, error)
+	FindByPage(offset int, limit int) (result []*models.SwapEvent, count int64, err error)
+	ScanByPage(result interface{}, offset int, limit int) (count int64, err error)
+	Scan(result interface{}) (err error)
+	Returning(value interface{}, columns ...string) ISwapEventDo
+	UnderlyingDB() *gorm.DB
+	schema.Tabler
+}
+
+func (s swapEventDo) Debug() ISwapEventDo {
+	return s.withDO(s.DO.Debug())
+}
+
+func (s swapEventDo) WithContext(ctx context.Context) ISwapEventDo {
+	return s.withDO(s.DO.WithContext(ctx))
+}
+
+func (s swapEventDo) ReadDB() ISwapEventDo {
+	return s.Clauses(dbresolver.Read)
+}
+
+func (s swapEventDo) WriteDB() ISwapEventDo {
+	return s.Clauses(dbresolver.Write)
+}
+
+func (s swapEventDo) Session(config *gorm.Session) ISwapEventDo {
+	return s.withDO(s.DO.Session(config))
+}
+
+func (s swapEventDo) Clauses(conds ...clause.Expression) ISwapEventDo {
+	return s.withDO(s.DO.Clauses(conds...))
+}
+
+func (s swapEventDo) Returning(value interface{}, columns ...string) ISwapEventDo {
+	return s.withDO(s.DO.Returning(value, columns...))
+}
+
+func (s swapEventDo) Not(conds ...gen.Condition) ISwapEventDo {
+	return s.withDO(s.DO.Not(conds...))
+}
+
+func (s swapEventDo) Or(conds ...gen.Condition) ISwapEventDo {
+	return s.withDO(s.DO.Or(conds...))
+}
+
+func (s swapEventDo) Select(conds ...field.Expr) ISwapEventDo {
+	return s.withDO(s.DO.Select(conds...))
+}
+
+func (s swapEventDo) Where(conds ...gen.Condition) ISwapEventDo {
+	return s.withDO(s.DO.Where(conds...))
+}
+
+func (s swapEventDo) Order(conds ...field.Expr) ISwapEventDo {
+	return s.withDO(s.DO.Order(conds...))
+}
+
+func (s swapEventDo) Distinct(cols ...field.Expr) ISwapEventDo {
+	return s.withDO(s.DO.Distinct(cols...))
+}
+
+func (s swapEventDo) Omit(cols ...field.Expr) ISwapEventDo {
+	return s.withDO(s.DO.Omit(cols...))
+}
+
+func (s swapEventDo) Join(table schema.Tabler, on ...field.Expr) ISwapEventDo {
+	return s.withDO(s.DO.Join(table, on...))
+}
+
+func (s swapEventDo) LeftJoin(table schema.Tabler, on ...field.Expr) ISwapEventDo {
+	return s.withDO(s.DO.LeftJoin(table, on...))
+}
+
+func (s swapEventDo) RightJoin(table schema.Tabler, on ...field.Expr) ISwapEventDo {
+	return s.withDO(s.DO.RightJoin(table, on...))
+}
+
+func (s swapEventDo) Group(cols ...field.Expr) ISwapEventDo {
+	return s.withDO(s.DO.Group(cols...))
+}
+
+func (s swapEventDo) Having(conds ...gen.Condition) ISwapEventDo {
+	return s.withDO(s.DO.Having(conds...))
+}
+
+func (s swapEventDo) Limit(limit int) ISwapEventDo {
+	return s.withDO(s.DO.Limit(limit))
+}
+
+func (s swapEventDo) Offset(offset int) ISwapEventDo {
+	return s.withDO(s.DO.Offset(offset))
+}
+
+func (s swapEventDo) Scopes(funcs ...func(gen.Dao) gen.Dao) ISwapEventDo {
+	return s.withDO(s.DO.Scopes(funcs...))
+}
+
+func (s swapEventDo) Unscoped() ISwapEventDo {
+	return s.withDO(s.DO.Unscoped())
+}
+
+func (s swapEventDo) Create(values ...*models.SwapEvent) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return s.DO.Create(values)
+}
+
+func (s swapEventDo) CreateInBatches(values []*models.SwapEvent, batchSize int) error {
+	return s.DO.CreateInBatches(values, batchSize)
+}
+
+// Save : !!! underlying implementation is different with GORM
+// The method is equivalent to executing the statement: db.Clauses(clause.OnConflict{UpdateAll: true}).Create(values)
+func (s swapEventDo) Save(values ...*models.SwapEvent) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return s.DO.Save(values)
+}
+
+func (s swapEventDo) First() (*models.SwapEvent, error) {
+	if result, err := s.DO.First(); err != nil {
+		return nil, err
+	} else {
+		return result.(*models.SwapEvent), nil
+	}
+}
+
+func (s swapEventDo) Take() (*models.SwapEvent, error) {
+	if result, err := s.DO.Take(); err != nil {
+		return nil, err
+	} else {
+		return result.(*models.SwapEvent), nil
+	}
+}
+
+func (s swapEventDo) Last() (*models.SwapEvent, error) {
+	if result, err := s.DO.Last(); err != nil {
+		return nil, err
+	} else {
+		return result.(*models.SwapEvent), nil
+	}
+}
+
+func (s swapEventDo) Find() ([]*models.SwapEvent, error) {
+	result, err := s.DO.Find()
+	return result.([]*models.SwapEvent), err
+}
+
+func (s swapEventDo) FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*models.SwapEvent, err error) {
+	buf := make([]*models.SwapEvent, 0, batchSize)
+	err = s.DO.FindInBatches(&buf, batchSize, func(tx gen.Dao, batch int) error {
+		defer func() { results = append(results, buf...) }()
+		return fc(tx, batch)
+	})
+	return results, err
+}
+
+func (s swapEventDo) FindInBatches(result *[]*models.SwapEvent, batchSize int, fc func(tx gen.Dao, batch int) error) error {
+	return s.DO.FindInBatches(result, batchSize, fc)
+}
+
+func (s swapEventDo) Attrs(attrs ...field.AssignExpr) ISwapEventDo {
+	return s.withDO(s.DO.Attrs(attrs...))
+}
+
+func (s swapEventDo) Assign(attrs ...field.AssignExpr) ISwapEventDo {
+	return s.withDO(s.DO.Assign(attrs...))
+}
+
+func (s swapEventDo) Joins(fields ...field.RelationField) ISwapEventDo {
+	for _, _f := range fields {
+		s = *s.withDO(s.DO.Joins(_f))
+	}
+	return &s
+}
+
+func (s swapEventDo) Preload(fields ...field.RelationField) ISwapEventDo {
+	for _, _f := range fields {
+		s = *s.withDO(s.DO.Preload(_f))
+	}
+	return &s
+}
+
+func (s swapEventDo) FirstOrInit() (*models.SwapEvent, error) {
+	if result, err := s.DO.FirstOrInit(); err != nil {
+		return nil, err
+	} else {
+		return result.(*models.SwapEvent), nil
+	}
+}
+
+func (s swapEventDo) FirstOrCreate() (*models.SwapEvent, error) {
+	if result, err := s.DO.FirstOrCreate(); err != nil {
+		return nil, err
+	} else {
+		return result.(*models.SwapEvent), nil
+	}
+}
+
+func (s swapEventDo) FindByPage(offset int, limit int) (result []*models.SwapEvent, count int64, err error) {
+	result, err = s.Offset(offset).Limit(limit).Find()
+	if err != nil {
+		return
+	}
+
+	if size := len(result); 0 < limit && 0 < size && size < limit {
+		count = int64(size + offset)
+		return
+	}
+
+	count, err = s.Offset(-1).Limit(-1).Count()
+	return
+}
+
+func (s swapEventDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
+	count, err = s.Count()
+	if err != nil {
+		return
+	}
+
+	err = s.Offset(offset).Limit(limit).Scan(result)
+	return
+}
+
+func (s swapEventDo) Scan(result interface{}) (err error) {
+	return s.DO.Scan(result)
+}
+
+func (s swapEventDo) Delete(models ...*models.SwapEvent) (result gen.ResultInfo, err error) {
+	return s.DO.Delete(models)
+}
+
+func (s *swapEventDo) withDO(do gen.Dao) *swapEventDo {
+	s.DO = *do.(*gen.DO)
+	return s
+}
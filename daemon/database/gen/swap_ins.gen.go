@@ -48,6 +48,12 @@ func newSwapIn(db *gorm.DB, opts ...gen.DOOption) swapIn {
 	_swapIn.RefundRequestedAt = field.NewTime(tableName, "refund_requested_at")
 	_swapIn.LockTxID = field.NewString(tableName, "lock_tx_id")
 	_swapIn.RefundAmount = field.NewInt64(tableName, "refund_amount")
+	_swapIn.NotFoundSince = field.NewTime(tableName, "not_found_since")
+	_swapIn.FiatCurrency = field.NewString(tableName, "fiat_currency")
+	_swapIn.FiatRate = field.NewField(tableName, "fiat_rate")
+	_swapIn.ClientRequestID = field.NewString(tableName, "client_request_id")
+	_swapIn.Label = field.NewString(tableName, "label")
+	_swapIn.InvoiceGenerated = field.NewBool(tableName, "invoice_generated")
 
 	_swapIn.fillFieldMap()
 
@@ -79,6 +85,12 @@ type swapIn struct {
 	RefundRequestedAt  field.Time
 	LockTxID           field.String
 	RefundAmount       field.Int64
+	NotFoundSince      field.Time
+	FiatCurrency       field.String
+	FiatRate           field.Field
+	ClientRequestID    field.String
+	Label              field.String
+	InvoiceGenerated   field.Bool
 
 	fieldMap map[string]field.Expr
 }
@@ -116,6 +128,12 @@ func (s *swapIn) updateTableName(table string) *swapIn {
 	s.RefundRequestedAt = field.NewTime(table, "refund_requested_at")
 	s.LockTxID = field.NewString(table, "lock_tx_id")
 	s.RefundAmount = field.NewInt64(table, "refund_amount")
+	s.NotFoundSince = field.NewTime(table, "not_found_since")
+	s.FiatCurrency = field.NewString(table, "fiat_currency")
+	s.FiatRate = field.NewField(table, "fiat_rate")
+	s.ClientRequestID = field.NewString(table, "client_request_id")
+	s.Label = field.NewString(table, "label")
+	s.InvoiceGenerated = field.NewBool(table, "invoice_generated")
 
 	s.fillFieldMap()
 
@@ -140,7 +158,7 @@ func (s *swapIn) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 }
 
 func (s *swapIn) fillFieldMap() {
-	s.fieldMap = make(map[string]field.Expr, 21)
+	s.fieldMap = make(map[string]field.Expr, 27)
 	s.fieldMap["id"] = s.ID
 	s.fieldMap["swap_id"] = s.SwapID
 	s.fieldMap["amount_sats"] = s.AmountSats
@@ -162,6 +180,12 @@ func (s *swapIn) fillFieldMap() {
 	s.fieldMap["refund_requested_at"] = s.RefundRequestedAt
 	s.fieldMap["lock_tx_id"] = s.LockTxID
 	s.fieldMap["refund_amount"] = s.RefundAmount
+	s.fieldMap["not_found_since"] = s.NotFoundSince
+	s.fieldMap["fiat_currency"] = s.FiatCurrency
+	s.fieldMap["fiat_rate"] = s.FiatRate
+	s.fieldMap["client_request_id"] = s.ClientRequestID
+	s.fieldMap["label"] = s.Label
+	s.fieldMap["invoice_generated"] = s.InvoiceGenerated
 }
 
 func (s swapIn) clone(db *gorm.DB) swapIn {
@@ -49,6 +49,14 @@ func newSwapOut(db *gorm.DB, opts ...gen.DOOption) swapOut {
 	_swapOut.RefundPublicKey = field.NewString(tableName, "refund_public_key")
 	_swapOut.CreatedAt = field.NewTime(tableName, "created_at")
 	_swapOut.UpdatedAt = field.NewTime(tableName, "updated_at")
+	_swapOut.NotFoundSince = field.NewTime(tableName, "not_found_since")
+	_swapOut.FiatCurrency = field.NewString(tableName, "fiat_currency")
+	_swapOut.FiatRate = field.NewField(tableName, "fiat_rate")
+	_swapOut.ClientRequestID = field.NewString(tableName, "client_request_id")
+	_swapOut.Label = field.NewString(tableName, "label")
+	_swapOut.PaymentStatus = field.NewString(tableName, "payment_status")
+	_swapOut.PaymentAttempts = field.NewInt64(tableName, "payment_attempts")
+	_swapOut.PaymentError = field.NewString(tableName, "payment_error")
 
 	_swapOut.fillFieldMap()
 
@@ -81,6 +89,14 @@ type swapOut struct {
 	RefundPublicKey    field.String
 	CreatedAt          field.Time
 	UpdatedAt          field.Time
+	NotFoundSince      field.Time
+	FiatCurrency       field.String
+	FiatRate           field.Field
+	ClientRequestID    field.String
+	Label              field.String
+	PaymentStatus      field.String
+	PaymentAttempts    field.Int64
+	PaymentError       field.String
 
 	fieldMap map[string]field.Expr
 }
@@ -119,6 +135,14 @@ func (s *swapOut) updateTableName(table string) *swapOut {
 	s.RefundPublicKey = field.NewString(table, "refund_public_key")
 	s.CreatedAt = field.NewTime(table, "created_at")
 	s.UpdatedAt = field.NewTime(table, "updated_at")
+	s.NotFoundSince = field.NewTime(table, "not_found_since")
+	s.FiatCurrency = field.NewString(table, "fiat_currency")
+	s.FiatRate = field.NewField(table, "fiat_rate")
+	s.ClientRequestID = field.NewString(table, "client_request_id")
+	s.Label = field.NewString(table, "label")
+	s.PaymentStatus = field.NewString(table, "payment_status")
+	s.PaymentAttempts = field.NewInt64(table, "payment_attempts")
+	s.PaymentError = field.NewString(table, "payment_error")
 
 	s.fillFieldMap()
 
@@ -143,7 +167,7 @@ func (s *swapOut) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 }
 
 func (s *swapOut) fillFieldMap() {
-	s.fieldMap = make(map[string]field.Expr, 22)
+	s.fieldMap = make(map[string]field.Expr, 30)
 	s.fieldMap["id"] = s.ID
 	s.fieldMap["swap_id"] = s.SwapID
 	s.fieldMap["status"] = s.Status
@@ -166,6 +190,14 @@ func (s *swapOut) fillFieldMap() {
 	s.fieldMap["refund_public_key"] = s.RefundPublicKey
 	s.fieldMap["created_at"] = s.CreatedAt
 	s.fieldMap["updated_at"] = s.UpdatedAt
+	s.fieldMap["not_found_since"] = s.NotFoundSince
+	s.fieldMap["fiat_currency"] = s.FiatCurrency
+	s.fieldMap["fiat_rate"] = s.FiatRate
+	s.fieldMap["client_request_id"] = s.ClientRequestID
+	s.fieldMap["label"] = s.Label
+	s.fieldMap["payment_status"] = s.PaymentStatus
+	s.fieldMap["payment_attempts"] = s.PaymentAttempts
+	s.fieldMap["payment_error"] = s.PaymentError
 }
 
 func (s swapOut) clone(db *gorm.DB) swapOut {
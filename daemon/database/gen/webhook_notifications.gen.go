@@ -0,0 +1,436 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package gen
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+
+	"gorm.io/plugin/dbresolver"
+
+	"github.com/40acres/40swap/daemon/database/models"
+)
+
+func newWebhookNotification(db *gorm.DB, opts ...gen.DOOption) webhookNotification {
+	_webhookNotification := webhookNotification{}
+
+	_webhookNotification.webhookNotificationDo.UseDB(db, opts...)
+	_webhookNotification.webhookNotificationDo.UseModel(&models.WebhookNotification{})
+
+	tableName := _webhookNotification.webhookNotificationDo.TableName()
+	_webhookNotification.ALL = field.NewAsterisk(tableName)
+	_webhookNotification.ID = field.NewInt64(tableName, "id")
+	_webhookNotification.URL = field.NewString(tableName, "url")
+	_webhookNotification.EventType = field.NewString(tableName, "event_type")
+	_webhookNotification.SwapID = field.NewString(tableName, "swap_id")
+	_webhookNotification.Payload = field.NewString(tableName, "payload")
+	_webhookNotification.Status = field.NewString(tableName, "status")
+	_webhookNotification.Attempts = field.NewInt32(tableName, "attempts")
+	_webhookNotification.NextAttemptAt = field.NewTime(tableName, "next_attempt_at")
+	_webhookNotification.LastError = field.NewString(tableName, "last_error")
+	_webhookNotification.DeliveredAt = field.NewTime(tableName, "delivered_at")
+	_webhookNotification.CreatedAt = field.NewTime(tableName, "created_at")
+	_webhookNotification.UpdatedAt = field.NewTime(tableName, "updated_at")
+
+	_webhookNotification.fillFieldMap()
+
+	return _webhookNotification
+}
+
+type webhookNotification struct {
+	webhookNotificationDo webhookNotificationDo
+
+	ALL           field.Asterisk
+	ID            field.Int64
+	URL           field.String
+	EventType     field.String
+	SwapID        field.String
+	Payload       field.String
+	Status        field.String
+	Attempts      field.Int32
+	NextAttemptAt field.Time
+	LastError     field.String
+	DeliveredAt   field.Time
+	CreatedAt     field.Time
+	UpdatedAt     field.Time
+
+	fieldMap map[string]field.Expr
+}
+
+func (w webhookNotification) Table(newTableName string) *webhookNotification {
+	w.webhookNotificationDo.UseTable(newTableName)
+	return w.updateTableName(newTableName)
+}
+
+func (w webhookNotification) As(alias string) *webhookNotification {
+	w.webhookNotificationDo.DO = *(w.webhookNotificationDo.As(alias).(*gen.DO))
+	return w.updateTableName(alias)
+}
+
+func (w *webhookNotification) updateTableName(table string) *webhookNotification {
+	w.ALL = field.NewAsterisk(table)
+	w.ID = field.NewInt64(table, "id")
+	w.URL = field.NewString(table, "url")
+	w.EventType = field.NewString(table, "event_type")
+	w.SwapID = field.NewString(table, "swap_id")
+	w.Payload = field.NewString(table, "payload")
+	w.Status = field.NewString(table, "status")
+	w.Attempts = field.NewInt32(table, "attempts")
+	w.NextAttemptAt = field.NewTime(table, "next_attempt_at")
+	w.LastError = field.NewString(table, "last_error")
+	w.DeliveredAt = field.NewTime(table, "delivered_at")
+	w.CreatedAt = field.NewTime(table, "created_at")
+	w.UpdatedAt = field.NewTime(table, "updated_at")
+
+	w.fillFieldMap()
+
+	return w
+}
+
+func (w *webhookNotification) WithContext(ctx context.Context) IWebhookNotificationDo {
+	return w.webhookNotificationDo.WithContext(ctx)
+}
+
+func (w webhookNotification) TableName() string { return w.webhookNotificationDo.TableName() }
+
+func (w webhookNotification) Alias() string { return w.webhookNotificationDo.Alias() }
+
+func (w webhookNotification) Columns(cols ...field.Expr) gen.Columns {
+	return w.webhookNotificationDo.Columns(cols...)
+}
+
+func (w *webhookNotification) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
+	_f, ok := w.fieldMap[fieldName]
+	if !ok || _f == nil {
+		return nil, false
+	}
+	_oe, ok := _f.(field.OrderExpr)
+	return _oe, ok
+}
+
+func (w *webhookNotification) fillFieldMap() {
+	w.fieldMap = make(map[string]field.Expr, 12)
+	w.fieldMap["id"] = w.ID
+	w.fieldMap["url"] = w.URL
+	w.fieldMap["event_type"] = w.EventType
+	w.fieldMap["swap_id"] = w.SwapID
+	w.fieldMap["payload"] = w.Payload
+	w.fieldMap["status"] = w.Status
+	w.fieldMap["attempts"] = w.Attempts
+	w.fieldMap["next_attempt_at"] = w.NextAttemptAt
+	w.fieldMap["last_error"] = w.LastError
+	w.fieldMap["delivered_at"] = w.DeliveredAt
+	w.fieldMap["created_at"] = w.CreatedAt
+	w.fieldMap["updated_at"] = w.UpdatedAt
+}
+
+func (w webhookNotification) clone(db *gorm.DB) webhookNotification {
+	w.webhookNotificationDo.ReplaceConnPool(db.Statement.ConnPool)
+	return w
+}
+
+func (w webhookNotification) replaceDB(db *gorm.DB) webhookNotification {
+	w.webhookNotificationDo.ReplaceDB(db)
+	return w
+}
+
+type webhookNotificationDo struct{ gen.DO }
+
+type IWebhookNotificationDo interface {
+	gen.SubQuery
+	Debug() IWebhookNotificationDo
+	WithContext(ctx context.Context) IWebhookNotificationDo
+	WithResult(fc func(tx gen.Dao)) gen.ResultInfo
+	ReplaceDB(db *gorm.DB)
+	ReadDB() IWebhookNotificationDo
+	WriteDB() IWebhookNotificationDo
+	As(alias string) gen.Dao
+	Session(config *gorm.Session) IWebhookNotificationDo
+	Columns(cols ...field.Expr) gen.Columns
+	Clauses(conds ...clause.Expression) IWebhookNotificationDo
+	Not(conds ...gen.Condition) IWebhookNotificationDo
+	Or(conds ...gen.Condition) IWebhookNotificationDo
+	Select(conds ...field.Expr) IWebhookNotificationDo
+	Where(conds ...gen.Condition) IWebhookNotificationDo
+	Order(conds ...field.Expr) IWebhookNotificationDo
+	Distinct(cols ...field.Expr) IWebhookNotificationDo
+	Omit(cols ...field.Expr) IWebhookNotificationDo
+	Join(table schema.Tabler, on ...field.Expr) IWebhookNotificationDo
+	LeftJoin(table schema.Tabler, on ...field.Expr) IWebhookNotificationDo
+	RightJoin(table schema.Tabler, on ...field.Expr) IWebhookNotificationDo
+	Group(cols ...field.Expr) IWebhookNotificationDo
+	Having(conds ...gen.Condition) IWebhookNotificationDo
+	Limit(limit int) IWebhookNotificationDo
+	Offset(offset int) IWebhookNotificationDo
+	Count() (count int64, err error)
+	Scopes(funcs ...func(gen.Dao) gen.Dao) IWebhookNotificationDo
+	Unscoped() IWebhookNotificationDo
+	Create(values ...*models.WebhookNotification) error
+	CreateInBatches(values []*models.WebhookNotification, batchSize int) error
+	Save(values ...*models.WebhookNotification) error
+	First() (*models.WebhookNotification, error)
+	Take() (*models.WebhookNotification, error)
+	Last() (*models.WebhookNotification, error)
+	Find() ([]*models.WebhookNotification, error)
+	FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*models.WebhookNotification, err error)
+	FindInBatches(result *[]*models.WebhookNotification, batchSize int, fc func(tx gen.Dao, batch int) error) error
+	Pluck(column field.Expr, dest interface{}) error
+	Delete(...*models.WebhookNotification) (info gen.ResultInfo, err error)
+	Update(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	Updates(value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumn(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumnSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	UpdateColumns(value interface{}) (info gen.ResultInfo, err error)
+	UpdateFrom(q gen.SubQuery) gen.Dao
+	Attrs(attrs ...field.AssignExpr) IWebhookNotificationDo
+	Assign(attrs ...field.AssignExpr) IWebhookNotificationDo
+	Joins(fields ...field.RelationField) IWebhookNotificationDo
+	Preload(fields ...field.RelationField) IWebhookNotificationDo
+	FirstOrInit() (*models.WebhookNotification, error)
+	FirstOrCreate() (*models.WebhookNotification, error)
+	FindByPage(offset int, limit int) (result []*models.WebhookNotification, count int64, err error)
+	ScanByPage(result interface{}, offset int, limit int) (count int64, err error)
+	Scan(result interface{}) (err error)
+	Returning(value interface{}, columns ...string) IWebhookNotificationDo
+	UnderlyingDB() *gorm.DB
+	schema.Tabler
+}
+
+func (w webhookNotificationDo) Debug() IWebhookNotificationDo {
+	return w.withDO(w.DO.Debug())
+}
+
+func (w webhookNotificationDo) WithContext(ctx context.Context) IWebhookNotificationDo {
+	return w.withDO(w.DO.WithContext(ctx))
+}
+
+func (w webhookNotificationDo) ReadDB() IWebhookNotificationDo {
+	return w.Clauses(dbresolver.Read)
+}
+
+func (w webhookNotificationDo) WriteDB() IWebhookNotificationDo {
+	return w.Clauses(dbresolver.Write)
+}
+
+func (w webhookNotificationDo) Session(config *gorm.Session) IWebhookNotificationDo {
+	return w.withDO(w.DO.Session(config))
+}
+
+func (w webhookNotificationDo) Clauses(conds ...clause.Expression) IWebhookNotificationDo {
+	return w.withDO(w.DO.Clauses(conds...))
+}
+
+func (w webhookNotificationDo) Returning(value interface{}, columns ...string) IWebhookNotificationDo {
+	return w.withDO(w.DO.Returning(value, columns...))
+}
+
+func (w webhookNotificationDo) Not(conds ...gen.Condition) IWebhookNotificationDo {
+	return w.withDO(w.DO.Not(conds...))
+}
+
+func (w webhookNotificationDo) Or(conds ...gen.Condition) IWebhookNotificationDo {
+	return w.withDO(w.DO.Or(conds...))
+}
+
+func (w webhookNotificationDo) Select(conds ...field.Expr) IWebhookNotificationDo {
+	return w.withDO(w.DO.Select(conds...))
+}
+
+func (w webhookNotificationDo) Where(conds ...gen.Condition) IWebhookNotificationDo {
+	return w.withDO(w.DO.Where(conds...))
+}
+
+func (w webhookNotificationDo) Order(conds ...field.Expr) IWebhookNotificationDo {
+	return w.withDO(w.DO.Order(conds...))
+}
+
+func (w webhookNotificationDo) Distinct(cols ...field.Expr) IWebhookNotificationDo {
+	return w.withDO(w.DO.Distinct(cols...))
+}
+
+func (w webhookNotificationDo) Omit(cols ...field.Expr) IWebhookNotificationDo {
+	return w.withDO(w.DO.Omit(cols...))
+}
+
+func (w webhookNotificationDo) Join(table schema.Tabler, on ...field.Expr) IWebhookNotificationDo {
+	return w.withDO(w.DO.Join(table, on...))
+}
+
+func (w webhookNotificationDo) LeftJoin(table schema.Tabler, on ...field.Expr) IWebhookNotificationDo {
+	return w.withDO(w.DO.LeftJoin(table, on...))
+}
+
+func (w webhookNotificationDo) RightJoin(table schema.Tabler, on ...field.Expr) IWebhookNotificationDo {
+	return w.withDO(w.DO.RightJoin(table, on...))
+}
+
+func (w webhookNotificationDo) Group(cols ...field.Expr) IWebhookNotificationDo {
+	return w.withDO(w.DO.Group(cols...))
+}
+
+func (w webhookNotificationDo) Having(conds ...gen.Condition) IWebhookNotificationDo {
+	return w.withDO(w.DO.Having(conds...))
+}
+
+func (w webhookNotificationDo) Limit(limit int) IWebhookNotificationDo {
+	return w.withDO(w.DO.Limit(limit))
+}
+
+func (w webhookNotificationDo) Offset(offset int) IWebhookNotificationDo {
+	return w.withDO(w.DO.Offset(offset))
+}
+
+func (w webhookNotificationDo) Scopes(funcs ...func(gen.Dao) gen.Dao) IWebhookNotificationDo {
+	return w.withDO(w.DO.Scopes(funcs...))
+}
+
+func (w webhookNotificationDo) Unscoped() IWebhookNotificationDo {
+	return w.withDO(w.DO.Unscoped())
+}
+
+func (w webhookNotificationDo) Create(values ...*models.WebhookNotification) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return w.DO.Create(values)
+}
+
+func (w webhookNotificationDo) CreateInBatches(values []*models.WebhookNotification, batchSize int) error {
+	return w.DO.CreateInBatches(values, batchSize)
+}
+
+// Save : !!! underlying implementation is different with GORM
+// The method is equivalent to executing the statement: db.Clauses(clause.OnConflict{UpdateAll: true}).Create(values)
+func (w webhookNotificationDo) Save(values ...*models.WebhookNotification) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return w.DO.Save(values)
+}
+
+func (w webhookNotificationDo) First() (*models.WebhookNotification, error) {
+	if result, err := w.DO.First(); err != nil {
+		return nil, err
+	} else {
+		return result.(*models.WebhookNotification), nil
+	}
+}
+
+func (w webhookNotificationDo) Take() (*models.WebhookNotification, error) {
+	if result, err := w.DO.Take(); err != nil {
+		return nil, err
+	} else {
+		return result.(*models.WebhookNotification), nil
+	}
+}
+
+func (w webhookNotificationDo) Last() (*models.WebhookNotification, error) {
+	if result, err := w.DO.Last(); err != nil {
+		return nil, err
+	} else {
+		return result.(*models.WebhookNotification), nil
+	}
+}
+
+func (w webhookNotificationDo) Find() ([]*models.WebhookNotification, error) {
+	result, err := w.DO.Find()
+	return result.([]*models.WebhookNotification), err
+}
+
+func (w webhookNotificationDo) FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*models.WebhookNotification, err error) {
+	buf := make([]*models.WebhookNotification, 0, batchSize)
+	err = w.DO.FindInBatches(&buf, batchSize, func(tx gen.Dao, batch int) error {
+		defer func() { results = append(results, buf...) }()
+		return fc(tx, batch)
+	})
+	return results, err
+}
+
+func (w webhookNotificationDo) FindInBatches(result *[]*models.WebhookNotification, batchSize int, fc func(tx gen.Dao, batch int) error) error {
+	return w.DO.FindInBatches(result, batchSize, fc)
+}
+
+func (w webhookNotificationDo) Attrs(attrs ...field.AssignExpr) IWebhookNotificationDo {
+	return w.withDO(w.DO.Attrs(attrs...))
+}
+
+func (w webhookNotificationDo) Assign(attrs ...field.AssignExpr) IWebhookNotificationDo {
+	return w.withDO(w.DO.Assign(attrs...))
+}
+
+func (w webhookNotificationDo) Joins(fields ...field.RelationField) IWebhookNotificationDo {
+	for _, _f := range fields {
+		w = *w.withDO(w.DO.Joins(_f))
+	}
+	return &w
+}
+
+func (w webhookNotificationDo) Preload(fields ...field.RelationField) IWebhookNotificationDo {
+	for _, _f := range fields {
+		w = *w.withDO(w.DO.Preload(_f))
+	}
+	return &w
+}
+
+func (w webhookNotificationDo) FirstOrInit() (*models.WebhookNotification, error) {
+	if result, err := w.DO.FirstOrInit(); err != nil {
+		return nil, err
+	} else {
+		return result.(*models.WebhookNotification), nil
+	}
+}
+
+func (w webhookNotificationDo) FirstOrCreate() (*models.WebhookNotification, error) {
+	if result, err := w.DO.FirstOrCreate(); err != nil {
+		return nil, err
+	} else {
+		return result.(*models.WebhookNotification), nil
+	}
+}
+
+func (w webhookNotificationDo) FindByPage(offset int, limit int) (result []*models.WebhookNotification, count int64, err error) {
+	result, err = w.Offset(offset).Limit(limit).Find()
+	if err != nil {
+		return
+	}
+
+	if size := len(result); 0 < limit && 0 < size && size < limit {
+		count = int64(size + offset)
+		return
+	}
+
+	count, err = w.Offset(-1).Limit(-1).Count()
+	return
+}
+
+func (w webhookNotificationDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
+	count, err = w.Count()
+	if err != nil {
+		return
+	}
+
+	err = w.Offset(offset).Limit(limit).Scan(result)
+	return
+}
+
+func (w webhookNotificationDo) Scan(result interface{}) (err error) {
+	return w.DO.Scan(result)
+}
+
+func (w webhookNotificationDo) Delete(models ...*models.WebhookNotification) (result gen.ResultInfo, err error) {
+	return w.DO.Delete(models)
+}
+
+func (w *webhookNotificationDo) withDO(do gen.Dao) *webhookNotificationDo {
+	w.DO = *do.(*gen.DO)
+	return w
+}
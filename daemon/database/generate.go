@@ -73,6 +73,8 @@ func generate(db *gorm.DB, path string) (err error) {
 			gen.FieldGORMTag("pre_image", func(tag field.GormTag) field.GormTag {
 				return tag.Append("serializer", "preimage")
 			}),
+			gen.FieldType("fiat_rate", "*decimal.Decimal"),
+			gen.FieldType("client_request_id", "*string"),
 		),
 		g.GenerateModelAs("swap_outs", "SwapOut",
 			gen.FieldType("status", "SwapStatus"),
@@ -82,6 +84,18 @@ func generate(db *gorm.DB, path string) (err error) {
 			gen.FieldGORMTag("pre_image", func(tag field.GormTag) field.GormTag {
 				return tag.Set("serializer", "preimage")
 			}),
+			gen.FieldType("fiat_rate", "*decimal.Decimal"),
+			gen.FieldType("client_request_id", "*string"),
+			gen.FieldType("payment_status", "PaymentStatus"),
+		),
+		g.GenerateModelAs("swap_events", "SwapEvent",
+			gen.FieldType("direction", "SwapDirection"),
+			gen.FieldType("from_status", "*SwapStatus"),
+			gen.FieldType("to_status", "SwapStatus"),
+			gen.FieldType("outcome", "*SwapOutcome"),
+		),
+		g.GenerateModelAs("webhook_notifications", "WebhookNotification",
+			gen.FieldType("status", "NotificationStatus"),
 		),
 	)
 
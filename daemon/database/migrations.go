@@ -2,11 +2,13 @@ package database
 
 import (
 	"errors"
+	"slices"
 	"time"
 
 	"github.com/40acres/40swap/daemon/database/models"
 	"github.com/go-gormigrate/gormigrate/v2"
 	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
 )
 
@@ -16,28 +18,28 @@ func CreateSwapsTables() *gormigrate.Migration {
 	type swapOut struct {
 		ID uint `gorm:"primaryKey;autoIncrement"`
 
-		SwapId             string  `gorm:"not null;unique"`
-		Status             int     `gorm:"type:swap_status;not null"`
-		AmountSATS         uint64  `gorm:"not null"`
-		DestinationAddress string  `gorm:"not null"`
-		ServiceFeeSATS     uint64  `gorm:"not null"`
-		OnchainFeeSATS     uint64  `gorm:"not null"`
-		OffchainFeeSATS    uint64  `gorm:"not null"`
-		DestinationChain   int     `gorm:"type:chain_enum;not null"`
-		ClaimPubkey        string  `gorm:"not null"`
-		PaymentRequest     string  `gorm:"not null"`
-		Description        *string `gorm:"not null"`
-		MaxRoutingFeeRatio float64 `gorm:"not null"`
-		Outcome            int     `gorm:"type:swap_outcome;not null"`
+		SwapId             string          `gorm:"not null;unique"`
+		Status             swapStatusEnum  `gorm:"not null"`
+		AmountSATS         uint64          `gorm:"not null"`
+		DestinationAddress string          `gorm:"not null"`
+		ServiceFeeSATS     uint64          `gorm:"not null"`
+		OnchainFeeSATS     uint64          `gorm:"not null"`
+		OffchainFeeSATS    uint64          `gorm:"not null"`
+		DestinationChain   chainEnum       `gorm:"not null"`
+		ClaimPubkey        string          `gorm:"not null"`
+		PaymentRequest     string          `gorm:"not null"`
+		Description        *string         `gorm:"not null"`
+		MaxRoutingFeeRatio float64         `gorm:"not null"`
+		Outcome            swapOutcomeEnum `gorm:"not null"`
 	}
 
 	type swapIn struct {
-		ID                 uint   `gorm:"primaryKey;autoIncrement"`
-		SwapID             string `gorm:"not null"`
-		AmountSATS         uint64 `gorm:"not null"`
-		Status             int    `gorm:"type:swap_status;not null"`
-		Outcome            int    `gorm:"type:swap_outcome;not null"`
-		SourceChain        int    `gorm:"type:chain_enum;not null"`
+		ID                 uint            `gorm:"primaryKey;autoIncrement"`
+		SwapID             string          `gorm:"not null"`
+		AmountSATS         uint64          `gorm:"not null"`
+		Status             swapStatusEnum  `gorm:"not null"`
+		Outcome            swapOutcomeEnum `gorm:"not null"`
+		SourceChain        chainEnum       `gorm:"not null"`
 		ClaimAddress       string
 		ClaimTxId          string
 		TimeoutBlockHeight uint64
@@ -56,16 +58,16 @@ func CreateSwapsTables() *gormigrate.Migration {
 	return &gormigrate.Migration{
 		ID: ID,
 		Migrate: func(tx *gorm.DB) error {
-			if err := tx.Exec(models.CreateChainEnumSQL()); err.Error != nil {
-				return err.Error
+			if err := execPostgres(tx, models.CreateChainEnumSQL()); err != nil {
+				return err
 			}
 
-			if err := tx.Exec(models.CreateSwapStatusEnumSQL()); err.Error != nil {
-				return err.Error
+			if err := execPostgres(tx, models.CreateSwapStatusEnumSQL()); err != nil {
+				return err
 			}
 
-			if err := tx.Exec(models.CreateSwapOutcomeEnumSQL()); err.Error != nil {
-				return err.Error
+			if err := execPostgres(tx, models.CreateSwapOutcomeEnumSQL()); err != nil {
+				return err
 			}
 
 			if err := tx.Migrator().CreateTable(&swapOut{}); err != nil {
@@ -83,15 +85,15 @@ func CreateSwapsTables() *gormigrate.Migration {
 				return err
 			}
 
-			if err := tx.Exec(models.DropSwapOutcomeEnumSQL()); err.Error != nil {
-				return err.Error
+			if err := execPostgres(tx, models.DropSwapOutcomeEnumSQL()); err != nil {
+				return err
 			}
 
-			if err := tx.Exec(models.DropSwapStatusEnumSQL()); err.Error != nil {
-				return err.Error
+			if err := execPostgres(tx, models.DropSwapStatusEnumSQL()); err != nil {
+				return err
 			}
 
-			return tx.Exec(models.DropChainEnumSQL()).Error
+			return execPostgres(tx, models.DropChainEnumSQL())
 		},
 	}
 }
@@ -104,10 +106,10 @@ func RemoveNotNullInOutcome() *gormigrate.Migration {
 		ID: ID,
 		Migrate: func(tx *gorm.DB) error {
 			type swapIn struct {
-				Outcome *int `gorm:"type:swap_outcome"`
+				Outcome *swapOutcomeEnum
 			}
 			type swapOut struct {
-				Outcome *int `gorm:"type:swap_outcome"`
+				Outcome *swapOutcomeEnum
 			}
 
 			if err := tx.Migrator().AlterColumn(&swapOut{}, "outcome"); err != nil {
@@ -118,10 +120,10 @@ func RemoveNotNullInOutcome() *gormigrate.Migration {
 		},
 		Rollback: func(tx *gorm.DB) error {
 			type swapIn struct {
-				Outcome int `gorm:"type:swap_outcome;not null"`
+				Outcome swapOutcomeEnum `gorm:"not null"`
 			}
 			type swapOut struct {
-				Outcome int `gorm:"type:swap_outcome;not null"`
+				Outcome swapOutcomeEnum `gorm:"not null"`
 			}
 
 			if err := tx.Migrator().AlterColumn(&swapIn{}, "outcome"); err != nil {
@@ -390,6 +392,358 @@ func RenameOnchainFeeSatsAndAddCreatedAndUpdatedAt() *gormigrate.Migration {
 	}
 }
 
+func AddNotFoundSinceToSwaps() *gormigrate.Migration {
+	const ID = "12_add_not_found_since_to_swaps"
+
+	type swapIn struct {
+		NotFoundSince *timestamptz
+	}
+
+	type swapOut struct {
+		NotFoundSince *timestamptz
+	}
+
+	return &gormigrate.Migration{
+		ID: ID,
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.Migrator().AddColumn(&swapIn{}, "NotFoundSince"); err != nil {
+				return err
+			}
+
+			return tx.Migrator().AddColumn(&swapOut{}, "NotFoundSince")
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Migrator().DropColumn(&swapOut{}, "NotFoundSince"); err != nil {
+				return err
+			}
+
+			return tx.Migrator().DropColumn(&swapIn{}, "NotFoundSince")
+		},
+	}
+}
+
+func CreateSwapEventsTable() *gormigrate.Migration {
+	const ID = "13_create_swap_events_table"
+
+	type swapEvent struct {
+		ID              uint              `gorm:"primaryKey;autoIncrement"`
+		SwapID          string            `gorm:"not null;index"`
+		Direction       swapDirectionEnum `gorm:"not null"`
+		FromStatus      *swapStatusEnum
+		ToStatus        swapStatusEnum `gorm:"not null"`
+		Outcome         *swapOutcomeEnum
+		LockTxID        string
+		ClaimTxID       string
+		RefundTxID      string
+		ServiceFeeSats  int64
+		OnchainFeeSats  int64
+		OffchainFeeSats int64
+		Message         string
+		Error           string
+		CreatedAt       timestamptz `gorm:"autoCreateTime"`
+	}
+
+	return &gormigrate.Migration{
+		ID: ID,
+		Migrate: func(tx *gorm.DB) error {
+			if err := execPostgres(tx, models.CreateSwapDirectionEnumSQL()); err != nil {
+				return err
+			}
+
+			return tx.Migrator().CreateTable(&swapEvent{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Migrator().DropTable(&swapEvent{}); err != nil {
+				return err
+			}
+
+			return execPostgres(tx, models.DropSwapDirectionEnumSQL())
+		},
+	}
+}
+
+func CreateWebhookNotificationsTable() *gormigrate.Migration {
+	const ID = "14_create_webhook_notifications_table"
+
+	type webhookNotification struct {
+		ID            uint        `gorm:"primaryKey;autoIncrement"`
+		URL           string      `gorm:"not null"`
+		EventType     string      `gorm:"not null"`
+		SwapID        string      `gorm:"index"`
+		Payload       string      `gorm:"not null"`
+		Status        string      `gorm:"not null;index"`
+		Attempts      int32       `gorm:"not null;default:0"`
+		NextAttemptAt timestamptz `gorm:"not null"`
+		LastError     string
+		DeliveredAt   *timestamptz
+		CreatedAt     timestamptz `gorm:"autoCreateTime"`
+		UpdatedAt     timestamptz `gorm:"autoUpdateTime"`
+	}
+
+	return &gormigrate.Migration{
+		ID: ID,
+		Migrate: func(tx *gorm.DB) error {
+			return tx.Migrator().CreateTable(&webhookNotification{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&webhookNotification{})
+		},
+	}
+}
+
+func AddFiatRateToSwaps() *gormigrate.Migration {
+	const ID = "15_add_fiat_rate_to_swaps"
+
+	type swapIn struct {
+		FiatCurrency string
+		FiatRate     *decimal.Decimal `gorm:"type:numeric"`
+	}
+
+	type swapOut struct {
+		FiatCurrency string
+		FiatRate     *decimal.Decimal `gorm:"type:numeric"`
+	}
+
+	return &gormigrate.Migration{
+		ID: ID,
+		Migrate: func(tx *gorm.DB) error {
+			for _, table := range []any{&swapIn{}, &swapOut{}} {
+				if err := tx.Migrator().AddColumn(table, "FiatCurrency"); err != nil {
+					return err
+				}
+				if err := tx.Migrator().AddColumn(table, "FiatRate"); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			for _, table := range []any{&swapOut{}, &swapIn{}} {
+				if err := tx.Migrator().DropColumn(table, "FiatRate"); err != nil {
+					return err
+				}
+				if err := tx.Migrator().DropColumn(table, "FiatCurrency"); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func AddClientRequestIDAndLabelToSwaps() *gormigrate.Migration {
+	const ID = "16_add_client_request_id_and_label_to_swaps"
+
+	type swapIn struct {
+		ClientRequestID *string `gorm:"uniqueIndex"`
+		Label           string
+	}
+
+	type swapOut struct {
+		ClientRequestID *string `gorm:"uniqueIndex"`
+		Label           string
+	}
+
+	return &gormigrate.Migration{
+		ID: ID,
+		Migrate: func(tx *gorm.DB) error {
+			for _, table := range []any{&swapIn{}, &swapOut{}} {
+				if err := tx.Migrator().AddColumn(table, "ClientRequestID"); err != nil {
+					return err
+				}
+				if err := tx.Migrator().CreateIndex(table, "ClientRequestID"); err != nil {
+					return err
+				}
+				if err := tx.Migrator().AddColumn(table, "Label"); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			for _, table := range []any{&swapOut{}, &swapIn{}} {
+				if err := tx.Migrator().DropIndex(table, "ClientRequestID"); err != nil {
+					return err
+				}
+				if err := tx.Migrator().DropColumn(table, "Label"); err != nil {
+					return err
+				}
+				if err := tx.Migrator().DropColumn(table, "ClientRequestID"); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func AddPaymentStatusToSwapOut() *gormigrate.Migration {
+	const ID = "17_add_payment_status_to_swap_out"
+
+	type swapOut struct {
+		PaymentStatus   string `gorm:"not null;default:''"`
+		PaymentAttempts int64  `gorm:"not null;default:0"`
+		PaymentError    string
+	}
+
+	return &gormigrate.Migration{
+		ID: ID,
+		Migrate: func(tx *gorm.DB) error {
+			for _, column := range []string{"PaymentStatus", "PaymentAttempts", "PaymentError"} {
+				if err := tx.Migrator().AddColumn(&swapOut{}, column); err != nil {
+					return err
+				}
+			}
+
+			// Swaps created before were paid when they were created, so their
+			// payment status follows from the status of the swap. The invoice
+			// of a claimed or successful swap is paid, the one of a swap that
+			// failed, expired or was refunded wasn't.
+			err := tx.Session(&gorm.Session{AllowGlobalUpdate: true}).Model(&swapOut{}).
+				Update("payment_status", models.PaymentFailed).Error
+			if err != nil {
+				return err
+			}
+
+			err = tx.Model(&swapOut{}).
+				Where("status IN ?", []models.SwapStatus{models.StatusContractClaimedUnconfirmed, models.StatusInvoicePaid}).
+				Or("status = ? AND outcome = ?", models.StatusDone, models.OutcomeSuccess).
+				Update("payment_status", models.PaymentSucceeded).Error
+			if err != nil {
+				return err
+			}
+
+			// The server got the payment of the swaps past created, so the
+			// node sent it and it's tracked again
+			err = tx.Model(&swapOut{}).
+				Where("status IN ?", []models.SwapStatus{
+					models.StatusInvoicePaymentIntentReceived,
+					models.StatusContractFundedUnconfirmed,
+					models.StatusContractFunded,
+				}).
+				Update("payment_status", models.PaymentInFlight).Error
+			if err != nil {
+				return err
+			}
+
+			// The payment of a created swap may have failed to be sent, and
+			// the user may have paid another swap instead, so it's only
+			// followed if the node knows about it and never sent again
+			return tx.Model(&swapOut{}).
+				Where("status = ?", models.StatusCreated).
+				Update("payment_status", models.PaymentUnknown).Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			// The SQLite migrator drops columns recreating the table, which
+			// loses the indexes of the table
+			for _, column := range []string{"payment_error", "payment_attempts", "payment_status"} {
+				if err := tx.Exec("ALTER TABLE swap_outs DROP COLUMN " + column).Error; err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func AddCancelledOutcome() *gormigrate.Migration {
+	const ID = "18_add_cancelled_outcome"
+
+	type swapIn struct {
+		Outcome *cancellableSwapOutcomeEnum
+	}
+
+	type swapOut struct {
+		Outcome *cancellableSwapOutcomeEnum
+	}
+
+	type swapEvent struct {
+		Outcome *cancellableSwapOutcomeEnum
+	}
+
+	type previousSwapIn struct {
+		Outcome *swapOutcomeEnum
+	}
+
+	type previousSwapOut struct {
+		Outcome *swapOutcomeEnum
+	}
+
+	type previousSwapEvent struct {
+		Outcome *swapOutcomeEnum
+	}
+
+	return &gormigrate.Migration{
+		ID: ID,
+		Migrate: func(tx *gorm.DB) error {
+			if tx.Dialector.Name() != DialectSQLite {
+				return execPostgres(tx, models.AddCancelledSwapOutcomeSQL())
+			}
+
+			// SQLite restricts the values with a check constraint, which
+			// takes recreating the tables to change
+			tables := map[string]any{"swap_ins": &swapIn{}, "swap_outs": &swapOut{}, "swap_events": &swapEvent{}}
+			for table, model := range tables {
+				if err := alterSQLiteColumn(tx, model, table, "Outcome"); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			for _, table := range []string{"swap_ins", "swap_outs", "swap_events"} {
+				err := tx.Table(table).Where("outcome = ?", models.OutcomeCancelled).
+					Update("outcome", models.OutcomeFailed).Error
+				if err != nil {
+					return err
+				}
+			}
+
+			// Postgres can't drop a value of an enum, so it's left unused
+			if tx.Dialector.Name() != DialectSQLite {
+				return nil
+			}
+
+			tables := map[string]any{"swap_ins": &previousSwapIn{}, "swap_outs": &previousSwapOut{}, "swap_events": &previousSwapEvent{}}
+			for table, model := range tables {
+				if err := alterSQLiteColumn(tx, model, table, "Outcome"); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func AddInvoiceGeneratedToSwapIn() *gormigrate.Migration {
+	const ID = "19_add_invoice_generated_to_swap_in"
+
+	type swapIn struct {
+		InvoiceGenerated bool `gorm:"not null;default:false"`
+	}
+
+	return &gormigrate.Migration{
+		ID: ID,
+		Migrate: func(tx *gorm.DB) error {
+			// Swaps created before can't tell who created their invoice, so
+			// their invoices are left to expire when they're cancelled
+			return tx.Migrator().AddColumn(&swapIn{}, "InvoiceGenerated")
+		},
+		Rollback: func(tx *gorm.DB) error {
+			// The SQLite migrator drops columns recreating the table, which
+			// loses the indexes of the table
+			return tx.Exec("ALTER TABLE swap_ins DROP COLUMN invoice_generated").Error
+		},
+	}
+}
+
 var migrations = []*gormigrate.Migration{
 	CreateSwapsTables(),
 	RemoveNotNullInOutcome(),
@@ -402,6 +756,14 @@ var migrations = []*gormigrate.Migration{
 	AddIsAutoSwapToSwapOut(),
 	AddContractFieldsToSwapOut(),
 	RenameOnchainFeeSatsAndAddCreatedAndUpdatedAt(),
+	AddNotFoundSinceToSwaps(),
+	CreateSwapEventsTable(),
+	CreateWebhookNotificationsTable(),
+	AddFiatRateToSwaps(),
+	AddClientRequestIDAndLabelToSwaps(),
+	AddPaymentStatusToSwapOut(),
+	AddCancelledOutcome(),
+	AddInvoiceGeneratedToSwapIn(),
 }
 
 type Migrator struct {
@@ -435,22 +797,35 @@ func (m *Migrator) Rollback() error {
 	return gormigrate.New(m.db, m.opts, migrations).RollbackLast()
 }
 
+// Version returns the ID of the last migration applied to the database, empty
+// when it isn't migrated yet.
+func (m *Migrator) Version() (string, error) {
+	if !m.db.Migrator().HasTable(m.opts.TableName) {
+		return "", nil
+	}
+
+	var applied []string
+	if err := m.db.Table(m.opts.TableName).Pluck(m.opts.IDColumnName, &applied).Error; err != nil {
+		return "", err
+	}
+
+	version := ""
+	for _, migration := range migrations {
+		if slices.Contains(applied, migration.ID) {
+			version = migration.ID
+		}
+	}
+
+	return version, nil
+}
+
 // Reset will only rollback the DB to its initial state, this is no tables.
 func (m *Migrator) Reset() error {
 	// We will only rollback if the `migrations` table exists.
 	// So first we need to check for the table existence.
-	var exists bool
-	tx := m.db.Raw(`SELECT EXISTS (
-		SELECT FROM information_schema.tables
-		WHERE table_name = $1
-	)`, m.opts.TableName).Scan(&exists)
-	if err := tx.Error; err != nil {
-		return err
-	}
-
 	// If the table `migrations` does not exist, it means that migrations have
 	// not been initialized so no Rollback needed.
-	if !exists {
+	if !m.db.Migrator().HasTable(m.opts.TableName) {
 		return nil
 	}
 
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+type SwapDirection string
+
+const (
+	SwapDirectionIn  SwapDirection = "IN"
+	SwapDirectionOut SwapDirection = "OUT"
+)
+
+func (d SwapDirection) String() string {
+	return string(d)
+}
+
+func (d *SwapDirection) Scan(value interface{}) error {
+	str, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("failed to scan SwapDirection: expected string, got %T", value)
+	}
+	*d = SwapDirection(str)
+
+	return nil
+}
+
+func (d SwapDirection) Value() (driver.Value, error) {
+	return string(d), nil
+}
+
+func CreateSwapDirectionEnumSQL() string {
+	return `CREATE TYPE swap_direction AS ENUM ('IN', 'OUT');`
+}
+
+func DropSwapDirectionEnumSQL() string {
+	return `DROP TYPE swap_direction;`
+}
@@ -12,6 +12,8 @@ const (
 	OutcomeSuccess  SwapOutcome = "SUCCESS"
 	OutcomeRefunded SwapOutcome = "REFUNDED"
 	OutcomeExpired  SwapOutcome = "EXPIRED"
+	// OutcomeCancelled is a swap the user cancelled before it was funded
+	OutcomeCancelled SwapOutcome = "CANCELLED"
 )
 
 func (o SwapOutcome) String() string {
@@ -52,6 +54,10 @@ func CreateSwapOutcomeEnumSQL() string {
 	`
 }
 
+func AddCancelledSwapOutcomeSQL() string {
+	return `ALTER TYPE "public"."swap_outcome" ADD VALUE IF NOT EXISTS 'CANCELLED';`
+}
+
 func DropSwapOutcomeEnumSQL() string {
 	return `DROP TYPE IF EXISTS "public"."swap_outcome";`
 }
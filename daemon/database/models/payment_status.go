@@ -0,0 +1,24 @@
+package models
+
+// PaymentStatus is the status of the payment of the invoice of a swap out
+type PaymentStatus string
+
+const (
+	// PaymentPending is a payment that hasn't been sent to the node yet
+	PaymentPending PaymentStatus = "PENDING"
+	// PaymentInFlight is a payment the node may be routing
+	PaymentInFlight PaymentStatus = "IN_FLIGHT"
+	// PaymentSucceeded is a paid invoice
+	PaymentSucceeded PaymentStatus = "SUCCEEDED"
+	// PaymentFailed is a payment that won't be retried because its invoice
+	// expired or the swap is over
+	PaymentFailed PaymentStatus = "FAILED"
+	// PaymentUnknown is the payment of a swap out created before payments
+	// were tracked, which may have never been sent. It's checked with the
+	// node once and never sent if the node doesn't know about it.
+	PaymentUnknown PaymentStatus = "UNKNOWN"
+)
+
+func (s PaymentStatus) String() string {
+	return string(s)
+}
@@ -0,0 +1,33 @@
+package models
+
+// NewSwapInEvent creates an event for a swap in moving from the given status
+// (nil when it was just created) to its current one.
+func NewSwapInEvent(swap *SwapIn, from *SwapStatus) *SwapEvent {
+	return &SwapEvent{
+		SwapID:         swap.SwapID,
+		Direction:      SwapDirectionIn,
+		FromStatus:     from,
+		ToStatus:       swap.Status,
+		Outcome:        swap.Outcome,
+		LockTxID:       swap.LockTxID,
+		RefundTxID:     swap.RefundTxID,
+		ServiceFeeSats: swap.ServiceFeeSats,
+		OnchainFeeSats: swap.OnchainFeeSats,
+	}
+}
+
+// NewSwapOutEvent creates an event for a swap out moving from the given status
+// (nil when it was just created) to its current one.
+func NewSwapOutEvent(swap *SwapOut, from *SwapStatus) *SwapEvent {
+	return &SwapEvent{
+		SwapID:          swap.SwapID,
+		Direction:       SwapDirectionOut,
+		FromStatus:      from,
+		ToStatus:        swap.Status,
+		Outcome:         swap.Outcome,
+		ClaimTxID:       swap.TxID,
+		ServiceFeeSats:  swap.ServiceFeeSats,
+		OnchainFeeSats:  swap.OnchainFeeSats,
+		OffchainFeeSats: swap.OffchainFeeSats,
+	}
+}
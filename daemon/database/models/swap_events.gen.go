@@ -0,0 +1,35 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package models
+
+import (
+	"time"
+)
+
+const TableNameSwapEvent = "swap_events"
+
+// SwapEvent mapped from table <swap_events>
+type SwapEvent struct {
+	ID              int64         `gorm:"column:id;type:bigint;primaryKey;autoIncrement:true;<-:create" json:"id"`
+	SwapID          string        `gorm:"column:swap_id;type:text;not null" json:"swap_id"`
+	Direction       SwapDirection `gorm:"column:direction;type:swap_direction;not null" json:"direction"`
+	FromStatus      *SwapStatus   `gorm:"column:from_status;type:swap_status" json:"from_status"`
+	ToStatus        SwapStatus    `gorm:"column:to_status;type:swap_status;not null" json:"to_status"`
+	Outcome         *SwapOutcome  `gorm:"column:outcome;type:swap_outcome" json:"outcome"`
+	LockTxID        string        `gorm:"column:lock_tx_id;type:text" json:"lock_tx_id"`
+	ClaimTxID       string        `gorm:"column:claim_tx_id;type:text" json:"claim_tx_id"`
+	RefundTxID      string        `gorm:"column:refund_tx_id;type:text" json:"refund_tx_id"`
+	ServiceFeeSats  int64         `gorm:"column:service_fee_sats;type:bigint" json:"service_fee_sats"`
+	OnchainFeeSats  int64         `gorm:"column:onchain_fee_sats;type:bigint" json:"onchain_fee_sats"`
+	OffchainFeeSats int64         `gorm:"column:offchain_fee_sats;type:bigint" json:"offchain_fee_sats"`
+	Message         string        `gorm:"column:message;type:text" json:"message"`
+	Error           string        `gorm:"column:error;type:text" json:"error"`
+	CreatedAt       time.Time     `gorm:"column:created_at;type:timestamp with time zone;<-:create" json:"created_at"`
+}
+
+// TableName SwapEvent's table name
+func (*SwapEvent) TableName() string {
+	return TableNameSwapEvent
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/shopspring/decimal"
 )
 
 const TableNameSwapIn = "swap_ins"
@@ -35,6 +36,12 @@ type SwapIn struct {
 	RefundRequestedAt  time.Time         `gorm:"column:refund_requested_at;type:timestamp with time zone" json:"refund_requested_at"`
 	LockTxID           string            `gorm:"column:lock_tx_id;type:text" json:"lock_tx_id"`
 	RefundAmount       int64             `gorm:"column:refund_amount;type:bigint" json:"refund_amount"`
+	NotFoundSince      *time.Time        `gorm:"column:not_found_since;type:timestamp with time zone" json:"not_found_since"`
+	FiatCurrency       string            `gorm:"column:fiat_currency;type:text" json:"fiat_currency"`
+	FiatRate           *decimal.Decimal  `gorm:"column:fiat_rate;type:numeric" json:"fiat_rate"`
+	ClientRequestID    *string           `gorm:"column:client_request_id;type:text" json:"client_request_id"`
+	Label              string            `gorm:"column:label;type:text" json:"label"`
+	InvoiceGenerated   bool              `gorm:"column:invoice_generated;type:boolean;not null" json:"invoice_generated"`
 }
 
 // TableName SwapIn's table name
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/shopspring/decimal"
 )
 
 const TableNameSwapOut = "swap_outs"
@@ -36,6 +37,14 @@ type SwapOut struct {
 	RefundPublicKey    string            `gorm:"column:refund_public_key;type:text" json:"refund_public_key"`
 	CreatedAt          time.Time         `gorm:"column:created_at;type:timestamp with time zone;<-:create" json:"created_at"`
 	UpdatedAt          time.Time         `gorm:"column:updated_at;type:timestamp with time zone;<-:update" json:"updated_at"`
+	NotFoundSince      *time.Time        `gorm:"column:not_found_since;type:timestamp with time zone" json:"not_found_since"`
+	FiatCurrency       string            `gorm:"column:fiat_currency;type:text" json:"fiat_currency"`
+	FiatRate           *decimal.Decimal  `gorm:"column:fiat_rate;type:numeric" json:"fiat_rate"`
+	ClientRequestID    *string           `gorm:"column:client_request_id;type:text" json:"client_request_id"`
+	Label              string            `gorm:"column:label;type:text" json:"label"`
+	PaymentStatus      PaymentStatus     `gorm:"column:payment_status;type:text;not null" json:"payment_status"`
+	PaymentAttempts    int64             `gorm:"column:payment_attempts;type:bigint;not null" json:"payment_attempts"`
+	PaymentError       string            `gorm:"column:payment_error;type:text" json:"payment_error"`
 }
 
 // TableName SwapOut's table name
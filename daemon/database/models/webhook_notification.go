@@ -0,0 +1,14 @@
+package models
+
+// NotificationStatus is the delivery status of a webhook notification
+type NotificationStatus string
+
+const (
+	NotificationPending   NotificationStatus = "PENDING"
+	NotificationDelivered NotificationStatus = "DELIVERED"
+	NotificationFailed    NotificationStatus = "FAILED"
+)
+
+func (s NotificationStatus) String() string {
+	return string(s)
+}
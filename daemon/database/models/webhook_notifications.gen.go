@@ -0,0 +1,32 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package models
+
+import (
+	"time"
+)
+
+const TableNameWebhookNotification = "webhook_notifications"
+
+// WebhookNotification mapped from table <webhook_notifications>
+type WebhookNotification struct {
+	ID            int64              `gorm:"column:id;type:bigint;primaryKey;autoIncrement:true;<-:create" json:"id"`
+	URL           string             `gorm:"column:url;type:text;not null" json:"url"`
+	EventType     string             `gorm:"column:event_type;type:text;not null" json:"event_type"`
+	SwapID        string             `gorm:"column:swap_id;type:text" json:"swap_id"`
+	Payload       string             `gorm:"column:payload;type:text;not null" json:"payload"`
+	Status        NotificationStatus `gorm:"column:status;type:text;not null" json:"status"`
+	Attempts      int32              `gorm:"column:attempts;type:integer;not null" json:"attempts"`
+	NextAttemptAt time.Time          `gorm:"column:next_attempt_at;type:timestamp with time zone;not null" json:"next_attempt_at"`
+	LastError     string             `gorm:"column:last_error;type:text" json:"last_error"`
+	DeliveredAt   *time.Time         `gorm:"column:delivered_at;type:timestamp with time zone" json:"delivered_at"`
+	CreatedAt     time.Time          `gorm:"column:created_at;type:timestamp with time zone;<-:create" json:"created_at"`
+	UpdatedAt     time.Time          `gorm:"column:updated_at;type:timestamp with time zone" json:"updated_at"`
+}
+
+// TableName WebhookNotification's table name
+func (*WebhookNotification) TableName() string {
+	return TableNameWebhookNotification
+}
@@ -0,0 +1,26 @@
+package database
+
+import (
+	"context"
+
+	"github.com/40acres/40swap/daemon/database/models"
+)
+
+type SwapEventRepository interface {
+	SaveSwapEvent(ctx context.Context, event *models.SwapEvent) error
+	GetSwapEvents(ctx context.Context, swapID string) ([]*models.SwapEvent, error)
+}
+
+func (d *Database) SaveSwapEvent(ctx context.Context, event *models.SwapEvent) error {
+	return d.query.WithContext(ctx).SwapEvent.Create(event)
+}
+
+// GetSwapEvents returns the events of a swap, oldest first
+func (d *Database) GetSwapEvents(ctx context.Context, swapID string) ([]*models.SwapEvent, error) {
+	event := d.query.SwapEvent
+
+	return event.WithContext(ctx).
+		Where(event.SwapID.Eq(swapID)).
+		Order(event.CreatedAt, event.ID).
+		Find()
+}
@@ -3,21 +3,44 @@ package database
 
 import (
 	"context"
+	"time"
 
 	"github.com/40acres/40swap/daemon/database/models"
 )
 
 type SwapInRepository interface {
 	SaveSwapIn(ctx context.Context, swapIn *models.SwapIn) error
+	SaveSwapInIf(ctx context.Context, swapIn *models.SwapIn, status models.SwapStatus) error
 	GetPendingSwapIns(ctx context.Context) ([]*models.SwapIn, error)
+	GetCancelledSwapIns(ctx context.Context, height int64) ([]*models.SwapIn, error)
 	GetSwapIn(ctx context.Context, swapID string) (*models.SwapIn, error)
+	GetSwapInByClientRequestID(ctx context.Context, clientRequestID string) (*models.SwapIn, error)
 	GetSwapInByClaimAddress(ctx context.Context, address string) (*models.SwapIn, error)
+	GetFinishedSwapIns(ctx context.Context, from, to time.Time) ([]*models.SwapIn, error)
 }
 
 func (d *Database) SaveSwapIn(ctx context.Context, swapIn *models.SwapIn) error {
 	return d.query.WithContext(ctx).SwapIn.Save(swapIn)
 }
 
+// SaveSwapInIf saves the swap in only if its status in the database is still
+// status, so the changes made by others since it was read aren't lost. It
+// returns ErrSwapChanged otherwise.
+func (d *Database) SaveSwapInIf(ctx context.Context, swapIn *models.SwapIn, status models.SwapStatus) error {
+	result := d.orm.WithContext(ctx).Model(swapIn).
+		Where("swap_id = ?", swapIn.SwapID).
+		Where("status = ?", status).
+		Select("*").Updates(swapIn)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrSwapChanged
+	}
+
+	return nil
+}
+
 func (d *Database) GetPendingSwapIns(ctx context.Context) ([]*models.SwapIn, error) {
 	var swapIns []*models.SwapIn
 	swap := d.query.SwapIn
@@ -33,14 +56,48 @@ func (d *Database) GetPendingSwapIns(ctx context.Context) ([]*models.SwapIn, err
 	return swapIns, nil
 }
 
+// GetCancelledSwapIns returns the cancelled swap ins with a contract that
+// doesn't expire before height, which may still be funded
+func (d *Database) GetCancelledSwapIns(ctx context.Context, height int64) ([]*models.SwapIn, error) {
+	swap := d.query.SwapIn
+
+	return swap.WithContext(ctx).
+		Where(swap.Status.Eq(models.StatusDone)).
+		Where(swap.Outcome.Eq(models.OutcomeCancelled)).
+		Where(swap.ClaimAddress.Neq("")).
+		Where(swap.TimeoutBlockHeight.Gte(height)).
+		Find()
+}
+
 func (d *Database) GetSwapIn(ctx context.Context, swapID string) (*models.SwapIn, error) {
 	return d.query.WithContext(ctx).SwapIn.
 		Where(d.query.SwapIn.SwapID.Eq(swapID)).
 		First()
 }
 
+// GetSwapInByClientRequestID returns the swap created by the request with the
+// client request ID
+func (d *Database) GetSwapInByClientRequestID(ctx context.Context, clientRequestID string) (*models.SwapIn, error) {
+	return d.query.WithContext(ctx).SwapIn.
+		Where(d.query.SwapIn.ClientRequestID.Eq(clientRequestID)).
+		First()
+}
+
 func (d *Database) GetSwapInByClaimAddress(ctx context.Context, address string) (*models.SwapIn, error) {
 	return d.query.WithContext(ctx).SwapIn.
 		Where(d.query.SwapIn.ClaimAddress.Eq(address)).
 		First()
 }
+
+// GetFinishedSwapIns returns the swap ins with an outcome created in
+// [from, to), oldest first
+func (d *Database) GetFinishedSwapIns(ctx context.Context, from, to time.Time) ([]*models.SwapIn, error) {
+	swap := d.query.SwapIn
+
+	return swap.WithContext(ctx).
+		Where(swap.Outcome.IsNotNull()).
+		Where(swap.CreatedAt.Gte(from)).
+		Where(swap.CreatedAt.Lt(to)).
+		Order(swap.CreatedAt, swap.ID).
+		Find()
+}
@@ -3,22 +3,54 @@ package database
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/40acres/40swap/daemon/database/models"
 )
 
+// ErrSwapChanged is returned by the conditional saves when the swap changed
+// since it was read
+var ErrSwapChanged = errors.New("swap changed since it was read")
+
 type SwapOutRepository interface {
 	SaveSwapOut(ctx context.Context, swapOut *models.SwapOut) error
+	SaveSwapOutIf(ctx context.Context, swapOut *models.SwapOut, status models.SwapStatus, paymentStatuses ...models.PaymentStatus) error
 	GetPendingSwapOuts(ctx context.Context) ([]*models.SwapOut, error)
 	GetSwapOut(ctx context.Context, swapID string) (*models.SwapOut, error)
+	GetSwapOutByClientRequestID(ctx context.Context, clientRequestID string) (*models.SwapOut, error)
 	GetPendingAutoSwapOuts(ctx context.Context) ([]*models.SwapOut, error)
 	UpdateAutoSwap(ctx context.Context, swapID string, isAutoSwap bool) error
+	GetFinishedSwapOuts(ctx context.Context, from, to time.Time) ([]*models.SwapOut, error)
 }
 
 func (d *Database) SaveSwapOut(ctx context.Context, swapOut *models.SwapOut) error {
 	return d.query.WithContext(ctx).SwapOut.Save(swapOut)
 }
 
+// SaveSwapOutIf saves the swap out only if its status in the database is still
+// status, and its payment status one of paymentStatuses when given, so the
+// changes made by others since it was read aren't lost. It returns
+// ErrSwapChanged otherwise.
+func (d *Database) SaveSwapOutIf(ctx context.Context, swapOut *models.SwapOut, status models.SwapStatus, paymentStatuses ...models.PaymentStatus) error {
+	query := d.orm.WithContext(ctx).Model(swapOut).
+		Where("swap_id = ?", swapOut.SwapID).
+		Where("status = ?", status)
+	if len(paymentStatuses) > 0 {
+		query = query.Where("payment_status IN ?", paymentStatuses)
+	}
+
+	result := query.Select("*").Updates(swapOut)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrSwapChanged
+	}
+
+	return nil
+}
+
 func (d *Database) GetPendingSwapOuts(ctx context.Context) ([]*models.SwapOut, error) {
 	var swapOuts []*models.SwapOut
 	swap := d.query.SwapOut
@@ -40,6 +72,14 @@ func (d *Database) GetSwapOut(ctx context.Context, swapID string) (*models.SwapO
 		First()
 }
 
+// GetSwapOutByClientRequestID returns the swap created by the request with the
+// client request ID
+func (d *Database) GetSwapOutByClientRequestID(ctx context.Context, clientRequestID string) (*models.SwapOut, error) {
+	return d.query.WithContext(ctx).SwapOut.
+		Where(d.query.SwapOut.ClientRequestID.Eq(clientRequestID)).
+		First()
+}
+
 func (d *Database) GetPendingAutoSwapOuts(ctx context.Context) ([]*models.SwapOut, error) {
 	var swapOuts []*models.SwapOut
 	swap := d.query.SwapOut
@@ -65,3 +105,16 @@ func (d *Database) UpdateAutoSwap(ctx context.Context, swapID string, isAutoSwap
 
 	return err
 }
+
+// GetFinishedSwapOuts returns the swap outs with an outcome created in
+// [from, to), oldest first
+func (d *Database) GetFinishedSwapOuts(ctx context.Context, from, to time.Time) ([]*models.SwapOut, error) {
+	swap := d.query.SwapOut
+
+	return swap.WithContext(ctx).
+		Where(swap.Outcome.IsNotNull()).
+		Where(swap.CreatedAt.Gte(from)).
+		Where(swap.CreatedAt.Lt(to)).
+		Order(swap.CreatedAt, swap.ID).
+		Find()
+}
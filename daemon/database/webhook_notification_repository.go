@@ -0,0 +1,35 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/40acres/40swap/daemon/database/models"
+)
+
+type WebhookNotificationRepository interface {
+	SaveWebhookNotifications(ctx context.Context, notifications []*models.WebhookNotification) error
+	GetDueWebhookNotifications(ctx context.Context, now time.Time, limit int) ([]*models.WebhookNotification, error)
+	UpdateWebhookNotification(ctx context.Context, notification *models.WebhookNotification) error
+}
+
+func (d *Database) SaveWebhookNotifications(ctx context.Context, notifications []*models.WebhookNotification) error {
+	return d.query.WithContext(ctx).WebhookNotification.Create(notifications...)
+}
+
+// GetDueWebhookNotifications returns the pending notifications whose next
+// delivery attempt is due, oldest first
+func (d *Database) GetDueWebhookNotifications(ctx context.Context, now time.Time, limit int) ([]*models.WebhookNotification, error) {
+	notification := d.query.WebhookNotification
+
+	return notification.WithContext(ctx).
+		Where(notification.Status.Eq(models.NotificationPending.String())).
+		Where(notification.NextAttemptAt.Lte(now)).
+		Order(notification.NextAttemptAt, notification.ID).
+		Limit(limit).
+		Find()
+}
+
+func (d *Database) UpdateWebhookNotification(ctx context.Context, notification *models.WebhookNotification) error {
+	return d.query.WithContext(ctx).WebhookNotification.Save(notification)
+}
@@ -13,6 +13,14 @@ const DefaultCltvExpiry uint64 = 144
 
 var ErrInvoiceCanceled = fmt.Errorf("invoice canceled")
 
+// ErrPaymentFailed is returned when the node gave up paying an invoice, it can
+// be paid again
+var ErrPaymentFailed = fmt.Errorf("payment failed")
+
+// ErrPaymentNotInitiated is returned when tracking a payment the node never
+// started
+var ErrPaymentNotInitiated = fmt.Errorf("payment not initiated")
+
 type Preimage = string
 type NetworkFeeSats = int64
 
@@ -26,6 +34,7 @@ type Client interface {
 	PayInvoice(ctx context.Context, paymentRequest string, feeLimitRatio float64) error
 	MonitorPaymentRequest(ctx context.Context, paymentHash string) (Preimage, NetworkFeeSats, error)
 	MonitorPaymentReception(ctx context.Context, rhash []byte) (Preimage, error)
+	CancelInvoice(ctx context.Context, rhash []byte) error
 	GenerateInvoice(ctx context.Context, amountSats decimal.Decimal, expiry time.Duration, memo string) (paymentRequest string, rhash []byte, e error)
 	GenerateAddress(ctx context.Context) (string, error)
 	GetChannelLocalBalance(ctx context.Context) (decimal.Decimal, error)
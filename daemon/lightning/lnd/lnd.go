@@ -289,6 +289,12 @@ func (c *Client) MonitorPaymentRequest(ctx context.Context, paymentHash string)
 
 		invoice, err := stream.Recv()
 		if err != nil {
+			// lnd has no status code for it, only the message of
+			// ErrPaymentNotInitiated
+			if strings.Contains(err.Error(), "payment isn't initiated") {
+				return "", 0, fmt.Errorf("%w: %w", lightning.ErrPaymentNotInitiated, err)
+			}
+
 			return "", 0, err
 		}
 
@@ -297,7 +303,7 @@ func (c *Client) MonitorPaymentRequest(ctx context.Context, paymentHash string)
 		case lnrpc.Payment_SUCCEEDED:
 			return invoice.PaymentPreimage, invoice.FeeSat, nil
 		case lnrpc.Payment_FAILED:
-			err := fmt.Errorf("payment failed: %w", errors.New(invoice.FailureReason.String()))
+			err := fmt.Errorf("%w: %w", lightning.ErrPaymentFailed, errors.New(invoice.FailureReason.String()))
 
 			return "", 0, err
 		}
@@ -343,6 +349,16 @@ func (c *Client) MonitorPaymentReception(ctx context.Context, rhash []byte) (lig
 	}
 }
 
+// CancelInvoice cancels an open invoice of the node, so it can't be paid
+func (c *Client) CancelInvoice(ctx context.Context, rhash []byte) error {
+	_, err := c.invoicesClient.CancelInvoice(ctx, &invoicesrpc.CancelInvoiceMsg{PaymentHash: rhash})
+	if err != nil {
+		return fmt.Errorf("could not cancel invoice: %w", err)
+	}
+
+	return nil
+}
+
 func (c *Client) GenerateInvoice(ctx context.Context, amountSats decimal.Decimal, expiry time.Duration, memo string) (paymentRequest string, rhash []byte, e error) {
 	invoiceReq := &lnrpc.Invoice{
 		Value:           amountSats.IntPart(),
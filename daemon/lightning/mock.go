@@ -43,6 +43,20 @@ func (m *MockClient) EXPECT() *MockClientMockRecorder {
 	return m.recorder
 }
 
+// CancelInvoice mocks base method.
+func (m *MockClient) CancelInvoice(ctx context.Context, rhash []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelInvoice", ctx, rhash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelInvoice indicates an expected call of CancelInvoice.
+func (mr *MockClientMockRecorder) CancelInvoice(ctx, rhash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelInvoice", reflect.TypeOf((*MockClient)(nil).CancelInvoice), ctx, rhash)
+}
+
 // GenerateAddress mocks base method.
 func (m *MockClient) GenerateAddress(ctx context.Context) (string, error) {
 	m.ctrl.T.Helper()
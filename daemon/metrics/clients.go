@@ -0,0 +1,201 @@
+//nolint:dupl
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/40acres/40swap/daemon/bitcoin"
+	"github.com/40acres/40swap/daemon/lightning"
+	"github.com/40acres/40swap/daemon/swaps"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	swapsBackend     = "swaps"
+	lightningBackend = "lightning"
+	bitcoinBackend   = "bitcoin"
+)
+
+// swapsClient records the latency of the calls to the swap server
+type swapsClient struct {
+	client swaps.ClientInterface
+}
+
+// NewSwapsClient wraps a swap server client to record its latencies
+func NewSwapsClient(client swaps.ClientInterface) swaps.ClientInterface {
+	return &swapsClient{client: client}
+}
+
+func (c *swapsClient) GetConfiguration(ctx context.Context) (res *swaps.ConfigurationResponse, err error) {
+	defer observe(swapsBackend, "GetConfiguration", time.Now(), &err)
+
+	return c.client.GetConfiguration(ctx)
+}
+
+func (c *swapsClient) CreateSwapOut(ctx context.Context, swapReq swaps.CreateSwapOutRequest) (res *swaps.SwapOutResponse, err error) {
+	defer observe(swapsBackend, "CreateSwapOut", time.Now(), &err)
+
+	return c.client.CreateSwapOut(ctx, swapReq)
+}
+
+func (c *swapsClient) GetSwapOut(ctx context.Context, swapId string) (res *swaps.SwapOutResponse, err error) {
+	defer observe(swapsBackend, "GetSwapOut", time.Now(), &err)
+
+	return c.client.GetSwapOut(ctx, swapId)
+}
+
+func (c *swapsClient) GetClaimPSBT(ctx context.Context, swapId, address string) (res *swaps.GetClaimPSBTResponse, err error) {
+	defer observe(swapsBackend, "GetClaimPSBT", time.Now(), &err)
+
+	return c.client.GetClaimPSBT(ctx, swapId, address)
+}
+
+func (c *swapsClient) PostClaim(ctx context.Context, swapId, tx string) (err error) {
+	defer observe(swapsBackend, "PostClaim", time.Now(), &err)
+
+	return c.client.PostClaim(ctx, swapId, tx)
+}
+
+func (c *swapsClient) CreateSwapIn(ctx context.Context, req *swaps.CreateSwapInRequest) (res *swaps.SwapInResponse, err error) {
+	defer observe(swapsBackend, "CreateSwapIn", time.Now(), &err)
+
+	return c.client.CreateSwapIn(ctx, req)
+}
+
+func (c *swapsClient) GetSwapIn(ctx context.Context, swapId string) (res *swaps.SwapInResponse, err error) {
+	defer observe(swapsBackend, "GetSwapIn", time.Now(), &err)
+
+	return c.client.GetSwapIn(ctx, swapId)
+}
+
+func (c *swapsClient) GetRefundPSBT(ctx context.Context, swapId, address string) (res *swaps.RefundPSBTResponse, err error) {
+	defer observe(swapsBackend, "GetRefundPSBT", time.Now(), &err)
+
+	return c.client.GetRefundPSBT(ctx, swapId, address)
+}
+
+func (c *swapsClient) PostRefund(ctx context.Context, swapId, tx string) (err error) {
+	defer observe(swapsBackend, "PostRefund", time.Now(), &err)
+
+	return c.client.PostRefund(ctx, swapId, tx)
+}
+
+// lightningClient records the latency of the calls to the lightning node
+type lightningClient struct {
+	client lightning.Client
+}
+
+// NewLightningClient wraps a lightning client to record its latencies
+func NewLightningClient(client lightning.Client) lightning.Client {
+	return &lightningClient{client: client}
+}
+
+func (c *lightningClient) PayInvoice(ctx context.Context, paymentRequest string, feeLimitRatio float64) (err error) {
+	defer observe(lightningBackend, "PayInvoice", time.Now(), &err)
+
+	return c.client.PayInvoice(ctx, paymentRequest, feeLimitRatio)
+}
+
+func (c *lightningClient) MonitorPaymentRequest(ctx context.Context, paymentHash string) (preimage lightning.Preimage, fee lightning.NetworkFeeSats, err error) {
+	defer observe(lightningBackend, "MonitorPaymentRequest", time.Now(), &err)
+
+	return c.client.MonitorPaymentRequest(ctx, paymentHash)
+}
+
+func (c *lightningClient) MonitorPaymentReception(ctx context.Context, rhash []byte) (preimage lightning.Preimage, err error) {
+	defer observe(lightningBackend, "MonitorPaymentReception", time.Now(), &err)
+
+	return c.client.MonitorPaymentReception(ctx, rhash)
+}
+
+func (c *lightningClient) CancelInvoice(ctx context.Context, rhash []byte) (err error) {
+	defer observe(lightningBackend, "CancelInvoice", time.Now(), &err)
+
+	return c.client.CancelInvoice(ctx, rhash)
+}
+
+func (c *lightningClient) GenerateInvoice(ctx context.Context, amountSats decimal.Decimal, expiry time.Duration, memo string) (paymentRequest string, rhash []byte, err error) {
+	defer observe(lightningBackend, "GenerateInvoice", time.Now(), &err)
+
+	return c.client.GenerateInvoice(ctx, amountSats, expiry, memo)
+}
+
+func (c *lightningClient) GenerateAddress(ctx context.Context) (address string, err error) {
+	defer observe(lightningBackend, "GenerateAddress", time.Now(), &err)
+
+	return c.client.GenerateAddress(ctx)
+}
+
+func (c *lightningClient) GetChannelLocalBalance(ctx context.Context) (balance decimal.Decimal, err error) {
+	defer observe(lightningBackend, "GetChannelLocalBalance", time.Now(), &err)
+
+	return c.client.GetChannelLocalBalance(ctx)
+}
+
+func (c *lightningClient) GetInfo(ctx context.Context) (res *lnrpc.GetInfoResponse, err error) {
+	defer observe(lightningBackend, "GetInfo", time.Now(), &err)
+
+	return c.client.GetInfo(ctx)
+}
+
+// bitcoinClient records the latency of the calls to the bitcoin backend
+type bitcoinClient struct {
+	client bitcoin.Client
+}
+
+// NewBitcoinClient wraps a bitcoin client to record its latencies
+func NewBitcoinClient(client bitcoin.Client) bitcoin.Client {
+	return &bitcoinClient{client: client}
+}
+
+func (c *bitcoinClient) PostRefund(ctx context.Context, tx string) (err error) {
+	defer observe(bitcoinBackend, "PostRefund", time.Now(), &err)
+
+	return c.client.PostRefund(ctx, tx)
+}
+
+func (c *bitcoinClient) GetTxFromOutpoint(ctx context.Context, outpoint string) (tx *wire.MsgTx, err error) {
+	defer observe(bitcoinBackend, "GetTxFromOutpoint", time.Now(), &err)
+
+	return c.client.GetTxFromOutpoint(ctx, outpoint)
+}
+
+func (c *bitcoinClient) GetTxFromTxID(ctx context.Context, txID string) (tx *wire.MsgTx, err error) {
+	defer observe(bitcoinBackend, "GetTxFromTxID", time.Now(), &err)
+
+	return c.client.GetTxFromTxID(ctx, txID)
+}
+
+func (c *bitcoinClient) GetTxsFromAddress(ctx context.Context, address string) (txs []*wire.MsgTx, err error) {
+	defer observe(bitcoinBackend, "GetTxsFromAddress", time.Now(), &err)
+
+	return c.client.GetTxsFromAddress(ctx, address)
+}
+
+func (c *bitcoinClient) GetRecommendedFees(ctx context.Context, speed bitcoin.Speed) (fee int64, err error) {
+	defer observe(bitcoinBackend, "GetRecommendedFees", time.Now(), &err)
+
+	return c.client.GetRecommendedFees(ctx, speed)
+}
+
+func (c *bitcoinClient) GetFeeFromTxId(ctx context.Context, txId string) (fee int64, err error) {
+	defer observe(bitcoinBackend, "GetFeeFromTxId", time.Now(), &err)
+
+	return c.client.GetFeeFromTxId(ctx, txId)
+}
+
+func (c *bitcoinClient) GetBlockHeight(ctx context.Context) (height int64, err error) {
+	defer observe(bitcoinBackend, "GetBlockHeight", time.Now(), &err)
+
+	return c.client.GetBlockHeight(ctx)
+}
+
+// SubscribeBlocks only records the time taken to set up the subscription
+func (c *bitcoinClient) SubscribeBlocks(ctx context.Context) (blocks <-chan int64, err error) {
+	defer observe(bitcoinBackend, "SubscribeBlocks", time.Now(), &err)
+
+	return c.client.SubscribeBlocks(ctx)
+}
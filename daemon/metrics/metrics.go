@@ -0,0 +1,169 @@
+// Package metrics exposes the daemon metrics to Prometheus.
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/40acres/40swap/daemon/database/models"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	log "github.com/sirupsen/logrus"
+)
+
+const namespace = "fortyswapd"
+
+// Registry holds every metric exported by the daemon
+var Registry = prometheus.NewRegistry()
+
+var (
+	swapsCreated = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "swaps_created_total",
+		Help:      "Number of swaps created.",
+	}, []string{"direction"})
+
+	swapsCompleted = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "swaps_completed_total",
+		Help:      "Number of swaps that reached the DONE status, by outcome.",
+	}, []string{"direction", "outcome"})
+
+	pendingSwaps = promauto.With(Registry).NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "pending_swaps",
+		Help:      "Number of swaps being monitored, by status.",
+	}, []string{"direction", "status"})
+
+	feesPaid = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "fees_paid_sats_total",
+		Help:      "Fees paid in successful swaps, in satoshis.",
+	}, []string{"direction", "type"})
+
+	monitorDuration = promauto.With(Registry).NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "monitor_iteration_duration_seconds",
+		Help:      "Time taken to monitor a single swap once.",
+		Buckets:   prometheus.DefBuckets,
+	})
+
+	monitorErrors = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "monitor_errors_total",
+		Help:      "Number of errors found by the swap monitor.",
+	}, []string{"direction"})
+
+	autoSwapAttempts = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "auto_swap_attempts_total",
+		Help:      "Number of swap outs attempted by the auto swap service.",
+	}, []string{"result"})
+
+	backendDuration = promauto.With(Registry).NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "backend_request_duration_seconds",
+		Help:      "Latency of the calls to the swap server, the lightning node and the bitcoin backend.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"backend", "method", "result"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// ObserveSwapEvent updates the swap counters from an event of the swap history
+func ObserveSwapEvent(event *models.SwapEvent) {
+	direction := event.Direction.String()
+
+	switch {
+	case event.FromStatus == nil:
+		swapsCreated.WithLabelValues(direction).Inc()
+	case event.ToStatus == models.StatusDone && *event.FromStatus != models.StatusDone:
+		outcome := "UNKNOWN"
+		if event.Outcome != nil {
+			outcome = event.Outcome.String()
+		}
+		swapsCompleted.WithLabelValues(direction, outcome).Inc()
+
+		if outcome == models.OutcomeSuccess.String() {
+			feesPaid.WithLabelValues(direction, "service").Add(float64(event.ServiceFeeSats))
+			feesPaid.WithLabelValues(direction, "onchain").Add(float64(event.OnchainFeeSats))
+			feesPaid.WithLabelValues(direction, "offchain").Add(float64(event.OffchainFeeSats))
+		}
+	}
+}
+
+// SetPendingSwaps replaces the number of pending swaps of a direction with
+// the given statuses
+func SetPendingSwaps(direction models.SwapDirection, statuses []models.SwapStatus) {
+	pendingSwaps.DeletePartialMatch(prometheus.Labels{"direction": direction.String()})
+	for _, status := range statuses {
+		pendingSwaps.WithLabelValues(direction.String(), status.String()).Inc()
+	}
+}
+
+// ObserveMonitorIteration records the duration of a monitor iteration that
+// started at the given time
+func ObserveMonitorIteration(start time.Time) {
+	monitorDuration.Observe(time.Since(start).Seconds())
+}
+
+// MonitorError counts an error found while monitoring swaps
+func MonitorError(direction models.SwapDirection) {
+	monitorErrors.WithLabelValues(direction.String()).Inc()
+}
+
+// AutoSwapAttempt counts a swap out attempted by the auto swap service
+func AutoSwapAttempt(err error) {
+	autoSwapAttempts.WithLabelValues(result(err)).Inc()
+}
+
+// observe records the latency of a backend call that started at the given
+// time. It takes a pointer to the error so it can be deferred.
+func observe(backend, method string, start time.Time, err *error) {
+	backendDuration.WithLabelValues(backend, method, result(*err)).Observe(time.Since(start).Seconds())
+}
+
+func result(err error) string {
+	if err != nil {
+		return "error"
+	}
+
+	return "success"
+}
+
+// ListenAndServe exposes the metrics over HTTP on the given host:port address
+// until the context is cancelled
+func ListenAndServe(ctx context.Context, addr string) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry}))
+
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		if err := server.Shutdown(context.Background()); err != nil {
+			log.Errorf("failed to shut down metrics server: %v", err)
+		}
+	}()
+
+	log.Infof("Serving metrics on %s/metrics", addr)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve metrics: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/40acres/40swap/daemon/bitcoin"
+	"github.com/40acres/40swap/daemon/database/models"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestObserveSwapEvent(t *testing.T) {
+	created := models.StatusCreated
+	pending := models.StatusContractFundedUnconfirmed
+	done := models.StatusDone
+	success := models.OutcomeSuccess
+	failed := models.OutcomeFailed
+
+	ObserveSwapEvent(&models.SwapEvent{Direction: models.SwapDirectionOut, ToStatus: created})
+	ObserveSwapEvent(&models.SwapEvent{Direction: models.SwapDirectionOut, FromStatus: &created, ToStatus: pending})
+	ObserveSwapEvent(&models.SwapEvent{
+		Direction:       models.SwapDirectionOut,
+		FromStatus:      &pending,
+		ToStatus:        done,
+		Outcome:         &success,
+		ServiceFeeSats:  100,
+		OnchainFeeSats:  20,
+		OffchainFeeSats: 3,
+	})
+	// Events of swaps that were already done don't count again
+	ObserveSwapEvent(&models.SwapEvent{Direction: models.SwapDirectionOut, FromStatus: &done, ToStatus: done, Outcome: &success, ServiceFeeSats: 100})
+	ObserveSwapEvent(&models.SwapEvent{Direction: models.SwapDirectionIn, FromStatus: &pending, ToStatus: done, Outcome: &failed, ServiceFeeSats: 100})
+
+	require.InDelta(t, 1, testutil.ToFloat64(swapsCreated.WithLabelValues("OUT")), 0)
+	require.InDelta(t, 1, testutil.ToFloat64(swapsCompleted.WithLabelValues("OUT", "SUCCESS")), 0)
+	require.InDelta(t, 1, testutil.ToFloat64(swapsCompleted.WithLabelValues("IN", "FAILED")), 0)
+	require.InDelta(t, 100, testutil.ToFloat64(feesPaid.WithLabelValues("OUT", "service")), 0)
+	require.InDelta(t, 20, testutil.ToFloat64(feesPaid.WithLabelValues("OUT", "onchain")), 0)
+	require.InDelta(t, 3, testutil.ToFloat64(feesPaid.WithLabelValues("OUT", "offchain")), 0)
+	require.InDelta(t, 0, testutil.ToFloat64(feesPaid.WithLabelValues("IN", "service")), 0)
+}
+
+func TestSetPendingSwaps(t *testing.T) {
+	SetPendingSwaps(models.SwapDirectionIn, []models.SwapStatus{
+		models.StatusCreated,
+		models.StatusCreated,
+		models.StatusInvoicePaid,
+	})
+	require.InDelta(t, 2, testutil.ToFloat64(pendingSwaps.WithLabelValues("IN", "CREATED")), 0)
+	require.InDelta(t, 1, testutil.ToFloat64(pendingSwaps.WithLabelValues("IN", "INVOICE_PAID")), 0)
+
+	// Statuses that are no longer pending are removed
+	SetPendingSwaps(models.SwapDirectionIn, []models.SwapStatus{models.StatusInvoicePaid})
+	require.Equal(t, 1, testutil.CollectAndCount(pendingSwaps))
+}
+
+func TestBitcoinClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	ctx := context.Background()
+	mock := bitcoin.NewMockClient(ctrl)
+	client := NewBitcoinClient(mock)
+
+	mock.EXPECT().GetBlockHeight(ctx).Return(int64(100), nil)
+	height, err := client.GetBlockHeight(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), height)
+
+	mock.EXPECT().GetBlockHeight(ctx).Return(int64(0), errors.New("backend down"))
+	_, err = client.GetBlockHeight(ctx)
+	require.Error(t, err)
+
+	require.Equal(t, 2, testutil.CollectAndCount(backendDuration))
+}
@@ -23,3 +23,22 @@ func NewFromBtc(amount decimal.Decimal) (Money, error) {
 func (m Money) ToBtc() decimal.Decimal {
 	return decimal.NewFromUint64(uint64(m)).Div(decimal.NewFromInt(1e8))
 }
+
+// ErrInvalidRate is returned when converting with a rate that isn't positive.
+var ErrInvalidRate = errors.New("rate must be positive")
+
+// NewFromFiat converts a fiat amount to satoshis at rate, the price of one
+// bitcoin in that currency. Fractions of a satoshi are dropped.
+func NewFromFiat(amount, rate decimal.Decimal) (Money, error) {
+	if !rate.IsPositive() {
+		return 0, ErrInvalidRate
+	}
+
+	return NewFromBtc(amount.Div(rate))
+}
+
+// ToFiat converts the amount to fiat at rate, the price of one bitcoin in that
+// currency, rounded to cents.
+func (m Money) ToFiat(rate decimal.Decimal) decimal.Decimal {
+	return m.ToBtc().Mul(rate).Round(2)
+}
@@ -1,6 +1,7 @@
 package money
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/shopspring/decimal"
@@ -68,3 +69,74 @@ func TestMoney_ToBtc(t *testing.T) {
 		})
 	}
 }
+
+func TestNewFromFiat(t *testing.T) {
+	tests := []struct {
+		name    string
+		amount  decimal.Decimal
+		rate    decimal.Decimal
+		want    Money
+		wantErr error
+	}{
+		{
+			name:   "NewFromFiat - Pass",
+			amount: decimal.NewFromInt(500),
+			rate:   decimal.NewFromInt(50000),
+			want:   1000000,
+		},
+		{
+			name:    "NewFromFiat - Fail Zero Rate",
+			amount:  decimal.NewFromInt(500),
+			rate:    decimal.Zero,
+			wantErr: ErrInvalidRate,
+		},
+		{
+			name:    "NewFromFiat - Fail Negative Amount",
+			amount:  decimal.NewFromInt(-500),
+			rate:    decimal.NewFromInt(50000),
+			wantErr: ErrNegativeAmount,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewFromFiat(tt.amount, tt.rate)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewFromFiat() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+			if got != tt.want {
+				t.Errorf("NewFromFiat() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoney_ToFiat(t *testing.T) {
+	tests := []struct {
+		name string
+		m    Money
+		rate decimal.Decimal
+		want decimal.Decimal
+	}{
+		{
+			name: "To Fiat - Pass",
+			m:    150000,
+			rate: decimal.RequireFromString("65432.10"),
+			want: decimal.RequireFromString("98.15"),
+		},
+		{
+			name: "To Fiat - Rounds To Cents",
+			m:    1,
+			rate: decimal.NewFromInt(65000),
+			want: decimal.Zero,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.m.ToFiat(tt.rate); got.Cmp(tt.want) != 0 {
+				t.Errorf("Money.ToFiat() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/40acres/40swap/daemon/notifier (interfaces: Repository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mock_repository.go -package=notifier . Repository
+//
+
+// Package notifier is a generated GoMock package.
+package notifier
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/40acres/40swap/daemon/database/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetDueWebhookNotifications mocks base method.
+func (m *MockRepository) GetDueWebhookNotifications(ctx context.Context, now time.Time, limit int) ([]*models.WebhookNotification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueWebhookNotifications", ctx, now, limit)
+	ret0, _ := ret[0].([]*models.WebhookNotification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueWebhookNotifications indicates an expected call of GetDueWebhookNotifications.
+func (mr *MockRepositoryMockRecorder) GetDueWebhookNotifications(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueWebhookNotifications", reflect.TypeOf((*MockRepository)(nil).GetDueWebhookNotifications), ctx, now, limit)
+}
+
+// SaveWebhookNotifications mocks base method.
+func (m *MockRepository) SaveWebhookNotifications(ctx context.Context, notifications []*models.WebhookNotification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveWebhookNotifications", ctx, notifications)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveWebhookNotifications indicates an expected call of SaveWebhookNotifications.
+func (mr *MockRepositoryMockRecorder) SaveWebhookNotifications(ctx, notifications any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveWebhookNotifications", reflect.TypeOf((*MockRepository)(nil).SaveWebhookNotifications), ctx, notifications)
+}
+
+// UpdateWebhookNotification mocks base method.
+func (m *MockRepository) UpdateWebhookNotification(ctx context.Context, notification *models.WebhookNotification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhookNotification", ctx, notification)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWebhookNotification indicates an expected call of UpdateWebhookNotification.
+func (mr *MockRepositoryMockRecorder) UpdateWebhookNotification(ctx, notification any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhookNotification", reflect.TypeOf((*MockRepository)(nil).UpdateWebhookNotification), ctx, notification)
+}
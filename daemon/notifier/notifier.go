@@ -0,0 +1,356 @@
+// Package notifier delivers swap lifecycle notifications to webhooks. Every
+// notification is first stored in an outbox table and then delivered in the
+// background, so they survive restarts and are retried when a webhook is down.
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/40acres/40swap/daemon/database"
+	"github.com/40acres/40swap/daemon/database/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// Event types sent to the webhooks
+const (
+	EventSwapCreated       = "swap.created"
+	EventSwapStatusChanged = "swap.status_changed"
+	EventSwapCompleted     = "swap.completed"
+	EventSwapFailed        = "swap.failed"
+	EventSwapRefunded      = "swap.refunded"
+	EventSwapExpired       = "swap.expired"
+	EventSwapCancelled     = "swap.cancelled"
+	EventSwapError         = "swap.error"
+	EventAutoSwapFailed    = "autoswap.failed"
+)
+
+// Headers sent along with every notification
+const (
+	SignatureHeader = "X-40swap-Signature"
+	// TimestampHeader is the unix time the notification was sent at, it's
+	// signed along with the body
+	TimestampHeader = "X-40swap-Timestamp"
+	EventHeader     = "X-40swap-Event"
+	DeliveryHeader  = "X-40swap-Delivery"
+)
+
+// SignatureTolerance is how old the timestamp of a notification can be, or how
+// far in the future, for Verify to accept it. It bounds the window in which a
+// captured notification can be replayed.
+const SignatureTolerance = 5 * time.Minute
+
+const (
+	DefaultMaxAttempts    = 10
+	DefaultInitialBackoff = 10 * time.Second
+	DefaultMaxBackoff     = time.Hour
+	DefaultPollInterval   = 5 * time.Second
+
+	deliveryBatchSize = 100
+	requestTimeout    = 10 * time.Second
+	maxErrorBodySize  = 512
+)
+
+//go:generate go tool mockgen -destination=mock_repository.go -package=notifier . Repository
+type Repository interface {
+	database.WebhookNotificationRepository
+}
+
+// Config holds the webhook settings
+type Config struct {
+	// URLs are the webhooks every notification is sent to
+	URLs []string
+	// Secret is the key used to sign the payloads with HMAC-SHA256, they are
+	// sent unsigned when empty
+	Secret string
+	// Events restricts the event types that are sent, all of them when empty
+	Events []string
+	// MaxAttempts is how many times a notification is tried before giving up
+	MaxAttempts int32
+	// InitialBackoff is the wait before the first retry, doubled on every
+	// following one up to MaxBackoff
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// Payload is the JSON body posted to the webhooks
+type Payload struct {
+	Type       string    `json:"type"`
+	SwapID     string    `json:"swapId,omitempty"`
+	Direction  string    `json:"direction,omitempty"`
+	FromStatus string    `json:"fromStatus,omitempty"`
+	Status     string    `json:"status,omitempty"`
+	Outcome    string    `json:"outcome,omitempty"`
+	Message    string    `json:"message,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+// Notifier queues notifications in the outbox and delivers them. A nil
+// Notifier is valid and discards every notification.
+type Notifier struct {
+	repository Repository
+	client     *http.Client
+	config     Config
+	now        func() time.Time
+}
+
+// New creates a notifier, filling the unset retry settings with defaults
+func New(repository Repository, config Config) *Notifier {
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = DefaultMaxAttempts
+	}
+	if config.InitialBackoff <= 0 {
+		config.InitialBackoff = DefaultInitialBackoff
+	}
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = DefaultMaxBackoff
+	}
+
+	return &Notifier{
+		repository: repository,
+		client:     &http.Client{Timeout: requestTimeout},
+		config:     config,
+		now:        time.Now,
+	}
+}
+
+// NotifySwapEvent queues a notification for an event of the swap history.
+// Failing to queue it is logged but never interrupts the caller.
+func (n *Notifier) NotifySwapEvent(ctx context.Context, event *models.SwapEvent) {
+	if n == nil {
+		return
+	}
+
+	payload := Payload{
+		Type:      swapEventType(event),
+		SwapID:    event.SwapID,
+		Direction: event.Direction.String(),
+		Status:    event.ToStatus.String(),
+		Message:   event.Message,
+		Error:     event.Error,
+		Timestamp: n.now(),
+	}
+	if event.FromStatus != nil {
+		payload.FromStatus = event.FromStatus.String()
+	}
+	if event.Outcome != nil {
+		payload.Outcome = event.Outcome.String()
+	}
+
+	n.enqueue(ctx, payload)
+}
+
+// NotifyAutoSwapFailure queues a notification for a failed auto swap check
+func (n *Notifier) NotifyAutoSwapFailure(ctx context.Context, err error) {
+	if n == nil {
+		return
+	}
+
+	n.enqueue(ctx, Payload{
+		Type:      EventAutoSwapFailed,
+		Error:     err.Error(),
+		Timestamp: n.now(),
+	})
+}
+
+func (n *Notifier) enqueue(ctx context.Context, payload Payload) {
+	if len(n.config.Events) > 0 && !slices.Contains(n.config.Events, payload.Type) {
+		return
+	}
+
+	logger := log.WithField("event", payload.Type)
+	if payload.SwapID != "" {
+		logger = logger.WithField("id", payload.SwapID)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		logger.Errorf("failed to encode webhook notification: %v", err)
+
+		return
+	}
+
+	notifications := make([]*models.WebhookNotification, 0, len(n.config.URLs))
+	for _, url := range n.config.URLs {
+		notifications = append(notifications, &models.WebhookNotification{
+			URL:           url,
+			EventType:     payload.Type,
+			SwapID:        payload.SwapID,
+			Payload:       string(body),
+			Status:        models.NotificationPending,
+			NextAttemptAt: payload.Timestamp,
+		})
+	}
+	if len(notifications) == 0 {
+		return
+	}
+
+	if err := n.repository.SaveWebhookNotifications(ctx, notifications); err != nil {
+		logger.Errorf("failed to queue webhook notification: %v", err)
+	}
+}
+
+// Run delivers the queued notifications every interval until the context is
+// cancelled
+func (n *Notifier) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := n.Deliver(ctx); err != nil {
+			log.Errorf("failed to deliver webhook notifications: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Deliver sends the notifications that are due, rescheduling the ones that
+// fail with an exponential backoff
+func (n *Notifier) Deliver(ctx context.Context) error {
+	notifications, err := n.repository.GetDueWebhookNotifications(ctx, n.now(), deliveryBatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to get due notifications: %w", err)
+	}
+
+	for _, notification := range notifications {
+		logger := log.WithFields(log.Fields{"event": notification.EventType, "url": notification.URL})
+
+		notification.Attempts++
+		err := n.send(ctx, notification)
+		switch {
+		case err == nil:
+			now := n.now()
+			notification.Status = models.NotificationDelivered
+			notification.DeliveredAt = &now
+			notification.LastError = ""
+		case notification.Attempts >= n.config.MaxAttempts:
+			logger.Errorf("giving up on webhook notification after %d attempts: %v", notification.Attempts, err)
+			notification.Status = models.NotificationFailed
+			notification.LastError = err.Error()
+		default:
+			logger.Warnf("failed to deliver webhook notification, attempt %d/%d: %v", notification.Attempts, n.config.MaxAttempts, err)
+			notification.NextAttemptAt = n.now().Add(n.backoff(notification.Attempts))
+			notification.LastError = err.Error()
+		}
+
+		if err := n.repository.UpdateWebhookNotification(ctx, notification); err != nil {
+			return fmt.Errorf("failed to update notification %d: %w", notification.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (n *Notifier) send(ctx context.Context, notification *models.WebhookNotification) error {
+	body := []byte(notification.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, notification.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, notification.EventType)
+	req.Header.Set(DeliveryHeader, strconv.FormatInt(notification.ID, 10))
+	if n.config.Secret != "" {
+		// Signed on every attempt, so retries aren't rejected as stale
+		timestamp := strconv.FormatInt(n.now().Unix(), 10)
+		req.Header.Set(TimestampHeader, timestamp)
+		req.Header.Set(SignatureHeader, "sha256="+Sign(n.config.Secret, timestamp, body))
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, msg)
+	}
+
+	return nil
+}
+
+// backoff returns the wait before the next attempt of a notification that
+// already failed the given number of times
+func (n *Notifier) backoff(attempts int32) time.Duration {
+	wait := n.config.InitialBackoff
+	for i := int32(1); i < attempts; i++ {
+		wait *= 2
+		if wait >= n.config.MaxBackoff {
+			return n.config.MaxBackoff
+		}
+	}
+
+	return wait
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of "<timestamp>.<body>", which
+// receivers can use to check a notification comes from this daemon
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature and timestamp headers of a notification, which
+// is rejected when its timestamp is more than SignatureTolerance away from now
+func Verify(secret, signature, timestamp string, body []byte, now time.Time) error {
+	sent, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid timestamp %q: %w", timestamp, err)
+	}
+	if age := now.Sub(time.Unix(sent, 0)); age > SignatureTolerance || age < -SignatureTolerance {
+		return fmt.Errorf("timestamp %s is out of the %s tolerance", timestamp, SignatureTolerance)
+	}
+	if !hmac.Equal([]byte(signature), []byte("sha256="+Sign(secret, timestamp, body))) {
+		return fmt.Errorf("invalid signature")
+	}
+
+	return nil
+}
+
+func swapEventType(event *models.SwapEvent) string {
+	switch {
+	case event.Error != "":
+		return EventSwapError
+	case event.FromStatus == nil:
+		return EventSwapCreated
+	case event.ToStatus == models.StatusContractExpired:
+		return EventSwapExpired
+	case event.ToStatus == models.StatusDone && event.Outcome != nil:
+		switch *event.Outcome {
+		case models.OutcomeSuccess:
+			return EventSwapCompleted
+		case models.OutcomeRefunded:
+			return EventSwapRefunded
+		case models.OutcomeExpired:
+			return EventSwapExpired
+		case models.OutcomeFailed:
+			return EventSwapFailed
+		case models.OutcomeCancelled:
+			return EventSwapCancelled
+		}
+	}
+
+	return EventSwapStatusChanged
+}
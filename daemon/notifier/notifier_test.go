@@ -0,0 +1,222 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/40acres/40swap/daemon/database/models"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNotifier_NotifySwapEvent(t *testing.T) {
+	created := models.StatusCreated
+	funded := models.StatusContractFunded
+	refunded := models.OutcomeRefunded
+	success := models.OutcomeSuccess
+	cancelled := models.OutcomeCancelled
+	now := time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		events   []string
+		event    *models.SwapEvent
+		wantType string
+	}{
+		{
+			name:     "created",
+			event:    &models.SwapEvent{SwapID: "abc", Direction: models.SwapDirectionIn, ToStatus: created},
+			wantType: EventSwapCreated,
+		},
+		{
+			name:     "status changed",
+			event:    &models.SwapEvent{SwapID: "abc", Direction: models.SwapDirectionIn, FromStatus: &created, ToStatus: funded},
+			wantType: EventSwapStatusChanged,
+		},
+		{
+			name:     "refunded",
+			event:    &models.SwapEvent{SwapID: "abc", Direction: models.SwapDirectionIn, FromStatus: &funded, ToStatus: models.StatusDone, Outcome: &refunded},
+			wantType: EventSwapRefunded,
+		},
+		{
+			name:     "expired",
+			event:    &models.SwapEvent{SwapID: "abc", Direction: models.SwapDirectionOut, FromStatus: &funded, ToStatus: models.StatusContractExpired},
+			wantType: EventSwapExpired,
+		},
+		{
+			name:     "cancelled",
+			event:    &models.SwapEvent{SwapID: "abc", Direction: models.SwapDirectionIn, FromStatus: &created, ToStatus: models.StatusDone, Outcome: &cancelled},
+			wantType: EventSwapCancelled,
+		},
+		{
+			name:     "claim failed",
+			event:    &models.SwapEvent{SwapID: "abc", Direction: models.SwapDirectionOut, FromStatus: &funded, ToStatus: funded, Error: "failed to claim"},
+			wantType: EventSwapError,
+		},
+		{
+			name:   "filtered out",
+			events: []string{EventSwapRefunded},
+			event:  &models.SwapEvent{SwapID: "abc", Direction: models.SwapDirectionOut, FromStatus: &funded, ToStatus: models.StatusDone, Outcome: &success},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repository := NewMockRepository(ctrl)
+			notifier := New(repository, Config{URLs: []string{"http://a", "http://b"}, Events: tt.events})
+			notifier.now = func() time.Time { return now }
+
+			if tt.wantType != "" {
+				repository.EXPECT().SaveWebhookNotifications(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, notifications []*models.WebhookNotification) error {
+						require.Len(t, notifications, 2)
+						for i, url := range []string{"http://a", "http://b"} {
+							require.Equal(t, url, notifications[i].URL)
+							require.Equal(t, tt.wantType, notifications[i].EventType)
+							require.Equal(t, "abc", notifications[i].SwapID)
+							require.Equal(t, models.NotificationPending, notifications[i].Status)
+							require.Equal(t, now, notifications[i].NextAttemptAt)
+						}
+
+						var payload Payload
+						require.NoError(t, json.Unmarshal([]byte(notifications[0].Payload), &payload))
+						require.Equal(t, tt.wantType, payload.Type)
+						require.Equal(t, tt.event.ToStatus.String(), payload.Status)
+
+						return nil
+					})
+			}
+
+			notifier.NotifySwapEvent(context.Background(), tt.event)
+		})
+	}
+}
+
+func TestNotifier_NilIsNoop(t *testing.T) {
+	var notifier *Notifier
+	notifier.NotifySwapEvent(context.Background(), &models.SwapEvent{})
+	notifier.NotifyAutoSwapFailure(context.Background(), errors.New("boom"))
+}
+
+func TestNotifier_Deliver(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)
+	body := `{"type":"swap.refunded","swapId":"abc"}`
+
+	tests := []struct {
+		name       string
+		statusCode int
+		attempts   int32
+		check      func(t *testing.T, notification *models.WebhookNotification)
+	}{
+		{
+			name:       "delivered",
+			statusCode: http.StatusNoContent,
+			check: func(t *testing.T, notification *models.WebhookNotification) {
+				require.Equal(t, models.NotificationDelivered, notification.Status)
+				require.Equal(t, &now, notification.DeliveredAt)
+				require.Equal(t, int32(1), notification.Attempts)
+			},
+		},
+		{
+			name:       "retried with backoff",
+			statusCode: http.StatusInternalServerError,
+			attempts:   2,
+			check: func(t *testing.T, notification *models.WebhookNotification) {
+				require.Equal(t, models.NotificationPending, notification.Status)
+				require.Equal(t, int32(3), notification.Attempts)
+				require.Equal(t, now.Add(4*time.Minute), notification.NextAttemptAt)
+				require.Contains(t, notification.LastError, "unexpected status code 500")
+			},
+		},
+		{
+			name:       "gives up after the last attempt",
+			statusCode: http.StatusBadGateway,
+			attempts:   4,
+			check: func(t *testing.T, notification *models.WebhookNotification) {
+				require.Equal(t, models.NotificationFailed, notification.Status)
+				require.Equal(t, int32(5), notification.Attempts)
+				require.Nil(t, notification.DeliveredAt)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				require.JSONEq(t, body, string(got))
+				require.Equal(t, strconv.FormatInt(now.Unix(), 10), r.Header.Get(TimestampHeader))
+				require.NoError(t, Verify("secret", r.Header.Get(SignatureHeader), r.Header.Get(TimestampHeader), got, now))
+				require.Equal(t, "swap.refunded", r.Header.Get(EventHeader))
+				require.Equal(t, "7", r.Header.Get(DeliveryHeader))
+				w.WriteHeader(tt.statusCode)
+			}))
+			t.Cleanup(server.Close)
+
+			ctrl := gomock.NewController(t)
+			repository := NewMockRepository(ctrl)
+			notifier := New(repository, Config{
+				URLs:           []string{server.URL},
+				Secret:         "secret",
+				MaxAttempts:    5,
+				InitialBackoff: time.Minute,
+				MaxBackoff:     time.Hour,
+			})
+			notifier.now = func() time.Time { return now }
+
+			notification := &models.WebhookNotification{
+				ID:            7,
+				URL:           server.URL,
+				EventType:     "swap.refunded",
+				Payload:       body,
+				Status:        models.NotificationPending,
+				Attempts:      tt.attempts,
+				NextAttemptAt: now,
+			}
+			repository.EXPECT().GetDueWebhookNotifications(ctx, now, deliveryBatchSize).Return([]*models.WebhookNotification{notification}, nil)
+			repository.EXPECT().UpdateWebhookNotification(ctx, notification).Return(nil)
+
+			require.NoError(t, notifier.Deliver(ctx))
+			tt.check(t, notification)
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	body := []byte(`{"type":"swap.refunded"}`)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	signature := "sha256=" + Sign("secret", timestamp, body)
+
+	require.NoError(t, Verify("secret", signature, timestamp, body, now))
+	require.NoError(t, Verify("secret", signature, timestamp, body, now.Add(SignatureTolerance)))
+	require.ErrorContains(t, Verify("secret", signature, timestamp, body, now.Add(SignatureTolerance+time.Second)), "out of the 5m0s tolerance")
+	require.ErrorContains(t, Verify("secret", signature, timestamp, body, now.Add(-SignatureTolerance-time.Second)), "out of the 5m0s tolerance")
+	require.ErrorContains(t, Verify("other", signature, timestamp, body, now), "invalid signature")
+	require.ErrorContains(t, Verify("secret", signature, timestamp, []byte(`{"type":"swap.expired"}`), now), "invalid signature")
+
+	// The timestamp can't be swapped for a recent one without the secret
+	replayed := strconv.FormatInt(now.Add(time.Hour).Unix(), 10)
+	require.ErrorContains(t, Verify("secret", signature, replayed, body, now.Add(time.Hour)), "invalid signature")
+	require.ErrorContains(t, Verify("secret", signature, "yesterday", body, now), "invalid timestamp")
+}
+
+func TestNotifier_Backoff(t *testing.T) {
+	notifier := New(nil, Config{InitialBackoff: time.Second, MaxBackoff: 10 * time.Second})
+
+	require.Equal(t, time.Second, notifier.backoff(1))
+	require.Equal(t, 2*time.Second, notifier.backoff(2))
+	require.Equal(t, 8*time.Second, notifier.backoff(4))
+	require.Equal(t, 10*time.Second, notifier.backoff(5))
+	require.Equal(t, 10*time.Second, notifier.backoff(40))
+}
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/40acres/40swap/daemon/price (interfaces: Source)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mock_source.go -package=price . Source
+//
+
+// Package price is a generated GoMock package.
+package price
+
+import (
+	context "context"
+	reflect "reflect"
+
+	decimal "github.com/shopspring/decimal"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSource is a mock of Source interface.
+type MockSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockSourceMockRecorder
+	isgomock struct{}
+}
+
+// MockSourceMockRecorder is the mock recorder for MockSource.
+type MockSourceMockRecorder struct {
+	mock *MockSource
+}
+
+// NewMockSource creates a new mock instance.
+func NewMockSource(ctrl *gomock.Controller) *MockSource {
+	mock := &MockSource{ctrl: ctrl}
+	mock.recorder = &MockSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSource) EXPECT() *MockSourceMockRecorder {
+	return m.recorder
+}
+
+// Rate mocks base method.
+func (m *MockSource) Rate(ctx context.Context, currency string) (decimal.Decimal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rate", ctx, currency)
+	ret0, _ := ret[0].(decimal.Decimal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rate indicates an expected call of Rate.
+func (mr *MockSourceMockRecorder) Rate(ctx, currency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rate", reflect.TypeOf((*MockSource)(nil).Rate), ctx, currency)
+}
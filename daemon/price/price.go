@@ -0,0 +1,125 @@
+package price
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// DefaultURL is the mempool.space API serving the current bitcoin prices
+const DefaultURL = "https://mempool.space/api/v1/prices"
+
+var (
+	ErrUnknownCurrency  = errors.New("no rate for currency")
+	ErrUnexpectedStatus = errors.New("unexpected status code")
+)
+
+//go:generate go tool mockgen -destination=mock_source.go -package=price . Source
+type Source interface {
+	// Rate returns the price of one bitcoin in the currency, an ISO 4217 code
+	Rate(ctx context.Context, currency string) (decimal.Decimal, error)
+}
+
+// Static is a source with fixed rates, keyed by currency
+type Static map[string]decimal.Decimal
+
+func (s Static) Rate(_ context.Context, currency string) (decimal.Decimal, error) {
+	rate, ok := s[strings.ToUpper(currency)]
+	if !ok || !rate.IsPositive() {
+		return decimal.Zero, fmt.Errorf("%w %s", ErrUnknownCurrency, currency)
+	}
+
+	return rate, nil
+}
+
+// File reads the rates from a JSON file mapping currencies to prices, e.g.
+// {"USD": 65000.5}, for offline use. It's read on every call so the rates
+// can be updated without restarting the daemon.
+type File struct {
+	path string
+}
+
+func NewFile(path string) *File {
+	return &File{path: path}
+}
+
+func (f *File) Rate(ctx context.Context, currency string) (decimal.Decimal, error) {
+	file, err := os.Open(f.path)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("could not open rates file: %w", err)
+	}
+	defer file.Close()
+
+	rates, err := decodeRates(file)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	return rates.Rate(ctx, currency)
+}
+
+// HTTP fetches the rates from an API answering like mempool.space's
+// /v1/prices, a JSON object mapping currencies to prices.
+type HTTP struct {
+	client *http.Client
+	url    string
+}
+
+func NewHTTP(url string) *HTTP {
+	return &HTTP{
+		client: &http.Client{Timeout: 10 * time.Second},
+		url:    url,
+	}
+}
+
+func (h *HTTP) Rate(ctx context.Context, currency string) (decimal.Decimal, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, h.url, nil)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("could not get rates: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return decimal.Zero, fmt.Errorf("could not get rates: %d: %w", resp.StatusCode, ErrUnexpectedStatus)
+	}
+
+	rates, err := decodeRates(resp.Body)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	return rates.Rate(ctx, currency)
+}
+
+// decodeRates decodes a JSON object of rates, values that aren't numbers are
+// skipped
+func decodeRates(r io.Reader) (Static, error) {
+	var values map[string]json.RawMessage
+	if err := json.NewDecoder(r).Decode(&values); err != nil {
+		return nil, fmt.Errorf("could not decode rates: %w", err)
+	}
+
+	rates := Static{}
+	for currency, value := range values {
+		var rate decimal.Decimal
+		if err := rate.UnmarshalJSON(value); err != nil {
+			continue
+		}
+		rates[strings.ToUpper(currency)] = rate
+	}
+
+	return rates, nil
+}
@@ -0,0 +1,71 @@
+package price
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatic(t *testing.T) {
+	ctx := context.Background()
+	source := Static{"USD": decimal.NewFromInt(65000)}
+
+	rate, err := source.Rate(ctx, "usd")
+	require.NoError(t, err)
+	require.True(t, rate.Equal(decimal.NewFromInt(65000)))
+
+	_, err = source.Rate(ctx, "EUR")
+	require.ErrorIs(t, err, ErrUnknownCurrency)
+}
+
+func TestFile(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "rates.json")
+	source := NewFile(path)
+
+	_, err := source.Rate(ctx, "USD")
+	require.Error(t, err)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"USD": 65000.5, "eur": "60000"}`), 0o600))
+	rate, err := source.Rate(ctx, "USD")
+	require.NoError(t, err)
+	require.True(t, rate.Equal(decimal.RequireFromString("65000.5")))
+	rate, err = source.Rate(ctx, "EUR")
+	require.NoError(t, err)
+	require.True(t, rate.Equal(decimal.NewFromInt(60000)))
+
+	// Updates are picked up without a restart
+	require.NoError(t, os.WriteFile(path, []byte(`{"USD": 70000}`), 0o600))
+	rate, err = source.Rate(ctx, "USD")
+	require.NoError(t, err)
+	require.True(t, rate.Equal(decimal.NewFromInt(70000)))
+}
+
+func TestHTTP(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/prices" {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+		_, _ = w.Write([]byte(`{"time": 1735689600, "USD": 93429, "EUR": 89768}`))
+	}))
+	defer server.Close()
+
+	rate, err := NewHTTP(server.URL+"/v1/prices").Rate(ctx, "EUR")
+	require.NoError(t, err)
+	require.True(t, rate.Equal(decimal.NewFromInt(89768)))
+
+	_, err = NewHTTP(server.URL+"/v1/prices").Rate(ctx, "JPY")
+	require.ErrorIs(t, err, ErrUnknownCurrency)
+
+	_, err = NewHTTP(server.URL+"/missing").Rate(ctx, "USD")
+	require.ErrorIs(t, err, ErrUnexpectedStatus)
+}
@@ -25,4 +25,8 @@ docker run --rm -v ${ROOT}:${ROOT} -w ${ROOT} -u $(id -u):$(id -g) 40swap-proto-
     -I ${PROTO_DIR}      \
     --go_out=${OUT_DIR}      \
     --go-grpc_out=${OUT_DIR} \
+    --grpc-gateway_out=${OUT_DIR} \
+    --grpc-gateway_opt=grpc_api_configuration=${PROTO_DIR}/40swapd.yaml \
+    --openapiv2_out=${OUT_DIR}/rpc \
+    --openapiv2_opt=grpc_api_configuration=${PROTO_DIR}/40swapd.yaml \
     ${PROTO_FILES}
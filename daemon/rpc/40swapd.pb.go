@@ -119,6 +119,109 @@ func (Network) EnumDescriptor() ([]byte, []int) {
 	return file__40swapd_proto_rawDescGZIP(), []int{1}
 }
 
+// Enum definition for the periods report totals are aggregated by.
+type ReportPeriod int32
+
+const (
+	ReportPeriod_DAY   ReportPeriod = 0 // Calendar day in UTC.
+	ReportPeriod_WEEK  ReportPeriod = 1 // ISO week, starting on Monday, in UTC.
+	ReportPeriod_MONTH ReportPeriod = 2 // Calendar month in UTC.
+)
+
+// Enum value maps for ReportPeriod.
+var (
+	ReportPeriod_name = map[int32]string{
+		0: "DAY",
+		1: "WEEK",
+		2: "MONTH",
+	}
+	ReportPeriod_value = map[string]int32{
+		"DAY":   0,
+		"WEEK":  1,
+		"MONTH": 2,
+	}
+)
+
+func (x ReportPeriod) Enum() *ReportPeriod {
+	p := new(ReportPeriod)
+	*p = x
+	return p
+}
+
+func (x ReportPeriod) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReportPeriod) Descriptor() protoreflect.EnumDescriptor {
+	return file__40swapd_proto_enumTypes[2].Descriptor()
+}
+
+func (ReportPeriod) Type() protoreflect.EnumType {
+	return &file__40swapd_proto_enumTypes[2]
+}
+
+func (x ReportPeriod) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReportPeriod.Descriptor instead.
+func (ReportPeriod) EnumDescriptor() ([]byte, []int) {
+	return file__40swapd_proto_rawDescGZIP(), []int{2}
+}
+
+// Enum definition for the statuses of the payment of a swap out invoice.
+type PaymentStatus int32
+
+const (
+	PaymentStatus_PAYMENT_PENDING   PaymentStatus = 0 // Payment not sent to the lightning node yet.
+	PaymentStatus_PAYMENT_IN_FLIGHT PaymentStatus = 1 // Payment being routed by the lightning node.
+	PaymentStatus_PAYMENT_SUCCEEDED PaymentStatus = 2 // Invoice paid.
+	PaymentStatus_PAYMENT_FAILED    PaymentStatus = 3 // Payment given up, the invoice expired or the swap is over.
+)
+
+// Enum value maps for PaymentStatus.
+var (
+	PaymentStatus_name = map[int32]string{
+		0: "PAYMENT_PENDING",
+		1: "PAYMENT_IN_FLIGHT",
+		2: "PAYMENT_SUCCEEDED",
+		3: "PAYMENT_FAILED",
+	}
+	PaymentStatus_value = map[string]int32{
+		"PAYMENT_PENDING":   0,
+		"PAYMENT_IN_FLIGHT": 1,
+		"PAYMENT_SUCCEEDED": 2,
+		"PAYMENT_FAILED":    3,
+	}
+)
+
+func (x PaymentStatus) Enum() *PaymentStatus {
+	p := new(PaymentStatus)
+	*p = x
+	return p
+}
+
+func (x PaymentStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PaymentStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file__40swapd_proto_enumTypes[3].Descriptor()
+}
+
+func (PaymentStatus) Type() protoreflect.EnumType {
+	return &file__40swapd_proto_enumTypes[3]
+}
+
+func (x PaymentStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PaymentStatus.Descriptor instead.
+func (PaymentStatus) EnumDescriptor() ([]byte, []int) {
+	return file__40swapd_proto_rawDescGZIP(), []int{3}
+}
+
 // Enum definition for swap statuses.
 type Status int32
 
@@ -173,11 +276,11 @@ func (x Status) String() string {
 }
 
 func (Status) Descriptor() protoreflect.EnumDescriptor {
-	return file__40swapd_proto_enumTypes[2].Descriptor()
+	return file__40swapd_proto_enumTypes[4].Descriptor()
 }
 
 func (Status) Type() protoreflect.EnumType {
-	return &file__40swapd_proto_enumTypes[2]
+	return &file__40swapd_proto_enumTypes[4]
 }
 
 func (x Status) Number() protoreflect.EnumNumber {
@@ -186,19 +289,21 @@ func (x Status) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Status.Descriptor instead.
 func (Status) EnumDescriptor() ([]byte, []int) {
-	return file__40swapd_proto_rawDescGZIP(), []int{2}
+	return file__40swapd_proto_rawDescGZIP(), []int{4}
 }
 
 // Message definitions for SwapIn operation.
 type SwapInRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Chain         Chain                  `protobuf:"varint,1,opt,name=chain,proto3,enum=Chain" json:"chain,omitempty"`                        // Blockchain chain for the swap.
-	Invoice       *string                `protobuf:"bytes,2,opt,name=invoice,proto3,oneof" json:"invoice,omitempty"`                          // Invoice to be paid.
-	AmountSats    *uint64                `protobuf:"varint,3,opt,name=amount_sats,json=amountSats,proto3,oneof" json:"amount_sats,omitempty"` // Amount in satoshis.
-	Expiry        *uint32                `protobuf:"varint,4,opt,name=expiry,proto3,oneof" json:"expiry,omitempty"`                           // Expiry time for the swap.
-	RefundTo      string                 `protobuf:"bytes,5,opt,name=refund_to,json=refundTo,proto3" json:"refund_to,omitempty"`              // Address to refund in case of failure.
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Chain           Chain                  `protobuf:"varint,1,opt,name=chain,proto3,enum=Chain" json:"chain,omitempty"`                                        // Blockchain chain for the swap.
+	Invoice         *string                `protobuf:"bytes,2,opt,name=invoice,proto3,oneof" json:"invoice,omitempty"`                                          // Invoice to be paid.
+	AmountSats      *uint64                `protobuf:"varint,3,opt,name=amount_sats,json=amountSats,proto3,oneof" json:"amount_sats,omitempty"`                 // Amount in satoshis.
+	Expiry          *uint32                `protobuf:"varint,4,opt,name=expiry,proto3,oneof" json:"expiry,omitempty"`                                           // Expiry time for the swap.
+	RefundTo        string                 `protobuf:"bytes,5,opt,name=refund_to,json=refundTo,proto3" json:"refund_to,omitempty"`                              // Address to refund in case of failure.
+	ClientRequestId *string                `protobuf:"bytes,6,opt,name=client_request_id,json=clientRequestId,proto3,oneof" json:"client_request_id,omitempty"` // Idempotency key, repeating a request with it returns the swap it created instead of a new one.
+	Label           string                 `protobuf:"bytes,7,opt,name=label,proto3" json:"label,omitempty"`                                                    // User-defined label to tag the swap with.
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *SwapInRequest) Reset() {
@@ -266,6 +371,20 @@ func (x *SwapInRequest) GetRefundTo() string {
 	return ""
 }
 
+func (x *SwapInRequest) GetClientRequestId() string {
+	if x != nil && x.ClientRequestId != nil {
+		return *x.ClientRequestId
+	}
+	return ""
+}
+
+func (x *SwapInRequest) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
 type SwapInResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	SwapId        string                 `protobuf:"bytes,1,opt,name=swap_id,json=swapId,proto3" json:"swap_id,omitempty"`                      // Unique identifier for the swap.
@@ -341,6 +460,8 @@ type SwapOutRequest struct {
 	AmountSats           uint64                 `protobuf:"varint,2,opt,name=amount_sats,json=amountSats,proto3" json:"amount_sats,omitempty"`                                          // Amount in satoshis.
 	Address              string                 `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`                                                                   // Optional destination address.
 	MaxRoutingFeePercent *float32               `protobuf:"fixed32,4,opt,name=max_routing_fee_percent,json=maxRoutingFeePercent,proto3,oneof" json:"max_routing_fee_percent,omitempty"` // Maximum routing fee in percentage for the lightning network.
+	ClientRequestId      *string                `protobuf:"bytes,5,opt,name=client_request_id,json=clientRequestId,proto3,oneof" json:"client_request_id,omitempty"`                    // Idempotency key, repeating a request with it returns the swap it created instead of a new one.
+	Label                string                 `protobuf:"bytes,6,opt,name=label,proto3" json:"label,omitempty"`                                                                       // User-defined label to tag the swap with.
 	unknownFields        protoimpl.UnknownFields
 	sizeCache            protoimpl.SizeCache
 }
@@ -403,6 +524,20 @@ func (x *SwapOutRequest) GetMaxRoutingFeePercent() float32 {
 	return 0
 }
 
+func (x *SwapOutRequest) GetClientRequestId() string {
+	if x != nil && x.ClientRequestId != nil {
+		return *x.ClientRequestId
+	}
+	return ""
+}
+
+func (x *SwapOutRequest) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
 type SwapOutResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	SwapId        string                 `protobuf:"bytes,1,opt,name=swap_id,json=swapId,proto3" json:"swap_id,omitempty"`              // Unique identifier for the swap.
@@ -516,6 +651,9 @@ type GetSwapInResponse struct {
 	RefundTxId         *string                `protobuf:"bytes,12,opt,name=refund_tx_id,json=refundTxId,proto3,oneof" json:"refund_tx_id,omitempty"`                    // Refund transaction ID.
 	ServiceFeeSats     uint64                 `protobuf:"varint,13,opt,name=service_fee_sats,json=serviceFeeSats,proto3" json:"service_fee_sats,omitempty"`             // Service fee in satoshis.
 	OnchainFeeSats     uint64                 `protobuf:"varint,14,opt,name=onchain_fee_sats,json=onchainFeeSats,proto3" json:"onchain_fee_sats,omitempty"`             // On-chain fee in satoshis.
+	Fiat               *FiatValue             `protobuf:"bytes,15,opt,name=fiat,proto3" json:"fiat,omitempty"`                                                          // Value of the swap in fiat when it completed, unset when unknown.
+	Label              string                 `protobuf:"bytes,16,opt,name=label,proto3" json:"label,omitempty"`                                                        // User-defined label of the swap.
+	ClientRequestId    *string                `protobuf:"bytes,17,opt,name=client_request_id,json=clientRequestId,proto3,oneof" json:"client_request_id,omitempty"`     // Idempotency key of the request that created the swap.
 	unknownFields      protoimpl.UnknownFields
 	sizeCache          protoimpl.SizeCache
 }
@@ -648,6 +786,27 @@ func (x *GetSwapInResponse) GetOnchainFeeSats() uint64 {
 	return 0
 }
 
+func (x *GetSwapInResponse) GetFiat() *FiatValue {
+	if x != nil {
+		return x.Fiat
+	}
+	return nil
+}
+
+func (x *GetSwapInResponse) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *GetSwapInResponse) GetClientRequestId() string {
+	if x != nil && x.ClientRequestId != nil {
+		return *x.ClientRequestId
+	}
+	return ""
+}
+
 // Message definitions for querying SwapOut status.
 type GetSwapOutRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -695,18 +854,24 @@ func (x *GetSwapOutRequest) GetId() string {
 
 type GetSwapOutResponse struct {
 	state              protoimpl.MessageState `protogen:"open.v1"`
-	Id                 string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                                              // Unique identifier for the swap.
-	TimeoutBlockHeight uint32                 `protobuf:"varint,2,opt,name=timeout_block_height,json=timeoutBlockHeight,proto3" json:"timeout_block_height,omitempty"` // Block height at which the swap times out.
-	Invoice            string                 `protobuf:"bytes,3,opt,name=invoice,proto3" json:"invoice,omitempty"`                                                    // Invoice associated with the swap.
-	InputAmount        float64                `protobuf:"fixed64,4,opt,name=input_amount,json=inputAmount,proto3" json:"input_amount,omitempty"`                       // Input amount in BTC.
-	OutputAmount       float64                `protobuf:"fixed64,5,opt,name=output_amount,json=outputAmount,proto3" json:"output_amount,omitempty"`                    // Output amount in BTC.
-	Status             Status                 `protobuf:"varint,6,opt,name=status,proto3,enum=Status" json:"status,omitempty"`                                         // Current status of the swap.
-	CreatedAt          *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`                               // Timestamp when the swap was created.
-	Outcome            *string                `protobuf:"bytes,8,opt,name=outcome,proto3,oneof" json:"outcome,omitempty"`                                              // Outcome of the swap.
-	ClaimTxId          *string                `protobuf:"bytes,9,opt,name=claim_tx_id,json=claimTxId,proto3,oneof" json:"claim_tx_id,omitempty"`                       // Claim transaction txid.
-	ServiceFeeSats     uint64                 `protobuf:"varint,10,opt,name=service_fee_sats,json=serviceFeeSats,proto3" json:"service_fee_sats,omitempty"`            // Service fee in satoshis.
-	OnchainFeeSats     uint64                 `protobuf:"varint,11,opt,name=onchain_fee_sats,json=onchainFeeSats,proto3" json:"onchain_fee_sats,omitempty"`            // On-chain fee in satoshis.
-	OffchainFeeSats    uint64                 `protobuf:"varint,12,opt,name=offchain_fee_sats,json=offchainFeeSats,proto3" json:"offchain_fee_sats,omitempty"`         // Off-chain (routing) fee in satoshis.
+	Id                 string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                                                 // Unique identifier for the swap.
+	TimeoutBlockHeight uint32                 `protobuf:"varint,2,opt,name=timeout_block_height,json=timeoutBlockHeight,proto3" json:"timeout_block_height,omitempty"`    // Block height at which the swap times out.
+	Invoice            string                 `protobuf:"bytes,3,opt,name=invoice,proto3" json:"invoice,omitempty"`                                                       // Invoice associated with the swap.
+	InputAmount        float64                `protobuf:"fixed64,4,opt,name=input_amount,json=inputAmount,proto3" json:"input_amount,omitempty"`                          // Input amount in BTC.
+	OutputAmount       float64                `protobuf:"fixed64,5,opt,name=output_amount,json=outputAmount,proto3" json:"output_amount,omitempty"`                       // Output amount in BTC.
+	Status             Status                 `protobuf:"varint,6,opt,name=status,proto3,enum=Status" json:"status,omitempty"`                                            // Current status of the swap.
+	CreatedAt          *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`                                  // Timestamp when the swap was created.
+	Outcome            *string                `protobuf:"bytes,8,opt,name=outcome,proto3,oneof" json:"outcome,omitempty"`                                                 // Outcome of the swap.
+	ClaimTxId          *string                `protobuf:"bytes,9,opt,name=claim_tx_id,json=claimTxId,proto3,oneof" json:"claim_tx_id,omitempty"`                          // Claim transaction txid.
+	ServiceFeeSats     uint64                 `protobuf:"varint,10,opt,name=service_fee_sats,json=serviceFeeSats,proto3" json:"service_fee_sats,omitempty"`               // Service fee in satoshis.
+	OnchainFeeSats     uint64                 `protobuf:"varint,11,opt,name=onchain_fee_sats,json=onchainFeeSats,proto3" json:"onchain_fee_sats,omitempty"`               // On-chain fee in satoshis.
+	OffchainFeeSats    uint64                 `protobuf:"varint,12,opt,name=offchain_fee_sats,json=offchainFeeSats,proto3" json:"offchain_fee_sats,omitempty"`            // Off-chain (routing) fee in satoshis.
+	Fiat               *FiatValue             `protobuf:"bytes,13,opt,name=fiat,proto3" json:"fiat,omitempty"`                                                            // Value of the swap in fiat when it completed, unset when unknown.
+	Label              string                 `protobuf:"bytes,14,opt,name=label,proto3" json:"label,omitempty"`                                                          // User-defined label of the swap.
+	ClientRequestId    *string                `protobuf:"bytes,15,opt,name=client_request_id,json=clientRequestId,proto3,oneof" json:"client_request_id,omitempty"`       // Idempotency key of the request that created the swap.
+	PaymentStatus      PaymentStatus          `protobuf:"varint,16,opt,name=payment_status,json=paymentStatus,proto3,enum=PaymentStatus" json:"payment_status,omitempty"` // Status of the payment of the invoice.
+	PaymentAttempts    uint32                 `protobuf:"varint,17,opt,name=payment_attempts,json=paymentAttempts,proto3" json:"payment_attempts,omitempty"`              // Times the invoice payment has been attempted.
+	PaymentError       *string                `protobuf:"bytes,18,opt,name=payment_error,json=paymentError,proto3,oneof" json:"payment_error,omitempty"`                  // Error of the last failed payment attempt.
 	unknownFields      protoimpl.UnknownFields
 	sizeCache          protoimpl.SizeCache
 }
@@ -825,6 +990,133 @@ func (x *GetSwapOutResponse) GetOffchainFeeSats() uint64 {
 	return 0
 }
 
+func (x *GetSwapOutResponse) GetFiat() *FiatValue {
+	if x != nil {
+		return x.Fiat
+	}
+	return nil
+}
+
+func (x *GetSwapOutResponse) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *GetSwapOutResponse) GetClientRequestId() string {
+	if x != nil && x.ClientRequestId != nil {
+		return *x.ClientRequestId
+	}
+	return ""
+}
+
+func (x *GetSwapOutResponse) GetPaymentStatus() PaymentStatus {
+	if x != nil {
+		return x.PaymentStatus
+	}
+	return PaymentStatus_PAYMENT_PENDING
+}
+
+func (x *GetSwapOutResponse) GetPaymentAttempts() uint32 {
+	if x != nil {
+		return x.PaymentAttempts
+	}
+	return 0
+}
+
+func (x *GetSwapOutResponse) GetPaymentError() string {
+	if x != nil && x.PaymentError != nil {
+		return *x.PaymentError
+	}
+	return ""
+}
+
+// Value of a swap in fiat at the rate recorded when it completed.
+type FiatValue struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Currency      string                 `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`                            // ISO 4217 code of the currency, e.g. USD.
+	Rate          float64                `protobuf:"fixed64,2,opt,name=rate,proto3" json:"rate,omitempty"`                                  // Price of one bitcoin in the currency.
+	Amount        float64                `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`                              // Amount of the swap.
+	ServiceFee    float64                `protobuf:"fixed64,4,opt,name=service_fee,json=serviceFee,proto3" json:"service_fee,omitempty"`    // Service fee.
+	OnchainFee    float64                `protobuf:"fixed64,5,opt,name=onchain_fee,json=onchainFee,proto3" json:"onchain_fee,omitempty"`    // On-chain fee.
+	OffchainFee   float64                `protobuf:"fixed64,6,opt,name=offchain_fee,json=offchainFee,proto3" json:"offchain_fee,omitempty"` // Off-chain (routing) fee.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FiatValue) Reset() {
+	*x = FiatValue{}
+	mi := &file__40swapd_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FiatValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FiatValue) ProtoMessage() {}
+
+func (x *FiatValue) ProtoReflect() protoreflect.Message {
+	mi := &file__40swapd_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FiatValue.ProtoReflect.Descriptor instead.
+func (*FiatValue) Descriptor() ([]byte, []int) {
+	return file__40swapd_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *FiatValue) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *FiatValue) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *FiatValue) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *FiatValue) GetServiceFee() float64 {
+	if x != nil {
+		return x.ServiceFee
+	}
+	return 0
+}
+
+func (x *FiatValue) GetOnchainFee() float64 {
+	if x != nil {
+		return x.OnchainFee
+	}
+	return 0
+}
+
+func (x *FiatValue) GetOffchainFee() float64 {
+	if x != nil {
+		return x.OffchainFee
+	}
+	return 0
+}
+
 type RecoverReusedSwapAddressRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Outpoint      string                 `protobuf:"bytes,1,opt,name=outpoint,proto3" json:"outpoint,omitempty"`                       // Outpoint of the transaction to refund
@@ -835,7 +1127,7 @@ type RecoverReusedSwapAddressRequest struct {
 
 func (x *RecoverReusedSwapAddressRequest) Reset() {
 	*x = RecoverReusedSwapAddressRequest{}
-	mi := &file__40swapd_proto_msgTypes[8]
+	mi := &file__40swapd_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -847,7 +1139,7 @@ func (x *RecoverReusedSwapAddressRequest) String() string {
 func (*RecoverReusedSwapAddressRequest) ProtoMessage() {}
 
 func (x *RecoverReusedSwapAddressRequest) ProtoReflect() protoreflect.Message {
-	mi := &file__40swapd_proto_msgTypes[8]
+	mi := &file__40swapd_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -860,7 +1152,7 @@ func (x *RecoverReusedSwapAddressRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RecoverReusedSwapAddressRequest.ProtoReflect.Descriptor instead.
 func (*RecoverReusedSwapAddressRequest) Descriptor() ([]byte, []int) {
-	return file__40swapd_proto_rawDescGZIP(), []int{8}
+	return file__40swapd_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *RecoverReusedSwapAddressRequest) GetOutpoint() string {
@@ -887,7 +1179,7 @@ type RecoverReusedSwapAddressResponse struct {
 
 func (x *RecoverReusedSwapAddressResponse) Reset() {
 	*x = RecoverReusedSwapAddressResponse{}
-	mi := &file__40swapd_proto_msgTypes[9]
+	mi := &file__40swapd_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -899,7 +1191,7 @@ func (x *RecoverReusedSwapAddressResponse) String() string {
 func (*RecoverReusedSwapAddressResponse) ProtoMessage() {}
 
 func (x *RecoverReusedSwapAddressResponse) ProtoReflect() protoreflect.Message {
-	mi := &file__40swapd_proto_msgTypes[9]
+	mi := &file__40swapd_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -912,7 +1204,7 @@ func (x *RecoverReusedSwapAddressResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RecoverReusedSwapAddressResponse.ProtoReflect.Descriptor instead.
 func (*RecoverReusedSwapAddressResponse) Descriptor() ([]byte, []int) {
-	return file__40swapd_proto_rawDescGZIP(), []int{9}
+	return file__40swapd_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *RecoverReusedSwapAddressResponse) GetTxid() string {
@@ -929,180 +1221,1617 @@ func (x *RecoverReusedSwapAddressResponse) GetRecoveredAmount() float64 {
 	return 0
 }
 
-var File__40swapd_proto protoreflect.FileDescriptor
+type ReopenSwapRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // Unique identifier for the swap.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-var file__40swapd_proto_rawDesc = string([]byte{
-	0x0a, 0x0d, 0x34, 0x30, 0x73, 0x77, 0x61, 0x70, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
-	0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
-	0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x22, 0xd3, 0x01, 0x0a, 0x0d, 0x53, 0x77, 0x61, 0x70, 0x49, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x1c, 0x0a, 0x05, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0e, 0x32, 0x06, 0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x52, 0x05, 0x63, 0x68, 0x61, 0x69, 0x6e,
-	0x12, 0x1d, 0x0a, 0x07, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x48, 0x00, 0x52, 0x07, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x88, 0x01, 0x01, 0x12,
-	0x24, 0x0a, 0x0b, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x73, 0x61, 0x74, 0x73, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x04, 0x48, 0x01, 0x52, 0x0a, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x53, 0x61,
-	0x74, 0x73, 0x88, 0x01, 0x01, 0x12, 0x1b, 0x0a, 0x06, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x02, 0x52, 0x06, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79, 0x88,
-	0x01, 0x01, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x5f, 0x74, 0x6f, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x54, 0x6f, 0x42,
-	0x0a, 0x0a, 0x08, 0x5f, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x42, 0x0e, 0x0a, 0x0c, 0x5f,
-	0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x73, 0x61, 0x74, 0x73, 0x42, 0x09, 0x0a, 0x07, 0x5f,
-	0x65, 0x78, 0x70, 0x69, 0x72, 0x79, 0x22, 0x96, 0x01, 0x0a, 0x0e, 0x53, 0x77, 0x61, 0x70, 0x49,
-	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x73, 0x77, 0x61,
-	0x70, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x77, 0x61, 0x70,
-	0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x73, 0x61, 0x74,
-	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x53,
-	0x61, 0x74, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x5f, 0x61, 0x64, 0x64,
-	0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x6c, 0x61, 0x69,
-	0x6d, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x66, 0x75,
-	0x6e, 0x64, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0d, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22,
-	0xc1, 0x01, 0x0a, 0x0e, 0x53, 0x77, 0x61, 0x70, 0x4f, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x1c, 0x0a, 0x05, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0e, 0x32, 0x06, 0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x52, 0x05, 0x63, 0x68, 0x61, 0x69, 0x6e,
-	0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x73, 0x61, 0x74, 0x73, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x53, 0x61, 0x74,
-	0x73, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x3a, 0x0a, 0x17, 0x6d,
-	0x61, 0x78, 0x5f, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x70,
-	0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x02, 0x48, 0x00, 0x52, 0x14,
-	0x6d, 0x61, 0x78, 0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x46, 0x65, 0x65, 0x50, 0x65, 0x72,
-	0x63, 0x65, 0x6e, 0x74, 0x88, 0x01, 0x01, 0x42, 0x1a, 0x0a, 0x18, 0x5f, 0x6d, 0x61, 0x78, 0x5f,
-	0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x63,
-	0x65, 0x6e, 0x74, 0x22, 0x4b, 0x0a, 0x0f, 0x53, 0x77, 0x61, 0x70, 0x4f, 0x75, 0x74, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x77, 0x61, 0x70, 0x49, 0x64, 0x12,
-	0x1f, 0x0a, 0x0b, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x73, 0x61, 0x74, 0x73, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x53, 0x61, 0x74, 0x73,
-	0x22, 0x22, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x53, 0x77, 0x61, 0x70, 0x49, 0x6e, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x02, 0x69, 0x64, 0x22, 0xe2, 0x04, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x53, 0x77, 0x61, 0x70,
-	0x49, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x63, 0x6f,
-	0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x41, 0x64,
-	0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
-	0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
-	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74,
-	0x12, 0x21, 0x0a, 0x0c, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x41, 0x6d, 0x6f,
-	0x75, 0x6e, 0x74, 0x12, 0x21, 0x0a, 0x0a, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x74, 0x78, 0x5f, 0x69,
-	0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x6b, 0x54,
-	0x78, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x1d, 0x0a, 0x07, 0x6f, 0x75, 0x74, 0x63, 0x6f, 0x6d,
-	0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x07, 0x6f, 0x75, 0x74, 0x63, 0x6f,
-	0x6d, 0x65, 0x88, 0x01, 0x01, 0x12, 0x23, 0x0a, 0x0d, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x5f,
-	0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x6f, 0x75,
-	0x74, 0x70, 0x75, 0x74, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65,
-	0x64, 0x65, 0x65, 0x6d, 0x5f, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0c, 0x72, 0x65, 0x64, 0x65, 0x65, 0x6d, 0x53, 0x63, 0x72, 0x69, 0x70, 0x74, 0x12,
-	0x1f, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0e, 0x32,
-	0x07, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x12, 0x30, 0x0a, 0x14, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x5f, 0x62, 0x6c, 0x6f, 0x63,
-	0x6b, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x12,
-	0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x69, 0x67,
-	0x68, 0x74, 0x12, 0x20, 0x0a, 0x09, 0x70, 0x72, 0x65, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18,
-	0x0b, 0x20, 0x01, 0x28, 0x09, 0x48, 0x02, 0x52, 0x08, 0x70, 0x72, 0x65, 0x49, 0x6d, 0x61, 0x67,
-	0x65, 0x88, 0x01, 0x01, 0x12, 0x25, 0x0a, 0x0c, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x5f, 0x74,
-	0x78, 0x5f, 0x69, 0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x48, 0x03, 0x52, 0x0a, 0x72, 0x65,
-	0x66, 0x75, 0x6e, 0x64, 0x54, 0x78, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x28, 0x0a, 0x10, 0x73,
-	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x73, 0x61, 0x74, 0x73, 0x18,
-	0x0d, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x46, 0x65,
-	0x65, 0x53, 0x61, 0x74, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x6f, 0x6e, 0x63, 0x68, 0x61, 0x69, 0x6e,
-	0x5f, 0x66, 0x65, 0x65, 0x5f, 0x73, 0x61, 0x74, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x04, 0x52,
-	0x0e, 0x6f, 0x6e, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x46, 0x65, 0x65, 0x53, 0x61, 0x74, 0x73, 0x42,
-	0x0d, 0x0a, 0x0b, 0x5f, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x74, 0x78, 0x5f, 0x69, 0x64, 0x42, 0x0a,
-	0x0a, 0x08, 0x5f, 0x6f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x70,
-	0x72, 0x65, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x72, 0x65, 0x66,
-	0x75, 0x6e, 0x64, 0x5f, 0x74, 0x78, 0x5f, 0x69, 0x64, 0x22, 0x23, 0x0a, 0x11, 0x47, 0x65, 0x74,
-	0x53, 0x77, 0x61, 0x70, 0x4f, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e,
-	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0xf4,
-	0x03, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x53, 0x77, 0x61, 0x70, 0x4f, 0x75, 0x74, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x30, 0x0a, 0x14, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74,
-	0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0d, 0x52, 0x12, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x42, 0x6c, 0x6f, 0x63,
-	0x6b, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x69, 0x6e, 0x76, 0x6f, 0x69,
-	0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63,
-	0x65, 0x12, 0x21, 0x0a, 0x0c, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e,
-	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x41, 0x6d,
-	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x5f, 0x61,
-	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x6f, 0x75, 0x74,
-	0x70, 0x75, 0x74, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x06, 0x73, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x07, 0x2e, 0x53, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72,
-	0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
-	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
-	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61,
-	0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1d, 0x0a, 0x07, 0x6f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65,
-	0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x07, 0x6f, 0x75, 0x74, 0x63, 0x6f, 0x6d,
-	0x65, 0x88, 0x01, 0x01, 0x12, 0x23, 0x0a, 0x0b, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x5f, 0x74, 0x78,
-	0x5f, 0x69, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x09, 0x63, 0x6c, 0x61,
-	0x69, 0x6d, 0x54, 0x78, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x28, 0x0a, 0x10, 0x73, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x73, 0x61, 0x74, 0x73, 0x18, 0x0a, 0x20,
-	0x01, 0x28, 0x04, 0x52, 0x0e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x46, 0x65, 0x65, 0x53,
-	0x61, 0x74, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x6f, 0x6e, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x66,
-	0x65, 0x65, 0x5f, 0x73, 0x61, 0x74, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x6f,
-	0x6e, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x46, 0x65, 0x65, 0x53, 0x61, 0x74, 0x73, 0x12, 0x2a, 0x0a,
-	0x11, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x73, 0x61,
-	0x74, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61,
-	0x69, 0x6e, 0x46, 0x65, 0x65, 0x53, 0x61, 0x74, 0x73, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x6f, 0x75,
-	0x74, 0x63, 0x6f, 0x6d, 0x65, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x5f,
-	0x74, 0x78, 0x5f, 0x69, 0x64, 0x22, 0x6d, 0x0a, 0x1f, 0x52, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72,
-	0x52, 0x65, 0x75, 0x73, 0x65, 0x64, 0x53, 0x77, 0x61, 0x70, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x75, 0x74, 0x70,
-	0x6f, 0x69, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6f, 0x75, 0x74, 0x70,
-	0x6f, 0x69, 0x6e, 0x74, 0x12, 0x20, 0x0a, 0x09, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x5f, 0x74,
-	0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x08, 0x72, 0x65, 0x66, 0x75, 0x6e,
-	0x64, 0x54, 0x6f, 0x88, 0x01, 0x01, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x72, 0x65, 0x66, 0x75, 0x6e,
-	0x64, 0x5f, 0x74, 0x6f, 0x22, 0x61, 0x0a, 0x20, 0x52, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x52,
-	0x65, 0x75, 0x73, 0x65, 0x64, 0x53, 0x77, 0x61, 0x70, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x78, 0x69, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x78, 0x69, 0x64, 0x12, 0x29, 0x0a, 0x10,
-	0x72, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x65, 0x64, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0f, 0x72, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x65,
-	0x64, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x2a, 0x20, 0x0a, 0x05, 0x43, 0x68, 0x61, 0x69, 0x6e,
-	0x12, 0x0b, 0x0a, 0x07, 0x42, 0x49, 0x54, 0x43, 0x4f, 0x49, 0x4e, 0x10, 0x00, 0x12, 0x0a, 0x0a,
-	0x06, 0x4c, 0x49, 0x51, 0x55, 0x49, 0x44, 0x10, 0x01, 0x2a, 0x30, 0x0a, 0x07, 0x4e, 0x65, 0x74,
-	0x77, 0x6f, 0x72, 0x6b, 0x12, 0x0b, 0x0a, 0x07, 0x4d, 0x41, 0x49, 0x4e, 0x4e, 0x45, 0x54, 0x10,
-	0x00, 0x12, 0x0b, 0x0a, 0x07, 0x54, 0x45, 0x53, 0x54, 0x4e, 0x45, 0x54, 0x10, 0x01, 0x12, 0x0b,
-	0x0a, 0x07, 0x52, 0x45, 0x47, 0x54, 0x45, 0x53, 0x54, 0x10, 0x02, 0x2a, 0xe7, 0x01, 0x0a, 0x06,
-	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x0b, 0x0a, 0x07, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45,
-	0x44, 0x10, 0x00, 0x12, 0x23, 0x0a, 0x1f, 0x49, 0x4e, 0x56, 0x4f, 0x49, 0x43, 0x45, 0x5f, 0x50,
-	0x41, 0x59, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x49, 0x4e, 0x54, 0x45, 0x4e, 0x54, 0x5f, 0x52, 0x45,
-	0x43, 0x45, 0x49, 0x56, 0x45, 0x44, 0x10, 0x01, 0x12, 0x1f, 0x0a, 0x1b, 0x43, 0x4f, 0x4e, 0x54,
-	0x52, 0x41, 0x43, 0x54, 0x5f, 0x46, 0x55, 0x4e, 0x44, 0x45, 0x44, 0x5f, 0x55, 0x4e, 0x43, 0x4f,
-	0x4e, 0x46, 0x49, 0x52, 0x4d, 0x45, 0x44, 0x10, 0x02, 0x12, 0x13, 0x0a, 0x0f, 0x43, 0x4f, 0x4e,
-	0x54, 0x52, 0x41, 0x43, 0x54, 0x5f, 0x46, 0x55, 0x4e, 0x44, 0x45, 0x44, 0x10, 0x03, 0x12, 0x10,
-	0x0a, 0x0c, 0x49, 0x4e, 0x56, 0x4f, 0x49, 0x43, 0x45, 0x5f, 0x50, 0x41, 0x49, 0x44, 0x10, 0x04,
-	0x12, 0x20, 0x0a, 0x1c, 0x43, 0x4f, 0x4e, 0x54, 0x52, 0x41, 0x43, 0x54, 0x5f, 0x43, 0x4c, 0x41,
-	0x49, 0x4d, 0x45, 0x44, 0x5f, 0x55, 0x4e, 0x43, 0x4f, 0x4e, 0x46, 0x49, 0x52, 0x4d, 0x45, 0x44,
-	0x10, 0x05, 0x12, 0x08, 0x0a, 0x04, 0x44, 0x4f, 0x4e, 0x45, 0x10, 0x06, 0x12, 0x21, 0x0a, 0x1d,
-	0x43, 0x4f, 0x4e, 0x54, 0x52, 0x41, 0x43, 0x54, 0x5f, 0x52, 0x45, 0x46, 0x55, 0x4e, 0x44, 0x45,
-	0x44, 0x5f, 0x55, 0x4e, 0x43, 0x4f, 0x4e, 0x46, 0x49, 0x52, 0x4d, 0x45, 0x44, 0x10, 0x07, 0x12,
-	0x14, 0x0a, 0x10, 0x43, 0x4f, 0x4e, 0x54, 0x52, 0x41, 0x43, 0x54, 0x5f, 0x45, 0x58, 0x50, 0x49,
-	0x52, 0x45, 0x44, 0x10, 0x08, 0x32, 0xb2, 0x02, 0x0a, 0x0b, 0x53, 0x77, 0x61, 0x70, 0x53, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x29, 0x0a, 0x06, 0x53, 0x77, 0x61, 0x70, 0x49, 0x6e, 0x12,
-	0x0e, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x49, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x0f, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x49, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x2c, 0x0a, 0x07, 0x53, 0x77, 0x61, 0x70, 0x4f, 0x75, 0x74, 0x12, 0x0f, 0x2e, 0x53, 0x77,
-	0x61, 0x70, 0x4f, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x53,
-	0x77, 0x61, 0x70, 0x4f, 0x75, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32,
-	0x0a, 0x09, 0x47, 0x65, 0x74, 0x53, 0x77, 0x61, 0x70, 0x49, 0x6e, 0x12, 0x11, 0x2e, 0x47, 0x65,
-	0x74, 0x53, 0x77, 0x61, 0x70, 0x49, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12,
-	0x2e, 0x47, 0x65, 0x74, 0x53, 0x77, 0x61, 0x70, 0x49, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x35, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x53, 0x77, 0x61, 0x70, 0x4f, 0x75, 0x74,
-	0x12, 0x12, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x77, 0x61, 0x70, 0x4f, 0x75, 0x74, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x77, 0x61, 0x70, 0x4f, 0x75,
-	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5f, 0x0a, 0x18, 0x52, 0x65, 0x63,
-	0x6f, 0x76, 0x65, 0x72, 0x52, 0x65, 0x75, 0x73, 0x65, 0x64, 0x53, 0x77, 0x61, 0x70, 0x41, 0x64,
-	0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x20, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x52,
+func (x *ReopenSwapRequest) Reset() {
+	*x = ReopenSwapRequest{}
+	mi := &file__40swapd_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReopenSwapRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReopenSwapRequest) ProtoMessage() {}
+
+func (x *ReopenSwapRequest) ProtoReflect() protoreflect.Message {
+	mi := &file__40swapd_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReopenSwapRequest.ProtoReflect.Descriptor instead.
+func (*ReopenSwapRequest) Descriptor() ([]byte, []int) {
+	return file__40swapd_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ReopenSwapRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ReopenSwapResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                      // Unique identifier for the swap.
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`                  // Type of the swap (IN or OUT).
+	Status        Status                 `protobuf:"varint,3,opt,name=status,proto3,enum=Status" json:"status,omitempty"` // Status the swap was reopened with.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReopenSwapResponse) Reset() {
+	*x = ReopenSwapResponse{}
+	mi := &file__40swapd_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReopenSwapResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReopenSwapResponse) ProtoMessage() {}
+
+func (x *ReopenSwapResponse) ProtoReflect() protoreflect.Message {
+	mi := &file__40swapd_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReopenSwapResponse.ProtoReflect.Descriptor instead.
+func (*ReopenSwapResponse) Descriptor() ([]byte, []int) {
+	return file__40swapd_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ReopenSwapResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ReopenSwapResponse) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ReopenSwapResponse) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_CREATED
+}
+
+// Message definitions for cancelling a swap.
+type CancelSwapRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // Unique identifier for the swap.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelSwapRequest) Reset() {
+	*x = CancelSwapRequest{}
+	mi := &file__40swapd_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelSwapRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelSwapRequest) ProtoMessage() {}
+
+func (x *CancelSwapRequest) ProtoReflect() protoreflect.Message {
+	mi := &file__40swapd_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelSwapRequest.ProtoReflect.Descriptor instead.
+func (*CancelSwapRequest) Descriptor() ([]byte, []int) {
+	return file__40swapd_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CancelSwapRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CancelSwapResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                      // Unique identifier for the swap.
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`                  // Type of the swap (IN or OUT).
+	Status        Status                 `protobuf:"varint,3,opt,name=status,proto3,enum=Status" json:"status,omitempty"` // Status of the swap once cancelled.
+	Outcome       string                 `protobuf:"bytes,4,opt,name=outcome,proto3" json:"outcome,omitempty"`            // Outcome of the swap once cancelled.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelSwapResponse) Reset() {
+	*x = CancelSwapResponse{}
+	mi := &file__40swapd_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelSwapResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelSwapResponse) ProtoMessage() {}
+
+func (x *CancelSwapResponse) ProtoReflect() protoreflect.Message {
+	mi := &file__40swapd_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelSwapResponse.ProtoReflect.Descriptor instead.
+func (*CancelSwapResponse) Descriptor() ([]byte, []int) {
+	return file__40swapd_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CancelSwapResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CancelSwapResponse) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CancelSwapResponse) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_CREATED
+}
+
+func (x *CancelSwapResponse) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+// Message definitions for querying the history of a swap.
+type GetSwapTimelineRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // Unique identifier for the swap.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSwapTimelineRequest) Reset() {
+	*x = GetSwapTimelineRequest{}
+	mi := &file__40swapd_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSwapTimelineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSwapTimelineRequest) ProtoMessage() {}
+
+func (x *GetSwapTimelineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file__40swapd_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSwapTimelineRequest.ProtoReflect.Descriptor instead.
+func (*GetSwapTimelineRequest) Descriptor() ([]byte, []int) {
+	return file__40swapd_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetSwapTimelineRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type SwapEvent struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`                       // Timestamp when the event happened.
+	Type            string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`                                                  // Type of the swap (IN or OUT).
+	FromStatus      *Status                `protobuf:"varint,3,opt,name=from_status,json=fromStatus,proto3,enum=Status,oneof" json:"from_status,omitempty"` // Status before the event, unset when the swap was created.
+	ToStatus        Status                 `protobuf:"varint,4,opt,name=to_status,json=toStatus,proto3,enum=Status" json:"to_status,omitempty"`             // Status after the event.
+	Outcome         *string                `protobuf:"bytes,5,opt,name=outcome,proto3,oneof" json:"outcome,omitempty"`                                      // Outcome of the swap after the event.
+	LockTxId        *string                `protobuf:"bytes,6,opt,name=lock_tx_id,json=lockTxId,proto3,oneof" json:"lock_tx_id,omitempty"`                  // Lock transaction txid.
+	ClaimTxId       *string                `protobuf:"bytes,7,opt,name=claim_tx_id,json=claimTxId,proto3,oneof" json:"claim_tx_id,omitempty"`               // Claim transaction txid.
+	RefundTxId      *string                `protobuf:"bytes,8,opt,name=refund_tx_id,json=refundTxId,proto3,oneof" json:"refund_tx_id,omitempty"`            // Refund transaction txid.
+	ServiceFeeSats  uint64                 `protobuf:"varint,9,opt,name=service_fee_sats,json=serviceFeeSats,proto3" json:"service_fee_sats,omitempty"`     // Service fee in satoshis.
+	OnchainFeeSats  uint64                 `protobuf:"varint,10,opt,name=onchain_fee_sats,json=onchainFeeSats,proto3" json:"onchain_fee_sats,omitempty"`    // On-chain fee in satoshis.
+	OffchainFeeSats uint64                 `protobuf:"varint,11,opt,name=offchain_fee_sats,json=offchainFeeSats,proto3" json:"offchain_fee_sats,omitempty"` // Off-chain (routing) fee in satoshis.
+	Message         *string                `protobuf:"bytes,12,opt,name=message,proto3,oneof" json:"message,omitempty"`                                     // Why the event happened.
+	Error           *string                `protobuf:"bytes,13,opt,name=error,proto3,oneof" json:"error,omitempty"`                                         // Error that caused the event, if any.
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SwapEvent) Reset() {
+	*x = SwapEvent{}
+	mi := &file__40swapd_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SwapEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SwapEvent) ProtoMessage() {}
+
+func (x *SwapEvent) ProtoReflect() protoreflect.Message {
+	mi := &file__40swapd_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SwapEvent.ProtoReflect.Descriptor instead.
+func (*SwapEvent) Descriptor() ([]byte, []int) {
+	return file__40swapd_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SwapEvent) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *SwapEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *SwapEvent) GetFromStatus() Status {
+	if x != nil && x.FromStatus != nil {
+		return *x.FromStatus
+	}
+	return Status_CREATED
+}
+
+func (x *SwapEvent) GetToStatus() Status {
+	if x != nil {
+		return x.ToStatus
+	}
+	return Status_CREATED
+}
+
+func (x *SwapEvent) GetOutcome() string {
+	if x != nil && x.Outcome != nil {
+		return *x.Outcome
+	}
+	return ""
+}
+
+func (x *SwapEvent) GetLockTxId() string {
+	if x != nil && x.LockTxId != nil {
+		return *x.LockTxId
+	}
+	return ""
+}
+
+func (x *SwapEvent) GetClaimTxId() string {
+	if x != nil && x.ClaimTxId != nil {
+		return *x.ClaimTxId
+	}
+	return ""
+}
+
+func (x *SwapEvent) GetRefundTxId() string {
+	if x != nil && x.RefundTxId != nil {
+		return *x.RefundTxId
+	}
+	return ""
+}
+
+func (x *SwapEvent) GetServiceFeeSats() uint64 {
+	if x != nil {
+		return x.ServiceFeeSats
+	}
+	return 0
+}
+
+func (x *SwapEvent) GetOnchainFeeSats() uint64 {
+	if x != nil {
+		return x.OnchainFeeSats
+	}
+	return 0
+}
+
+func (x *SwapEvent) GetOffchainFeeSats() uint64 {
+	if x != nil {
+		return x.OffchainFeeSats
+	}
+	return 0
+}
+
+func (x *SwapEvent) GetMessage() string {
+	if x != nil && x.Message != nil {
+		return *x.Message
+	}
+	return ""
+}
+
+func (x *SwapEvent) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+type GetSwapTimelineResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`         // Unique identifier for the swap.
+	Events        []*SwapEvent           `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"` // Events of the swap, oldest first.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSwapTimelineResponse) Reset() {
+	*x = GetSwapTimelineResponse{}
+	mi := &file__40swapd_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSwapTimelineResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSwapTimelineResponse) ProtoMessage() {}
+
+func (x *GetSwapTimelineResponse) ProtoReflect() protoreflect.Message {
+	mi := &file__40swapd_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSwapTimelineResponse.ProtoReflect.Descriptor instead.
+func (*GetSwapTimelineResponse) Descriptor() ([]byte, []int) {
+	return file__40swapd_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetSwapTimelineResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetSwapTimelineResponse) GetEvents() []*SwapEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+// Message definitions for quoting a SwapIn.
+type QuoteSwapInRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Chain         Chain                  `protobuf:"varint,1,opt,name=chain,proto3,enum=Chain" json:"chain,omitempty"`                  // Blockchain chain for the swap.
+	AmountSats    uint64                 `protobuf:"varint,2,opt,name=amount_sats,json=amountSats,proto3" json:"amount_sats,omitempty"` // Amount in satoshis to receive over lightning.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuoteSwapInRequest) Reset() {
+	*x = QuoteSwapInRequest{}
+	mi := &file__40swapd_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuoteSwapInRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuoteSwapInRequest) ProtoMessage() {}
+
+func (x *QuoteSwapInRequest) ProtoReflect() protoreflect.Message {
+	mi := &file__40swapd_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuoteSwapInRequest.ProtoReflect.Descriptor instead.
+func (*QuoteSwapInRequest) Descriptor() ([]byte, []int) {
+	return file__40swapd_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *QuoteSwapInRequest) GetChain() Chain {
+	if x != nil {
+		return x.Chain
+	}
+	return Chain_BITCOIN
+}
+
+func (x *QuoteSwapInRequest) GetAmountSats() uint64 {
+	if x != nil {
+		return x.AmountSats
+	}
+	return 0
+}
+
+type QuoteSwapInResponse struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	AmountSats          uint64                 `protobuf:"varint,1,opt,name=amount_sats,json=amountSats,proto3" json:"amount_sats,omitempty"`                                  // Amount in satoshis to receive over lightning.
+	ServiceFeeSats      uint64                 `protobuf:"varint,2,opt,name=service_fee_sats,json=serviceFeeSats,proto3" json:"service_fee_sats,omitempty"`                    // Fee charged by the swap service.
+	ServiceFeePercent   float64                `protobuf:"fixed64,3,opt,name=service_fee_percent,json=serviceFeePercent,proto3" json:"service_fee_percent,omitempty"`          // Percentage of the amount charged by the swap service.
+	OnchainFeeSats      uint64                 `protobuf:"varint,4,opt,name=onchain_fee_sats,json=onchainFeeSats,proto3" json:"onchain_fee_sats,omitempty"`                    // Estimated fee for the server to claim the contract.
+	SendAmountSats      uint64                 `protobuf:"varint,5,opt,name=send_amount_sats,json=sendAmountSats,proto3" json:"send_amount_sats,omitempty"`                    // Estimated amount to send to the contract address.
+	RefundFeeSats       uint64                 `protobuf:"varint,6,opt,name=refund_fee_sats,json=refundFeeSats,proto3" json:"refund_fee_sats,omitempty"`                       // Estimated fee to refund the contract if the swap fails.
+	FeeRateSatsPerVbyte int64                  `protobuf:"varint,7,opt,name=fee_rate_sats_per_vbyte,json=feeRateSatsPerVbyte,proto3" json:"fee_rate_sats_per_vbyte,omitempty"` // Fee rate the on-chain fees are estimated with.
+	MinAmountSats       uint64                 `protobuf:"varint,8,opt,name=min_amount_sats,json=minAmountSats,proto3" json:"min_amount_sats,omitempty"`                       // Minimum amount the server accepts.
+	MaxAmountSats       uint64                 `protobuf:"varint,9,opt,name=max_amount_sats,json=maxAmountSats,proto3" json:"max_amount_sats,omitempty"`                       // Maximum amount the server accepts.
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *QuoteSwapInResponse) Reset() {
+	*x = QuoteSwapInResponse{}
+	mi := &file__40swapd_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuoteSwapInResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuoteSwapInResponse) ProtoMessage() {}
+
+func (x *QuoteSwapInResponse) ProtoReflect() protoreflect.Message {
+	mi := &file__40swapd_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuoteSwapInResponse.ProtoReflect.Descriptor instead.
+func (*QuoteSwapInResponse) Descriptor() ([]byte, []int) {
+	return file__40swapd_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *QuoteSwapInResponse) GetAmountSats() uint64 {
+	if x != nil {
+		return x.AmountSats
+	}
+	return 0
+}
+
+func (x *QuoteSwapInResponse) GetServiceFeeSats() uint64 {
+	if x != nil {
+		return x.ServiceFeeSats
+	}
+	return 0
+}
+
+func (x *QuoteSwapInResponse) GetServiceFeePercent() float64 {
+	if x != nil {
+		return x.ServiceFeePercent
+	}
+	return 0
+}
+
+func (x *QuoteSwapInResponse) GetOnchainFeeSats() uint64 {
+	if x != nil {
+		return x.OnchainFeeSats
+	}
+	return 0
+}
+
+func (x *QuoteSwapInResponse) GetSendAmountSats() uint64 {
+	if x != nil {
+		return x.SendAmountSats
+	}
+	return 0
+}
+
+func (x *QuoteSwapInResponse) GetRefundFeeSats() uint64 {
+	if x != nil {
+		return x.RefundFeeSats
+	}
+	return 0
+}
+
+func (x *QuoteSwapInResponse) GetFeeRateSatsPerVbyte() int64 {
+	if x != nil {
+		return x.FeeRateSatsPerVbyte
+	}
+	return 0
+}
+
+func (x *QuoteSwapInResponse) GetMinAmountSats() uint64 {
+	if x != nil {
+		return x.MinAmountSats
+	}
+	return 0
+}
+
+func (x *QuoteSwapInResponse) GetMaxAmountSats() uint64 {
+	if x != nil {
+		return x.MaxAmountSats
+	}
+	return 0
+}
+
+// Message definitions for quoting a SwapOut.
+type QuoteSwapOutRequest struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Chain                Chain                  `protobuf:"varint,1,opt,name=chain,proto3,enum=Chain" json:"chain,omitempty"`                                                           // Blockchain chain for the swap.
+	AmountSats           uint64                 `protobuf:"varint,2,opt,name=amount_sats,json=amountSats,proto3" json:"amount_sats,omitempty"`                                          // Amount in satoshis to send over lightning.
+	MaxRoutingFeePercent *float32               `protobuf:"fixed32,3,opt,name=max_routing_fee_percent,json=maxRoutingFeePercent,proto3,oneof" json:"max_routing_fee_percent,omitempty"` // Maximum routing fee in percentage for the lightning network.
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *QuoteSwapOutRequest) Reset() {
+	*x = QuoteSwapOutRequest{}
+	mi := &file__40swapd_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuoteSwapOutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuoteSwapOutRequest) ProtoMessage() {}
+
+func (x *QuoteSwapOutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file__40swapd_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuoteSwapOutRequest.ProtoReflect.Descriptor instead.
+func (*QuoteSwapOutRequest) Descriptor() ([]byte, []int) {
+	return file__40swapd_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *QuoteSwapOutRequest) GetChain() Chain {
+	if x != nil {
+		return x.Chain
+	}
+	return Chain_BITCOIN
+}
+
+func (x *QuoteSwapOutRequest) GetAmountSats() uint64 {
+	if x != nil {
+		return x.AmountSats
+	}
+	return 0
+}
+
+func (x *QuoteSwapOutRequest) GetMaxRoutingFeePercent() float32 {
+	if x != nil && x.MaxRoutingFeePercent != nil {
+		return *x.MaxRoutingFeePercent
+	}
+	return 0
+}
+
+type QuoteSwapOutResponse struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	AmountSats          uint64                 `protobuf:"varint,1,opt,name=amount_sats,json=amountSats,proto3" json:"amount_sats,omitempty"`                                  // Amount in satoshis to send over lightning.
+	ServiceFeeSats      uint64                 `protobuf:"varint,2,opt,name=service_fee_sats,json=serviceFeeSats,proto3" json:"service_fee_sats,omitempty"`                    // Fee charged by the swap service.
+	ServiceFeePercent   float64                `protobuf:"fixed64,3,opt,name=service_fee_percent,json=serviceFeePercent,proto3" json:"service_fee_percent,omitempty"`          // Percentage of the amount charged by the swap service.
+	ClaimFeeSats        uint64                 `protobuf:"varint,4,opt,name=claim_fee_sats,json=claimFeeSats,proto3" json:"claim_fee_sats,omitempty"`                          // Estimated fee to claim the contract.
+	MaxRoutingFeeSats   uint64                 `protobuf:"varint,5,opt,name=max_routing_fee_sats,json=maxRoutingFeeSats,proto3" json:"max_routing_fee_sats,omitempty"`         // Maximum fee paid to route the lightning payment.
+	ReceiveAmountSats   uint64                 `protobuf:"varint,6,opt,name=receive_amount_sats,json=receiveAmountSats,proto3" json:"receive_amount_sats,omitempty"`           // Estimated amount received on-chain.
+	MaxTotalFeeSats     uint64                 `protobuf:"varint,7,opt,name=max_total_fee_sats,json=maxTotalFeeSats,proto3" json:"max_total_fee_sats,omitempty"`               // Service, claim and maximum routing fees together.
+	FeeRateSatsPerVbyte int64                  `protobuf:"varint,8,opt,name=fee_rate_sats_per_vbyte,json=feeRateSatsPerVbyte,proto3" json:"fee_rate_sats_per_vbyte,omitempty"` // Fee rate the on-chain fees are estimated with.
+	MinAmountSats       uint64                 `protobuf:"varint,9,opt,name=min_amount_sats,json=minAmountSats,proto3" json:"min_amount_sats,omitempty"`                       // Minimum amount the server accepts.
+	MaxAmountSats       uint64                 `protobuf:"varint,10,opt,name=max_amount_sats,json=maxAmountSats,proto3" json:"max_amount_sats,omitempty"`                      // Maximum amount the server accepts.
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *QuoteSwapOutResponse) Reset() {
+	*x = QuoteSwapOutResponse{}
+	mi := &file__40swapd_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuoteSwapOutResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuoteSwapOutResponse) ProtoMessage() {}
+
+func (x *QuoteSwapOutResponse) ProtoReflect() protoreflect.Message {
+	mi := &file__40swapd_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuoteSwapOutResponse.ProtoReflect.Descriptor instead.
+func (*QuoteSwapOutResponse) Descriptor() ([]byte, []int) {
+	return file__40swapd_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *QuoteSwapOutResponse) GetAmountSats() uint64 {
+	if x != nil {
+		return x.AmountSats
+	}
+	return 0
+}
+
+func (x *QuoteSwapOutResponse) GetServiceFeeSats() uint64 {
+	if x != nil {
+		return x.ServiceFeeSats
+	}
+	return 0
+}
+
+func (x *QuoteSwapOutResponse) GetServiceFeePercent() float64 {
+	if x != nil {
+		return x.ServiceFeePercent
+	}
+	return 0
+}
+
+func (x *QuoteSwapOutResponse) GetClaimFeeSats() uint64 {
+	if x != nil {
+		return x.ClaimFeeSats
+	}
+	return 0
+}
+
+func (x *QuoteSwapOutResponse) GetMaxRoutingFeeSats() uint64 {
+	if x != nil {
+		return x.MaxRoutingFeeSats
+	}
+	return 0
+}
+
+func (x *QuoteSwapOutResponse) GetReceiveAmountSats() uint64 {
+	if x != nil {
+		return x.ReceiveAmountSats
+	}
+	return 0
+}
+
+func (x *QuoteSwapOutResponse) GetMaxTotalFeeSats() uint64 {
+	if x != nil {
+		return x.MaxTotalFeeSats
+	}
+	return 0
+}
+
+func (x *QuoteSwapOutResponse) GetFeeRateSatsPerVbyte() int64 {
+	if x != nil {
+		return x.FeeRateSatsPerVbyte
+	}
+	return 0
+}
+
+func (x *QuoteSwapOutResponse) GetMinAmountSats() uint64 {
+	if x != nil {
+		return x.MinAmountSats
+	}
+	return 0
+}
+
+func (x *QuoteSwapOutResponse) GetMaxAmountSats() uint64 {
+	if x != nil {
+		return x.MaxAmountSats
+	}
+	return 0
+}
+
+// Message definitions for exporting swaps.
+type ExportSwapsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`                        // Export swaps created at or after this time, all of them when unset.
+	To            *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`                            // Export swaps created before this time, up to now when unset.
+	Period        ReportPeriod           `protobuf:"varint,3,opt,name=period,proto3,enum=ReportPeriod" json:"period,omitempty"` // Period the totals are aggregated by.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportSwapsRequest) Reset() {
+	*x = ExportSwapsRequest{}
+	mi := &file__40swapd_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportSwapsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportSwapsRequest) ProtoMessage() {}
+
+func (x *ExportSwapsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file__40swapd_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportSwapsRequest.ProtoReflect.Descriptor instead.
+func (*ExportSwapsRequest) Descriptor() ([]byte, []int) {
+	return file__40swapd_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ExportSwapsRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *ExportSwapsRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *ExportSwapsRequest) GetPeriod() ReportPeriod {
+	if x != nil {
+		return x.Period
+	}
+	return ReportPeriod_DAY
+}
+
+type SwapReport struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                                     // Unique identifier for the swap.
+	Type            string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`                                                 // Type of the swap (IN or OUT).
+	Status          Status                 `protobuf:"varint,3,opt,name=status,proto3,enum=Status" json:"status,omitempty"`                                // Status of the swap.
+	Outcome         string                 `protobuf:"bytes,4,opt,name=outcome,proto3" json:"outcome,omitempty"`                                           // Outcome of the swap.
+	AmountSats      uint64                 `protobuf:"varint,5,opt,name=amount_sats,json=amountSats,proto3" json:"amount_sats,omitempty"`                  // Amount in satoshis.
+	ServiceFeeSats  uint64                 `protobuf:"varint,6,opt,name=service_fee_sats,json=serviceFeeSats,proto3" json:"service_fee_sats,omitempty"`    // Service fee in satoshis.
+	OnchainFeeSats  uint64                 `protobuf:"varint,7,opt,name=onchain_fee_sats,json=onchainFeeSats,proto3" json:"onchain_fee_sats,omitempty"`    // On-chain fee in satoshis.
+	OffchainFeeSats uint64                 `protobuf:"varint,8,opt,name=offchain_fee_sats,json=offchainFeeSats,proto3" json:"offchain_fee_sats,omitempty"` // Off-chain (routing) fee in satoshis.
+	TotalFeeSats    uint64                 `protobuf:"varint,9,opt,name=total_fee_sats,json=totalFeeSats,proto3" json:"total_fee_sats,omitempty"`          // Service, on-chain and off-chain fees together.
+	LockTxId        *string                `protobuf:"bytes,10,opt,name=lock_tx_id,json=lockTxId,proto3,oneof" json:"lock_tx_id,omitempty"`                // Lock transaction txid.
+	ClaimTxId       *string                `protobuf:"bytes,11,opt,name=claim_tx_id,json=claimTxId,proto3,oneof" json:"claim_tx_id,omitempty"`             // Claim transaction txid.
+	RefundTxId      *string                `protobuf:"bytes,12,opt,name=refund_tx_id,json=refundTxId,proto3,oneof" json:"refund_tx_id,omitempty"`          // Refund transaction txid.
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`                     // Timestamp when the swap was created.
+	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`                     // Timestamp when the swap was last updated.
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SwapReport) Reset() {
+	*x = SwapReport{}
+	mi := &file__40swapd_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SwapReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SwapReport) ProtoMessage() {}
+
+func (x *SwapReport) ProtoReflect() protoreflect.Message {
+	mi := &file__40swapd_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SwapReport.ProtoReflect.Descriptor instead.
+func (*SwapReport) Descriptor() ([]byte, []int) {
+	return file__40swapd_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SwapReport) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SwapReport) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *SwapReport) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_CREATED
+}
+
+func (x *SwapReport) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+func (x *SwapReport) GetAmountSats() uint64 {
+	if x != nil {
+		return x.AmountSats
+	}
+	return 0
+}
+
+func (x *SwapReport) GetServiceFeeSats() uint64 {
+	if x != nil {
+		return x.ServiceFeeSats
+	}
+	return 0
+}
+
+func (x *SwapReport) GetOnchainFeeSats() uint64 {
+	if x != nil {
+		return x.OnchainFeeSats
+	}
+	return 0
+}
+
+func (x *SwapReport) GetOffchainFeeSats() uint64 {
+	if x != nil {
+		return x.OffchainFeeSats
+	}
+	return 0
+}
+
+func (x *SwapReport) GetTotalFeeSats() uint64 {
+	if x != nil {
+		return x.TotalFeeSats
+	}
+	return 0
+}
+
+func (x *SwapReport) GetLockTxId() string {
+	if x != nil && x.LockTxId != nil {
+		return *x.LockTxId
+	}
+	return ""
+}
+
+func (x *SwapReport) GetClaimTxId() string {
+	if x != nil && x.ClaimTxId != nil {
+		return *x.ClaimTxId
+	}
+	return ""
+}
+
+func (x *SwapReport) GetRefundTxId() string {
+	if x != nil && x.RefundTxId != nil {
+		return *x.RefundTxId
+	}
+	return ""
+}
+
+func (x *SwapReport) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *SwapReport) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type SwapReportTotal struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Period          string                 `protobuf:"bytes,1,opt,name=period,proto3" json:"period,omitempty"`                                             // First day of the period, as YYYY-MM-DD in UTC.
+	Type            string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`                                                 // Type of the swaps (IN or OUT).
+	Count           uint32                 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`                                              // Number of swaps.
+	AmountSats      uint64                 `protobuf:"varint,4,opt,name=amount_sats,json=amountSats,proto3" json:"amount_sats,omitempty"`                  // Amount in satoshis.
+	ServiceFeeSats  uint64                 `protobuf:"varint,5,opt,name=service_fee_sats,json=serviceFeeSats,proto3" json:"service_fee_sats,omitempty"`    // Service fees in satoshis.
+	OnchainFeeSats  uint64                 `protobuf:"varint,6,opt,name=onchain_fee_sats,json=onchainFeeSats,proto3" json:"onchain_fee_sats,omitempty"`    // On-chain fees in satoshis.
+	OffchainFeeSats uint64                 `protobuf:"varint,7,opt,name=offchain_fee_sats,json=offchainFeeSats,proto3" json:"offchain_fee_sats,omitempty"` // Off-chain (routing) fees in satoshis.
+	TotalFeeSats    uint64                 `protobuf:"varint,8,opt,name=total_fee_sats,json=totalFeeSats,proto3" json:"total_fee_sats,omitempty"`          // Service, on-chain and off-chain fees together.
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SwapReportTotal) Reset() {
+	*x = SwapReportTotal{}
+	mi := &file__40swapd_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SwapReportTotal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SwapReportTotal) ProtoMessage() {}
+
+func (x *SwapReportTotal) ProtoReflect() protoreflect.Message {
+	mi := &file__40swapd_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SwapReportTotal.ProtoReflect.Descriptor instead.
+func (*SwapReportTotal) Descriptor() ([]byte, []int) {
+	return file__40swapd_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SwapReportTotal) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *SwapReportTotal) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *SwapReportTotal) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *SwapReportTotal) GetAmountSats() uint64 {
+	if x != nil {
+		return x.AmountSats
+	}
+	return 0
+}
+
+func (x *SwapReportTotal) GetServiceFeeSats() uint64 {
+	if x != nil {
+		return x.ServiceFeeSats
+	}
+	return 0
+}
+
+func (x *SwapReportTotal) GetOnchainFeeSats() uint64 {
+	if x != nil {
+		return x.OnchainFeeSats
+	}
+	return 0
+}
+
+func (x *SwapReportTotal) GetOffchainFeeSats() uint64 {
+	if x != nil {
+		return x.OffchainFeeSats
+	}
+	return 0
+}
+
+func (x *SwapReportTotal) GetTotalFeeSats() uint64 {
+	if x != nil {
+		return x.TotalFeeSats
+	}
+	return 0
+}
+
+type ExportSwapsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Swaps         []*SwapReport          `protobuf:"bytes,1,rep,name=swaps,proto3" json:"swaps,omitempty"`   // Swaps, oldest first.
+	Totals        []*SwapReportTotal     `protobuf:"bytes,2,rep,name=totals,proto3" json:"totals,omitempty"` // Totals per period and type, oldest period first.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportSwapsResponse) Reset() {
+	*x = ExportSwapsResponse{}
+	mi := &file__40swapd_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportSwapsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportSwapsResponse) ProtoMessage() {}
+
+func (x *ExportSwapsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file__40swapd_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportSwapsResponse.ProtoReflect.Descriptor instead.
+func (*ExportSwapsResponse) Descriptor() ([]byte, []int) {
+	return file__40swapd_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ExportSwapsResponse) GetSwaps() []*SwapReport {
+	if x != nil {
+		return x.Swaps
+	}
+	return nil
+}
+
+func (x *ExportSwapsResponse) GetTotals() []*SwapReportTotal {
+	if x != nil {
+		return x.Totals
+	}
+	return nil
+}
+
+var File__40swapd_proto protoreflect.FileDescriptor
+
+var file__40swapd_proto_rawDesc = string([]byte{
+	0x0a, 0x0d, 0x34, 0x30, 0x73, 0x77, 0x61, 0x70, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x22, 0xb0, 0x02, 0x0a, 0x0d, 0x53, 0x77, 0x61, 0x70, 0x49, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1c, 0x0a, 0x05, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x06, 0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x52, 0x05, 0x63, 0x68, 0x61, 0x69, 0x6e,
+	0x12, 0x1d, 0x0a, 0x07, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x48, 0x00, 0x52, 0x07, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x88, 0x01, 0x01, 0x12,
+	0x24, 0x0a, 0x0b, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x73, 0x61, 0x74, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x04, 0x48, 0x01, 0x52, 0x0a, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x53, 0x61,
+	0x74, 0x73, 0x88, 0x01, 0x01, 0x12, 0x1b, 0x0a, 0x06, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x02, 0x52, 0x06, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79, 0x88,
+	0x01, 0x01, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x5f, 0x74, 0x6f, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x54, 0x6f, 0x12,
+	0x2f, 0x0a, 0x11, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x5f, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x48, 0x03, 0x52, 0x0f, 0x63, 0x6c,
+	0x69, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x88, 0x01, 0x01,
+	0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x69, 0x6e, 0x76, 0x6f, 0x69,
+	0x63, 0x65, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x73, 0x61,
+	0x74, 0x73, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79, 0x42, 0x14, 0x0a,
+	0x12, 0x5f, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x5f, 0x69, 0x64, 0x22, 0x96, 0x01, 0x0a, 0x0e, 0x53, 0x77, 0x61, 0x70, 0x49, 0x6e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x77, 0x61, 0x70, 0x49, 0x64, 0x12,
+	0x1f, 0x0a, 0x0b, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x73, 0x61, 0x74, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x53, 0x61, 0x74, 0x73,
+	0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x41, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x5f,
+	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x72,
+	0x65, 0x66, 0x75, 0x6e, 0x64, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0x9e, 0x02, 0x0a,
+	0x0e, 0x53, 0x77, 0x61, 0x70, 0x4f, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1c, 0x0a, 0x05, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x06,
+	0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x52, 0x05, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x12, 0x1f, 0x0a,
+	0x0b, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x73, 0x61, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0a, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x53, 0x61, 0x74, 0x73, 0x12, 0x18,
+	0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x3a, 0x0a, 0x17, 0x6d, 0x61, 0x78, 0x5f,
+	0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x63,
+	0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x02, 0x48, 0x00, 0x52, 0x14, 0x6d, 0x61, 0x78,
+	0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x46, 0x65, 0x65, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e,
+	0x74, 0x88, 0x01, 0x01, 0x12, 0x2f, 0x0a, 0x11, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x72,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x48,
+	0x01, 0x52, 0x0f, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x42, 0x1a, 0x0a, 0x18, 0x5f,
+	0x6d, 0x61, 0x78, 0x5f, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x66, 0x65, 0x65, 0x5f,
+	0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x42, 0x14, 0x0a, 0x12, 0x5f, 0x63, 0x6c, 0x69, 0x65,
+	0x6e, 0x74, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x22, 0x4b, 0x0a,
+	0x0f, 0x53, 0x77, 0x61, 0x70, 0x4f, 0x75, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x17, 0x0a, 0x07, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x77, 0x61, 0x70, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x5f, 0x73, 0x61, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a,
+	0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x53, 0x61, 0x74, 0x73, 0x22, 0x22, 0x0a, 0x10, 0x47, 0x65,
+	0x74, 0x53, 0x77, 0x61, 0x70, 0x49, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0xdf,
+	0x05, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x53, 0x77, 0x61, 0x70, 0x49, 0x6e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74,
+	0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f,
+	0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12,
+	0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x69, 0x6e,
+	0x70, 0x75, 0x74, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x0b, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x21, 0x0a,
+	0x0a, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x74, 0x78, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x48, 0x00, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x78, 0x49, 0x64, 0x88, 0x01, 0x01,
+	0x12, 0x1d, 0x0a, 0x07, 0x6f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x48, 0x01, 0x52, 0x07, 0x6f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x88, 0x01, 0x01, 0x12,
+	0x23, 0x0a, 0x0d, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x41, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x64, 0x65, 0x65, 0x6d, 0x5f, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x64,
+	0x65, 0x65, 0x6d, 0x53, 0x63, 0x72, 0x69, 0x70, 0x74, 0x12, 0x1f, 0x0a, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x07, 0x2e, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x30, 0x0a, 0x14, 0x74, 0x69,
+	0x6d, 0x65, 0x6f, 0x75, 0x74, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x68, 0x65, 0x69, 0x67,
+	0x68, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x12, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75,
+	0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x20, 0x0a, 0x09,
+	0x70, 0x72, 0x65, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x48,
+	0x02, 0x52, 0x08, 0x70, 0x72, 0x65, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x88, 0x01, 0x01, 0x12, 0x25,
+	0x0a, 0x0c, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x5f, 0x74, 0x78, 0x5f, 0x69, 0x64, 0x18, 0x0c,
+	0x20, 0x01, 0x28, 0x09, 0x48, 0x03, 0x52, 0x0a, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x54, 0x78,
+	0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x28, 0x0a, 0x10, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x5f, 0x66, 0x65, 0x65, 0x5f, 0x73, 0x61, 0x74, 0x73, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x46, 0x65, 0x65, 0x53, 0x61, 0x74, 0x73, 0x12,
+	0x28, 0x0a, 0x10, 0x6f, 0x6e, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x73,
+	0x61, 0x74, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x6f, 0x6e, 0x63, 0x68, 0x61,
+	0x69, 0x6e, 0x46, 0x65, 0x65, 0x53, 0x61, 0x74, 0x73, 0x12, 0x1e, 0x0a, 0x04, 0x66, 0x69, 0x61,
+	0x74, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x46, 0x69, 0x61, 0x74, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x52, 0x04, 0x66, 0x69, 0x61, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62,
+	0x65, 0x6c, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12,
+	0x2f, 0x0a, 0x11, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x5f, 0x69, 0x64, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x48, 0x04, 0x52, 0x0f, 0x63, 0x6c,
+	0x69, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x88, 0x01, 0x01,
+	0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x74, 0x78, 0x5f, 0x69, 0x64, 0x42,
+	0x0a, 0x0a, 0x08, 0x5f, 0x6f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x42, 0x0c, 0x0a, 0x0a, 0x5f,
+	0x70, 0x72, 0x65, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x72, 0x65,
+	0x66, 0x75, 0x6e, 0x64, 0x5f, 0x74, 0x78, 0x5f, 0x69, 0x64, 0x42, 0x14, 0x0a, 0x12, 0x5f, 0x63,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64,
+	0x22, 0x23, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x53, 0x77, 0x61, 0x70, 0x4f, 0x75, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x8f, 0x06, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x53, 0x77, 0x61,
+	0x70, 0x4f, 0x75, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x30, 0x0a, 0x14,
+	0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x68, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x12, 0x74, 0x69, 0x6d, 0x65,
+	0x6f, 0x75, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x18,
+	0x0a, 0x07, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x69, 0x6e, 0x70, 0x75,
+	0x74, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b,
+	0x69, 0x6e, 0x70, 0x75, 0x74, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x6f,
+	0x75, 0x74, 0x70, 0x75, 0x74, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x0c, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
+	0x12, 0x1f, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x07, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1d, 0x0a, 0x07,
+	0x6f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52,
+	0x07, 0x6f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x88, 0x01, 0x01, 0x12, 0x23, 0x0a, 0x0b, 0x63,
+	0x6c, 0x61, 0x69, 0x6d, 0x5f, 0x74, 0x78, 0x5f, 0x69, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09,
+	0x48, 0x01, 0x52, 0x09, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x54, 0x78, 0x49, 0x64, 0x88, 0x01, 0x01,
+	0x12, 0x28, 0x0a, 0x10, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x66, 0x65, 0x65, 0x5f,
+	0x73, 0x61, 0x74, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x46, 0x65, 0x65, 0x53, 0x61, 0x74, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x6f, 0x6e,
+	0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x73, 0x61, 0x74, 0x73, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x6f, 0x6e, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x46, 0x65, 0x65,
+	0x53, 0x61, 0x74, 0x73, 0x12, 0x2a, 0x0a, 0x11, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e,
+	0x5f, 0x66, 0x65, 0x65, 0x5f, 0x73, 0x61, 0x74, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0f, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x46, 0x65, 0x65, 0x53, 0x61, 0x74, 0x73,
+	0x12, 0x1e, 0x0a, 0x04, 0x66, 0x69, 0x61, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a,
+	0x2e, 0x46, 0x69, 0x61, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x04, 0x66, 0x69, 0x61, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x2f, 0x0a, 0x11, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x0f, 0x20, 0x01, 0x28,
+	0x09, 0x48, 0x02, 0x52, 0x0f, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x35, 0x0a, 0x0e, 0x70, 0x61, 0x79, 0x6d, 0x65,
+	0x6e, 0x74, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x0e, 0x2e, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
+	0x0d, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x29,
+	0x0a, 0x10, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70,
+	0x74, 0x73, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e,
+	0x74, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x12, 0x28, 0x0a, 0x0d, 0x70, 0x61, 0x79,
+	0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x12, 0x20, 0x01, 0x28, 0x09,
+	0x48, 0x03, 0x52, 0x0c, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x88, 0x01, 0x01, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x6f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x42,
+	0x0e, 0x0a, 0x0c, 0x5f, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x5f, 0x74, 0x78, 0x5f, 0x69, 0x64, 0x42,
+	0x14, 0x0a, 0x12, 0x5f, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x5f, 0x69, 0x64, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e,
+	0x74, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0xb8, 0x01, 0x0a, 0x09, 0x46, 0x69, 0x61, 0x74,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63,
+	0x79, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x04, 0x72, 0x61, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1f, 0x0a,
+	0x0b, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x66, 0x65, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x0a, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x46, 0x65, 0x65, 0x12, 0x1f,
+	0x0a, 0x0b, 0x6f, 0x6e, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x66, 0x65, 0x65, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x0a, 0x6f, 0x6e, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x46, 0x65, 0x65, 0x12,
+	0x21, 0x0a, 0x0c, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x66, 0x65, 0x65, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x46,
+	0x65, 0x65, 0x22, 0x6d, 0x0a, 0x1f, 0x52, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x52, 0x65, 0x75,
+	0x73, 0x65, 0x64, 0x53, 0x77, 0x61, 0x70, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x75, 0x74, 0x70, 0x6f, 0x69, 0x6e,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6f, 0x75, 0x74, 0x70, 0x6f, 0x69, 0x6e,
+	0x74, 0x12, 0x20, 0x0a, 0x09, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x5f, 0x74, 0x6f, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x08, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x54, 0x6f,
+	0x88, 0x01, 0x01, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x5f, 0x74,
+	0x6f, 0x22, 0x61, 0x0a, 0x20, 0x52, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x52, 0x65, 0x75, 0x73,
+	0x65, 0x64, 0x53, 0x77, 0x61, 0x70, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x78, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x78, 0x69, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x72, 0x65, 0x63,
+	0x6f, 0x76, 0x65, 0x72, 0x65, 0x64, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x0f, 0x72, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x65, 0x64, 0x41, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x22, 0x23, 0x0a, 0x11, 0x52, 0x65, 0x6f, 0x70, 0x65, 0x6e, 0x53, 0x77,
+	0x61, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x59, 0x0a, 0x12, 0x52, 0x65, 0x6f,
+	0x70, 0x65, 0x6e, 0x53, 0x77, 0x61, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x12, 0x1f, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x07, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x22, 0x23, 0x0a, 0x11, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x53, 0x77,
+	0x61, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x73, 0x0a, 0x12, 0x43, 0x61, 0x6e,
+	0x63, 0x65, 0x6c, 0x53, 0x77, 0x61, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x12, 0x1f, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x07, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x22, 0x28,
+	0x0a, 0x16, 0x47, 0x65, 0x74, 0x53, 0x77, 0x61, 0x70, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0xd9, 0x04, 0x0a, 0x09, 0x53, 0x77, 0x61,
+	0x70, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x5f, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x2d, 0x0a, 0x0b, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x07, 0x2e, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x48, 0x00, 0x52, 0x0a, 0x66, 0x72, 0x6f, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x88, 0x01, 0x01, 0x12, 0x24, 0x0a, 0x09, 0x74, 0x6f, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x07, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x08, 0x74, 0x6f, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1d, 0x0a, 0x07, 0x6f, 0x75,
+	0x74, 0x63, 0x6f, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x07, 0x6f,
+	0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x88, 0x01, 0x01, 0x12, 0x21, 0x0a, 0x0a, 0x6c, 0x6f, 0x63,
+	0x6b, 0x5f, 0x74, 0x78, 0x5f, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x48, 0x02, 0x52,
+	0x08, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x78, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x23, 0x0a, 0x0b,
+	0x63, 0x6c, 0x61, 0x69, 0x6d, 0x5f, 0x74, 0x78, 0x5f, 0x69, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x09, 0x48, 0x03, 0x52, 0x09, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x54, 0x78, 0x49, 0x64, 0x88, 0x01,
+	0x01, 0x12, 0x25, 0x0a, 0x0c, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x5f, 0x74, 0x78, 0x5f, 0x69,
+	0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x48, 0x04, 0x52, 0x0a, 0x72, 0x65, 0x66, 0x75, 0x6e,
+	0x64, 0x54, 0x78, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x28, 0x0a, 0x10, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x73, 0x61, 0x74, 0x73, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x46, 0x65, 0x65, 0x53, 0x61,
+	0x74, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x6f, 0x6e, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x66, 0x65,
+	0x65, 0x5f, 0x73, 0x61, 0x74, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x6f, 0x6e,
+	0x63, 0x68, 0x61, 0x69, 0x6e, 0x46, 0x65, 0x65, 0x53, 0x61, 0x74, 0x73, 0x12, 0x2a, 0x0a, 0x11,
+	0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x73, 0x61, 0x74,
+	0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69,
+	0x6e, 0x46, 0x65, 0x65, 0x53, 0x61, 0x74, 0x73, 0x12, 0x1d, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x48, 0x05, 0x52, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x88, 0x01, 0x01, 0x12, 0x19, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x48, 0x06, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x88,
+	0x01, 0x01, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x6f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x42, 0x0d,
+	0x0a, 0x0b, 0x5f, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x74, 0x78, 0x5f, 0x69, 0x64, 0x42, 0x0e, 0x0a,
+	0x0c, 0x5f, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x5f, 0x74, 0x78, 0x5f, 0x69, 0x64, 0x42, 0x0f, 0x0a,
+	0x0d, 0x5f, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x5f, 0x74, 0x78, 0x5f, 0x69, 0x64, 0x42, 0x0a,
+	0x0a, 0x08, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x22, 0x4d, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x53, 0x77, 0x61, 0x70, 0x54,
+	0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x22, 0x0a, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0a, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x73, 0x22, 0x53, 0x0a, 0x12, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x53, 0x77, 0x61, 0x70,
+	0x49, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x05, 0x63, 0x68, 0x61,
+	0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x06, 0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e,
+	0x52, 0x05, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x6d, 0x6f, 0x75, 0x6e,
+	0x74, 0x5f, 0x73, 0x61, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x61, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x53, 0x61, 0x74, 0x73, 0x22, 0x92, 0x03, 0x0a, 0x13, 0x51, 0x75, 0x6f,
+	0x74, 0x65, 0x53, 0x77, 0x61, 0x70, 0x49, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x73, 0x61, 0x74, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x53, 0x61, 0x74,
+	0x73, 0x12, 0x28, 0x0a, 0x10, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x66, 0x65, 0x65,
+	0x5f, 0x73, 0x61, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x46, 0x65, 0x65, 0x53, 0x61, 0x74, 0x73, 0x12, 0x2e, 0x0a, 0x13, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65,
+	0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x11, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x46, 0x65, 0x65, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x28, 0x0a, 0x10, 0x6f,
+	0x6e, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x73, 0x61, 0x74, 0x73, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x6f, 0x6e, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x46, 0x65,
+	0x65, 0x53, 0x61, 0x74, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x73, 0x65, 0x6e, 0x64, 0x5f, 0x61, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x73, 0x61, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0e, 0x73, 0x65, 0x6e, 0x64, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x53, 0x61, 0x74, 0x73, 0x12,
+	0x26, 0x0a, 0x0f, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x73, 0x61,
+	0x74, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64,
+	0x46, 0x65, 0x65, 0x53, 0x61, 0x74, 0x73, 0x12, 0x34, 0x0a, 0x17, 0x66, 0x65, 0x65, 0x5f, 0x72,
+	0x61, 0x74, 0x65, 0x5f, 0x73, 0x61, 0x74, 0x73, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x76, 0x62, 0x79,
+	0x74, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x13, 0x66, 0x65, 0x65, 0x52, 0x61, 0x74,
+	0x65, 0x53, 0x61, 0x74, 0x73, 0x50, 0x65, 0x72, 0x56, 0x62, 0x79, 0x74, 0x65, 0x12, 0x26, 0x0a,
+	0x0f, 0x6d, 0x69, 0x6e, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x73, 0x61, 0x74, 0x73,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x6d, 0x69, 0x6e, 0x41, 0x6d, 0x6f, 0x75, 0x6e,
+	0x74, 0x53, 0x61, 0x74, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6d, 0x61, 0x78, 0x5f, 0x61, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x5f, 0x73, 0x61, 0x74, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d,
+	0x6d, 0x61, 0x78, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x53, 0x61, 0x74, 0x73, 0x22, 0xac, 0x01,
+	0x0a, 0x13, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x53, 0x77, 0x61, 0x70, 0x4f, 0x75, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x05, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x06, 0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x52, 0x05, 0x63, 0x68,
+	0x61, 0x69, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x73, 0x61,
+	0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
+	0x53, 0x61, 0x74, 0x73, 0x12, 0x3a, 0x0a, 0x17, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x6f, 0x75, 0x74,
+	0x69, 0x6e, 0x67, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x02, 0x48, 0x00, 0x52, 0x14, 0x6d, 0x61, 0x78, 0x52, 0x6f, 0x75, 0x74,
+	0x69, 0x6e, 0x67, 0x46, 0x65, 0x65, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x88, 0x01, 0x01,
+	0x42, 0x1a, 0x0a, 0x18, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67,
+	0x5f, 0x66, 0x65, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x22, 0xcb, 0x03, 0x0a,
+	0x14, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x53, 0x77, 0x61, 0x70, 0x4f, 0x75, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f,
+	0x73, 0x61, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x61, 0x6d, 0x6f, 0x75,
+	0x6e, 0x74, 0x53, 0x61, 0x74, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x73, 0x61, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x46, 0x65, 0x65, 0x53, 0x61, 0x74, 0x73,
+	0x12, 0x2e, 0x0a, 0x13, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x66, 0x65, 0x65, 0x5f,
+	0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x11, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x46, 0x65, 0x65, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74,
+	0x12, 0x24, 0x0a, 0x0e, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x73, 0x61,
+	0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x46,
+	0x65, 0x65, 0x53, 0x61, 0x74, 0x73, 0x12, 0x2f, 0x0a, 0x14, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x6f,
+	0x75, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x73, 0x61, 0x74, 0x73, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x6d, 0x61, 0x78, 0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67,
+	0x46, 0x65, 0x65, 0x53, 0x61, 0x74, 0x73, 0x12, 0x2e, 0x0a, 0x13, 0x72, 0x65, 0x63, 0x65, 0x69,
+	0x76, 0x65, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x73, 0x61, 0x74, 0x73, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x41, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x53, 0x61, 0x74, 0x73, 0x12, 0x2b, 0x0a, 0x12, 0x6d, 0x61, 0x78, 0x5f, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x73, 0x61, 0x74, 0x73, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0f, 0x6d, 0x61, 0x78, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x46, 0x65, 0x65,
+	0x53, 0x61, 0x74, 0x73, 0x12, 0x34, 0x0a, 0x17, 0x66, 0x65, 0x65, 0x5f, 0x72, 0x61, 0x74, 0x65,
+	0x5f, 0x73, 0x61, 0x74, 0x73, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x76, 0x62, 0x79, 0x74, 0x65, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x13, 0x66, 0x65, 0x65, 0x52, 0x61, 0x74, 0x65, 0x53, 0x61,
+	0x74, 0x73, 0x50, 0x65, 0x72, 0x56, 0x62, 0x79, 0x74, 0x65, 0x12, 0x26, 0x0a, 0x0f, 0x6d, 0x69,
+	0x6e, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x73, 0x61, 0x74, 0x73, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0d, 0x6d, 0x69, 0x6e, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x53, 0x61,
+	0x74, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6d, 0x61, 0x78, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
+	0x5f, 0x73, 0x61, 0x74, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x6d, 0x61, 0x78,
+	0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x53, 0x61, 0x74, 0x73, 0x22, 0x97, 0x01, 0x0a, 0x12, 0x45,
+	0x78, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x77, 0x61, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x2e, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x66, 0x72, 0x6f,
+	0x6d, 0x12, 0x2a, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x25, 0x0a,
+	0x06, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0d, 0x2e,
+	0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x52, 0x06, 0x70, 0x65,
+	0x72, 0x69, 0x6f, 0x64, 0x22, 0xc7, 0x04, 0x0a, 0x0a, 0x53, 0x77, 0x61, 0x70, 0x52, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1f, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x07, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x75, 0x74, 0x63,
+	0x6f, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x75, 0x74, 0x63, 0x6f,
+	0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x73, 0x61, 0x74,
+	0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x53,
+	0x61, 0x74, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x66,
+	0x65, 0x65, 0x5f, 0x73, 0x61, 0x74, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x46, 0x65, 0x65, 0x53, 0x61, 0x74, 0x73, 0x12, 0x28, 0x0a,
+	0x10, 0x6f, 0x6e, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x73, 0x61, 0x74,
+	0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x6f, 0x6e, 0x63, 0x68, 0x61, 0x69, 0x6e,
+	0x46, 0x65, 0x65, 0x53, 0x61, 0x74, 0x73, 0x12, 0x2a, 0x0a, 0x11, 0x6f, 0x66, 0x66, 0x63, 0x68,
+	0x61, 0x69, 0x6e, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x73, 0x61, 0x74, 0x73, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0f, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x46, 0x65, 0x65, 0x53,
+	0x61, 0x74, 0x73, 0x12, 0x24, 0x0a, 0x0e, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x66, 0x65, 0x65,
+	0x5f, 0x73, 0x61, 0x74, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x74, 0x6f, 0x74,
+	0x61, 0x6c, 0x46, 0x65, 0x65, 0x53, 0x61, 0x74, 0x73, 0x12, 0x21, 0x0a, 0x0a, 0x6c, 0x6f, 0x63,
+	0x6b, 0x5f, 0x74, 0x78, 0x5f, 0x69, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52,
+	0x08, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x78, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x23, 0x0a, 0x0b,
+	0x63, 0x6c, 0x61, 0x69, 0x6d, 0x5f, 0x74, 0x78, 0x5f, 0x69, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28,
+	0x09, 0x48, 0x01, 0x52, 0x09, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x54, 0x78, 0x49, 0x64, 0x88, 0x01,
+	0x01, 0x12, 0x25, 0x0a, 0x0c, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x5f, 0x74, 0x78, 0x5f, 0x69,
+	0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x48, 0x02, 0x52, 0x0a, 0x72, 0x65, 0x66, 0x75, 0x6e,
+	0x64, 0x54, 0x78, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x41, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61,
+	0x74, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x42, 0x0d,
+	0x0a, 0x0b, 0x5f, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x74, 0x78, 0x5f, 0x69, 0x64, 0x42, 0x0e, 0x0a,
+	0x0c, 0x5f, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x5f, 0x74, 0x78, 0x5f, 0x69, 0x64, 0x42, 0x0f, 0x0a,
+	0x0d, 0x5f, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x5f, 0x74, 0x78, 0x5f, 0x69, 0x64, 0x22, 0x9a,
+	0x02, 0x0a, 0x0f, 0x53, 0x77, 0x61, 0x70, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x54, 0x6f, 0x74,
+	0x61, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x73,
+	0x61, 0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x61, 0x6d, 0x6f, 0x75, 0x6e,
+	0x74, 0x53, 0x61, 0x74, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x5f, 0x66, 0x65, 0x65, 0x5f, 0x73, 0x61, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x46, 0x65, 0x65, 0x53, 0x61, 0x74, 0x73, 0x12,
+	0x28, 0x0a, 0x10, 0x6f, 0x6e, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x73,
+	0x61, 0x74, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x6f, 0x6e, 0x63, 0x68, 0x61,
+	0x69, 0x6e, 0x46, 0x65, 0x65, 0x53, 0x61, 0x74, 0x73, 0x12, 0x2a, 0x0a, 0x11, 0x6f, 0x66, 0x66,
+	0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x73, 0x61, 0x74, 0x73, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x46, 0x65,
+	0x65, 0x53, 0x61, 0x74, 0x73, 0x12, 0x24, 0x0a, 0x0e, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x66,
+	0x65, 0x65, 0x5f, 0x73, 0x61, 0x74, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x46, 0x65, 0x65, 0x53, 0x61, 0x74, 0x73, 0x22, 0x62, 0x0a, 0x13, 0x45,
+	0x78, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x77, 0x61, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x21, 0x0a, 0x05, 0x73, 0x77, 0x61, 0x70, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x0b, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x05,
+	0x73, 0x77, 0x61, 0x70, 0x73, 0x12, 0x28, 0x0a, 0x06, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x52, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x52, 0x06, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x73, 0x2a,
+	0x20, 0x0a, 0x05, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x12, 0x0b, 0x0a, 0x07, 0x42, 0x49, 0x54, 0x43,
+	0x4f, 0x49, 0x4e, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x4c, 0x49, 0x51, 0x55, 0x49, 0x44, 0x10,
+	0x01, 0x2a, 0x30, 0x0a, 0x07, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x12, 0x0b, 0x0a, 0x07,
+	0x4d, 0x41, 0x49, 0x4e, 0x4e, 0x45, 0x54, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x54, 0x45, 0x53,
+	0x54, 0x4e, 0x45, 0x54, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x52, 0x45, 0x47, 0x54, 0x45, 0x53,
+	0x54, 0x10, 0x02, 0x2a, 0x2c, 0x0a, 0x0c, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x65, 0x72,
+	0x69, 0x6f, 0x64, 0x12, 0x07, 0x0a, 0x03, 0x44, 0x41, 0x59, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04,
+	0x57, 0x45, 0x45, 0x4b, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x4d, 0x4f, 0x4e, 0x54, 0x48, 0x10,
+	0x02, 0x2a, 0x66, 0x0a, 0x0d, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x13, 0x0a, 0x0f, 0x50, 0x41, 0x59, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x50, 0x45,
+	0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x50, 0x41, 0x59, 0x4d, 0x45,
+	0x4e, 0x54, 0x5f, 0x49, 0x4e, 0x5f, 0x46, 0x4c, 0x49, 0x47, 0x48, 0x54, 0x10, 0x01, 0x12, 0x15,
+	0x0a, 0x11, 0x50, 0x41, 0x59, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x53, 0x55, 0x43, 0x43, 0x45, 0x45,
+	0x44, 0x45, 0x44, 0x10, 0x02, 0x12, 0x12, 0x0a, 0x0e, 0x50, 0x41, 0x59, 0x4d, 0x45, 0x4e, 0x54,
+	0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x03, 0x2a, 0xe7, 0x01, 0x0a, 0x06, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x0b, 0x0a, 0x07, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x44, 0x10,
+	0x00, 0x12, 0x23, 0x0a, 0x1f, 0x49, 0x4e, 0x56, 0x4f, 0x49, 0x43, 0x45, 0x5f, 0x50, 0x41, 0x59,
+	0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x49, 0x4e, 0x54, 0x45, 0x4e, 0x54, 0x5f, 0x52, 0x45, 0x43, 0x45,
+	0x49, 0x56, 0x45, 0x44, 0x10, 0x01, 0x12, 0x1f, 0x0a, 0x1b, 0x43, 0x4f, 0x4e, 0x54, 0x52, 0x41,
+	0x43, 0x54, 0x5f, 0x46, 0x55, 0x4e, 0x44, 0x45, 0x44, 0x5f, 0x55, 0x4e, 0x43, 0x4f, 0x4e, 0x46,
+	0x49, 0x52, 0x4d, 0x45, 0x44, 0x10, 0x02, 0x12, 0x13, 0x0a, 0x0f, 0x43, 0x4f, 0x4e, 0x54, 0x52,
+	0x41, 0x43, 0x54, 0x5f, 0x46, 0x55, 0x4e, 0x44, 0x45, 0x44, 0x10, 0x03, 0x12, 0x10, 0x0a, 0x0c,
+	0x49, 0x4e, 0x56, 0x4f, 0x49, 0x43, 0x45, 0x5f, 0x50, 0x41, 0x49, 0x44, 0x10, 0x04, 0x12, 0x20,
+	0x0a, 0x1c, 0x43, 0x4f, 0x4e, 0x54, 0x52, 0x41, 0x43, 0x54, 0x5f, 0x43, 0x4c, 0x41, 0x49, 0x4d,
+	0x45, 0x44, 0x5f, 0x55, 0x4e, 0x43, 0x4f, 0x4e, 0x46, 0x49, 0x52, 0x4d, 0x45, 0x44, 0x10, 0x05,
+	0x12, 0x08, 0x0a, 0x04, 0x44, 0x4f, 0x4e, 0x45, 0x10, 0x06, 0x12, 0x21, 0x0a, 0x1d, 0x43, 0x4f,
+	0x4e, 0x54, 0x52, 0x41, 0x43, 0x54, 0x5f, 0x52, 0x45, 0x46, 0x55, 0x4e, 0x44, 0x45, 0x44, 0x5f,
+	0x55, 0x4e, 0x43, 0x4f, 0x4e, 0x46, 0x49, 0x52, 0x4d, 0x45, 0x44, 0x10, 0x07, 0x12, 0x14, 0x0a,
+	0x10, 0x43, 0x4f, 0x4e, 0x54, 0x52, 0x41, 0x43, 0x54, 0x5f, 0x45, 0x58, 0x50, 0x49, 0x52, 0x45,
+	0x44, 0x10, 0x08, 0x32, 0x97, 0x05, 0x0a, 0x0b, 0x53, 0x77, 0x61, 0x70, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x12, 0x29, 0x0a, 0x06, 0x53, 0x77, 0x61, 0x70, 0x49, 0x6e, 0x12, 0x0e, 0x2e,
+	0x53, 0x77, 0x61, 0x70, 0x49, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0f, 0x2e,
+	0x53, 0x77, 0x61, 0x70, 0x49, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c,
+	0x0a, 0x07, 0x53, 0x77, 0x61, 0x70, 0x4f, 0x75, 0x74, 0x12, 0x0f, 0x2e, 0x53, 0x77, 0x61, 0x70,
+	0x4f, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x53, 0x77, 0x61,
+	0x70, 0x4f, 0x75, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x09,
+	0x47, 0x65, 0x74, 0x53, 0x77, 0x61, 0x70, 0x49, 0x6e, 0x12, 0x11, 0x2e, 0x47, 0x65, 0x74, 0x53,
+	0x77, 0x61, 0x70, 0x49, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x47,
+	0x65, 0x74, 0x53, 0x77, 0x61, 0x70, 0x49, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x35, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x53, 0x77, 0x61, 0x70, 0x4f, 0x75, 0x74, 0x12, 0x12,
+	0x2e, 0x47, 0x65, 0x74, 0x53, 0x77, 0x61, 0x70, 0x4f, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x13, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x77, 0x61, 0x70, 0x4f, 0x75, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5f, 0x0a, 0x18, 0x52, 0x65, 0x63, 0x6f, 0x76,
+	0x65, 0x72, 0x52, 0x65, 0x75, 0x73, 0x65, 0x64, 0x53, 0x77, 0x61, 0x70, 0x41, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x12, 0x20, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x52, 0x65, 0x75,
+	0x73, 0x65, 0x64, 0x53, 0x77, 0x61, 0x70, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x52,
 	0x65, 0x75, 0x73, 0x65, 0x64, 0x53, 0x77, 0x61, 0x70, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x76, 0x65,
-	0x72, 0x52, 0x65, 0x75, 0x73, 0x65, 0x64, 0x53, 0x77, 0x61, 0x70, 0x41, 0x64, 0x64, 0x72, 0x65,
-	0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x07, 0x5a, 0x05, 0x2e, 0x2f,
-	0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x0a, 0x52, 0x65, 0x6f, 0x70,
+	0x65, 0x6e, 0x53, 0x77, 0x61, 0x70, 0x12, 0x12, 0x2e, 0x52, 0x65, 0x6f, 0x70, 0x65, 0x6e, 0x53,
+	0x77, 0x61, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x52, 0x65, 0x6f,
+	0x70, 0x65, 0x6e, 0x53, 0x77, 0x61, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x35, 0x0a, 0x0a, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x53, 0x77, 0x61, 0x70, 0x12, 0x12, 0x2e,
+	0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x53, 0x77, 0x61, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x13, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x53, 0x77, 0x61, 0x70, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x44, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x53, 0x77, 0x61,
+	0x70, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x17, 0x2e, 0x47, 0x65, 0x74, 0x53,
+	0x77, 0x61, 0x70, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x18, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x77, 0x61, 0x70, 0x54, 0x69, 0x6d, 0x65,
+	0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38, 0x0a, 0x0b,
+	0x51, 0x75, 0x6f, 0x74, 0x65, 0x53, 0x77, 0x61, 0x70, 0x49, 0x6e, 0x12, 0x13, 0x2e, 0x51, 0x75,
+	0x6f, 0x74, 0x65, 0x53, 0x77, 0x61, 0x70, 0x49, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x14, 0x2e, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x53, 0x77, 0x61, 0x70, 0x49, 0x6e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x0c, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x53,
+	0x77, 0x61, 0x70, 0x4f, 0x75, 0x74, 0x12, 0x14, 0x2e, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x53, 0x77,
+	0x61, 0x70, 0x4f, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x51,
+	0x75, 0x6f, 0x74, 0x65, 0x53, 0x77, 0x61, 0x70, 0x4f, 0x75, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x38, 0x0a, 0x0b, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x77, 0x61,
+	0x70, 0x73, 0x12, 0x13, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x77, 0x61, 0x70, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74,
+	0x53, 0x77, 0x61, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x07, 0x5a,
+	0x05, 0x2e, 0x2f, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 })
 
 var (
@@ -1117,46 +2846,95 @@ func file__40swapd_proto_rawDescGZIP() []byte {
 	return file__40swapd_proto_rawDescData
 }
 
-var file__40swapd_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file__40swapd_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file__40swapd_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file__40swapd_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
 var file__40swapd_proto_goTypes = []any{
 	(Chain)(0),                               // 0: Chain
 	(Network)(0),                             // 1: Network
-	(Status)(0),                              // 2: Status
-	(*SwapInRequest)(nil),                    // 3: SwapInRequest
-	(*SwapInResponse)(nil),                   // 4: SwapInResponse
-	(*SwapOutRequest)(nil),                   // 5: SwapOutRequest
-	(*SwapOutResponse)(nil),                  // 6: SwapOutResponse
-	(*GetSwapInRequest)(nil),                 // 7: GetSwapInRequest
-	(*GetSwapInResponse)(nil),                // 8: GetSwapInResponse
-	(*GetSwapOutRequest)(nil),                // 9: GetSwapOutRequest
-	(*GetSwapOutResponse)(nil),               // 10: GetSwapOutResponse
-	(*RecoverReusedSwapAddressRequest)(nil),  // 11: RecoverReusedSwapAddressRequest
-	(*RecoverReusedSwapAddressResponse)(nil), // 12: RecoverReusedSwapAddressResponse
-	(*timestamppb.Timestamp)(nil),            // 13: google.protobuf.Timestamp
+	(ReportPeriod)(0),                        // 2: ReportPeriod
+	(PaymentStatus)(0),                       // 3: PaymentStatus
+	(Status)(0),                              // 4: Status
+	(*SwapInRequest)(nil),                    // 5: SwapInRequest
+	(*SwapInResponse)(nil),                   // 6: SwapInResponse
+	(*SwapOutRequest)(nil),                   // 7: SwapOutRequest
+	(*SwapOutResponse)(nil),                  // 8: SwapOutResponse
+	(*GetSwapInRequest)(nil),                 // 9: GetSwapInRequest
+	(*GetSwapInResponse)(nil),                // 10: GetSwapInResponse
+	(*GetSwapOutRequest)(nil),                // 11: GetSwapOutRequest
+	(*GetSwapOutResponse)(nil),               // 12: GetSwapOutResponse
+	(*FiatValue)(nil),                        // 13: FiatValue
+	(*RecoverReusedSwapAddressRequest)(nil),  // 14: RecoverReusedSwapAddressRequest
+	(*RecoverReusedSwapAddressResponse)(nil), // 15: RecoverReusedSwapAddressResponse
+	(*ReopenSwapRequest)(nil),                // 16: ReopenSwapRequest
+	(*ReopenSwapResponse)(nil),               // 17: ReopenSwapResponse
+	(*CancelSwapRequest)(nil),                // 18: CancelSwapRequest
+	(*CancelSwapResponse)(nil),               // 19: CancelSwapResponse
+	(*GetSwapTimelineRequest)(nil),           // 20: GetSwapTimelineRequest
+	(*SwapEvent)(nil),                        // 21: SwapEvent
+	(*GetSwapTimelineResponse)(nil),          // 22: GetSwapTimelineResponse
+	(*QuoteSwapInRequest)(nil),               // 23: QuoteSwapInRequest
+	(*QuoteSwapInResponse)(nil),              // 24: QuoteSwapInResponse
+	(*QuoteSwapOutRequest)(nil),              // 25: QuoteSwapOutRequest
+	(*QuoteSwapOutResponse)(nil),             // 26: QuoteSwapOutResponse
+	(*ExportSwapsRequest)(nil),               // 27: ExportSwapsRequest
+	(*SwapReport)(nil),                       // 28: SwapReport
+	(*SwapReportTotal)(nil),                  // 29: SwapReportTotal
+	(*ExportSwapsResponse)(nil),              // 30: ExportSwapsResponse
+	(*timestamppb.Timestamp)(nil),            // 31: google.protobuf.Timestamp
 }
 var file__40swapd_proto_depIdxs = []int32{
 	0,  // 0: SwapInRequest.chain:type_name -> Chain
 	0,  // 1: SwapOutRequest.chain:type_name -> Chain
-	13, // 2: GetSwapInResponse.created_at:type_name -> google.protobuf.Timestamp
-	2,  // 3: GetSwapInResponse.status:type_name -> Status
-	2,  // 4: GetSwapOutResponse.status:type_name -> Status
-	13, // 5: GetSwapOutResponse.created_at:type_name -> google.protobuf.Timestamp
-	3,  // 6: SwapService.SwapIn:input_type -> SwapInRequest
-	5,  // 7: SwapService.SwapOut:input_type -> SwapOutRequest
-	7,  // 8: SwapService.GetSwapIn:input_type -> GetSwapInRequest
-	9,  // 9: SwapService.GetSwapOut:input_type -> GetSwapOutRequest
-	11, // 10: SwapService.RecoverReusedSwapAddress:input_type -> RecoverReusedSwapAddressRequest
-	4,  // 11: SwapService.SwapIn:output_type -> SwapInResponse
-	6,  // 12: SwapService.SwapOut:output_type -> SwapOutResponse
-	8,  // 13: SwapService.GetSwapIn:output_type -> GetSwapInResponse
-	10, // 14: SwapService.GetSwapOut:output_type -> GetSwapOutResponse
-	12, // 15: SwapService.RecoverReusedSwapAddress:output_type -> RecoverReusedSwapAddressResponse
-	11, // [11:16] is the sub-list for method output_type
-	6,  // [6:11] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	31, // 2: GetSwapInResponse.created_at:type_name -> google.protobuf.Timestamp
+	4,  // 3: GetSwapInResponse.status:type_name -> Status
+	13, // 4: GetSwapInResponse.fiat:type_name -> FiatValue
+	4,  // 5: GetSwapOutResponse.status:type_name -> Status
+	31, // 6: GetSwapOutResponse.created_at:type_name -> google.protobuf.Timestamp
+	13, // 7: GetSwapOutResponse.fiat:type_name -> FiatValue
+	3,  // 8: GetSwapOutResponse.payment_status:type_name -> PaymentStatus
+	4,  // 9: ReopenSwapResponse.status:type_name -> Status
+	4,  // 10: CancelSwapResponse.status:type_name -> Status
+	31, // 11: SwapEvent.created_at:type_name -> google.protobuf.Timestamp
+	4,  // 12: SwapEvent.from_status:type_name -> Status
+	4,  // 13: SwapEvent.to_status:type_name -> Status
+	21, // 14: GetSwapTimelineResponse.events:type_name -> SwapEvent
+	0,  // 15: QuoteSwapInRequest.chain:type_name -> Chain
+	0,  // 16: QuoteSwapOutRequest.chain:type_name -> Chain
+	31, // 17: ExportSwapsRequest.from:type_name -> google.protobuf.Timestamp
+	31, // 18: ExportSwapsRequest.to:type_name -> google.protobuf.Timestamp
+	2,  // 19: ExportSwapsRequest.period:type_name -> ReportPeriod
+	4,  // 20: SwapReport.status:type_name -> Status
+	31, // 21: SwapReport.created_at:type_name -> google.protobuf.Timestamp
+	31, // 22: SwapReport.updated_at:type_name -> google.protobuf.Timestamp
+	28, // 23: ExportSwapsResponse.swaps:type_name -> SwapReport
+	29, // 24: ExportSwapsResponse.totals:type_name -> SwapReportTotal
+	5,  // 25: SwapService.SwapIn:input_type -> SwapInRequest
+	7,  // 26: SwapService.SwapOut:input_type -> SwapOutRequest
+	9,  // 27: SwapService.GetSwapIn:input_type -> GetSwapInRequest
+	11, // 28: SwapService.GetSwapOut:input_type -> GetSwapOutRequest
+	14, // 29: SwapService.RecoverReusedSwapAddress:input_type -> RecoverReusedSwapAddressRequest
+	16, // 30: SwapService.ReopenSwap:input_type -> ReopenSwapRequest
+	18, // 31: SwapService.CancelSwap:input_type -> CancelSwapRequest
+	20, // 32: SwapService.GetSwapTimeline:input_type -> GetSwapTimelineRequest
+	23, // 33: SwapService.QuoteSwapIn:input_type -> QuoteSwapInRequest
+	25, // 34: SwapService.QuoteSwapOut:input_type -> QuoteSwapOutRequest
+	27, // 35: SwapService.ExportSwaps:input_type -> ExportSwapsRequest
+	6,  // 36: SwapService.SwapIn:output_type -> SwapInResponse
+	8,  // 37: SwapService.SwapOut:output_type -> SwapOutResponse
+	10, // 38: SwapService.GetSwapIn:output_type -> GetSwapInResponse
+	12, // 39: SwapService.GetSwapOut:output_type -> GetSwapOutResponse
+	15, // 40: SwapService.RecoverReusedSwapAddress:output_type -> RecoverReusedSwapAddressResponse
+	17, // 41: SwapService.ReopenSwap:output_type -> ReopenSwapResponse
+	19, // 42: SwapService.CancelSwap:output_type -> CancelSwapResponse
+	22, // 43: SwapService.GetSwapTimeline:output_type -> GetSwapTimelineResponse
+	24, // 44: SwapService.QuoteSwapIn:output_type -> QuoteSwapInResponse
+	26, // 45: SwapService.QuoteSwapOut:output_type -> QuoteSwapOutResponse
+	30, // 46: SwapService.ExportSwaps:output_type -> ExportSwapsResponse
+	36, // [36:47] is the sub-list for method output_type
+	25, // [25:36] is the sub-list for method input_type
+	25, // [25:25] is the sub-list for extension type_name
+	25, // [25:25] is the sub-list for extension extendee
+	0,  // [0:25] is the sub-list for field type_name
 }
 
 func init() { file__40swapd_proto_init() }
@@ -1168,14 +2946,17 @@ func file__40swapd_proto_init() {
 	file__40swapd_proto_msgTypes[2].OneofWrappers = []any{}
 	file__40swapd_proto_msgTypes[5].OneofWrappers = []any{}
 	file__40swapd_proto_msgTypes[7].OneofWrappers = []any{}
-	file__40swapd_proto_msgTypes[8].OneofWrappers = []any{}
+	file__40swapd_proto_msgTypes[9].OneofWrappers = []any{}
+	file__40swapd_proto_msgTypes[16].OneofWrappers = []any{}
+	file__40swapd_proto_msgTypes[20].OneofWrappers = []any{}
+	file__40swapd_proto_msgTypes[23].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file__40swapd_proto_rawDesc), len(file__40swapd_proto_rawDesc)),
-			NumEnums:      3,
-			NumMessages:   10,
+			NumEnums:      5,
+			NumMessages:   26,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
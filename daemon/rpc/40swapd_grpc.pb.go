@@ -24,6 +24,12 @@ const (
 	SwapService_GetSwapIn_FullMethodName                = "/SwapService/GetSwapIn"
 	SwapService_GetSwapOut_FullMethodName               = "/SwapService/GetSwapOut"
 	SwapService_RecoverReusedSwapAddress_FullMethodName = "/SwapService/RecoverReusedSwapAddress"
+	SwapService_ReopenSwap_FullMethodName               = "/SwapService/ReopenSwap"
+	SwapService_CancelSwap_FullMethodName               = "/SwapService/CancelSwap"
+	SwapService_GetSwapTimeline_FullMethodName          = "/SwapService/GetSwapTimeline"
+	SwapService_QuoteSwapIn_FullMethodName              = "/SwapService/QuoteSwapIn"
+	SwapService_QuoteSwapOut_FullMethodName             = "/SwapService/QuoteSwapOut"
+	SwapService_ExportSwaps_FullMethodName              = "/SwapService/ExportSwaps"
 )
 
 // SwapServiceClient is the client API for SwapService service.
@@ -36,6 +42,12 @@ type SwapServiceClient interface {
 	GetSwapIn(ctx context.Context, in *GetSwapInRequest, opts ...grpc.CallOption) (*GetSwapInResponse, error)
 	GetSwapOut(ctx context.Context, in *GetSwapOutRequest, opts ...grpc.CallOption) (*GetSwapOutResponse, error)
 	RecoverReusedSwapAddress(ctx context.Context, in *RecoverReusedSwapAddressRequest, opts ...grpc.CallOption) (*RecoverReusedSwapAddressResponse, error)
+	ReopenSwap(ctx context.Context, in *ReopenSwapRequest, opts ...grpc.CallOption) (*ReopenSwapResponse, error)
+	CancelSwap(ctx context.Context, in *CancelSwapRequest, opts ...grpc.CallOption) (*CancelSwapResponse, error)
+	GetSwapTimeline(ctx context.Context, in *GetSwapTimelineRequest, opts ...grpc.CallOption) (*GetSwapTimelineResponse, error)
+	QuoteSwapIn(ctx context.Context, in *QuoteSwapInRequest, opts ...grpc.CallOption) (*QuoteSwapInResponse, error)
+	QuoteSwapOut(ctx context.Context, in *QuoteSwapOutRequest, opts ...grpc.CallOption) (*QuoteSwapOutResponse, error)
+	ExportSwaps(ctx context.Context, in *ExportSwapsRequest, opts ...grpc.CallOption) (*ExportSwapsResponse, error)
 }
 
 type swapServiceClient struct {
@@ -96,6 +108,66 @@ func (c *swapServiceClient) RecoverReusedSwapAddress(ctx context.Context, in *Re
 	return out, nil
 }
 
+func (c *swapServiceClient) ReopenSwap(ctx context.Context, in *ReopenSwapRequest, opts ...grpc.CallOption) (*ReopenSwapResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReopenSwapResponse)
+	err := c.cc.Invoke(ctx, SwapService_ReopenSwap_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *swapServiceClient) CancelSwap(ctx context.Context, in *CancelSwapRequest, opts ...grpc.CallOption) (*CancelSwapResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelSwapResponse)
+	err := c.cc.Invoke(ctx, SwapService_CancelSwap_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *swapServiceClient) GetSwapTimeline(ctx context.Context, in *GetSwapTimelineRequest, opts ...grpc.CallOption) (*GetSwapTimelineResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSwapTimelineResponse)
+	err := c.cc.Invoke(ctx, SwapService_GetSwapTimeline_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *swapServiceClient) QuoteSwapIn(ctx context.Context, in *QuoteSwapInRequest, opts ...grpc.CallOption) (*QuoteSwapInResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QuoteSwapInResponse)
+	err := c.cc.Invoke(ctx, SwapService_QuoteSwapIn_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *swapServiceClient) QuoteSwapOut(ctx context.Context, in *QuoteSwapOutRequest, opts ...grpc.CallOption) (*QuoteSwapOutResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QuoteSwapOutResponse)
+	err := c.cc.Invoke(ctx, SwapService_QuoteSwapOut_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *swapServiceClient) ExportSwaps(ctx context.Context, in *ExportSwapsRequest, opts ...grpc.CallOption) (*ExportSwapsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportSwapsResponse)
+	err := c.cc.Invoke(ctx, SwapService_ExportSwaps_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SwapServiceServer is the server API for SwapService service.
 // All implementations must embed UnimplementedSwapServiceServer
 // for forward compatibility.
@@ -106,6 +178,12 @@ type SwapServiceServer interface {
 	GetSwapIn(context.Context, *GetSwapInRequest) (*GetSwapInResponse, error)
 	GetSwapOut(context.Context, *GetSwapOutRequest) (*GetSwapOutResponse, error)
 	RecoverReusedSwapAddress(context.Context, *RecoverReusedSwapAddressRequest) (*RecoverReusedSwapAddressResponse, error)
+	ReopenSwap(context.Context, *ReopenSwapRequest) (*ReopenSwapResponse, error)
+	CancelSwap(context.Context, *CancelSwapRequest) (*CancelSwapResponse, error)
+	GetSwapTimeline(context.Context, *GetSwapTimelineRequest) (*GetSwapTimelineResponse, error)
+	QuoteSwapIn(context.Context, *QuoteSwapInRequest) (*QuoteSwapInResponse, error)
+	QuoteSwapOut(context.Context, *QuoteSwapOutRequest) (*QuoteSwapOutResponse, error)
+	ExportSwaps(context.Context, *ExportSwapsRequest) (*ExportSwapsResponse, error)
 	mustEmbedUnimplementedSwapServiceServer()
 }
 
@@ -131,6 +209,24 @@ func (UnimplementedSwapServiceServer) GetSwapOut(context.Context, *GetSwapOutReq
 func (UnimplementedSwapServiceServer) RecoverReusedSwapAddress(context.Context, *RecoverReusedSwapAddressRequest) (*RecoverReusedSwapAddressResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RecoverReusedSwapAddress not implemented")
 }
+func (UnimplementedSwapServiceServer) ReopenSwap(context.Context, *ReopenSwapRequest) (*ReopenSwapResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReopenSwap not implemented")
+}
+func (UnimplementedSwapServiceServer) CancelSwap(context.Context, *CancelSwapRequest) (*CancelSwapResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelSwap not implemented")
+}
+func (UnimplementedSwapServiceServer) GetSwapTimeline(context.Context, *GetSwapTimelineRequest) (*GetSwapTimelineResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSwapTimeline not implemented")
+}
+func (UnimplementedSwapServiceServer) QuoteSwapIn(context.Context, *QuoteSwapInRequest) (*QuoteSwapInResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QuoteSwapIn not implemented")
+}
+func (UnimplementedSwapServiceServer) QuoteSwapOut(context.Context, *QuoteSwapOutRequest) (*QuoteSwapOutResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QuoteSwapOut not implemented")
+}
+func (UnimplementedSwapServiceServer) ExportSwaps(context.Context, *ExportSwapsRequest) (*ExportSwapsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportSwaps not implemented")
+}
 func (UnimplementedSwapServiceServer) mustEmbedUnimplementedSwapServiceServer() {}
 func (UnimplementedSwapServiceServer) testEmbeddedByValue()                     {}
 
@@ -242,6 +338,114 @@ func _SwapService_RecoverReusedSwapAddress_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SwapService_ReopenSwap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReopenSwapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SwapServiceServer).ReopenSwap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SwapService_ReopenSwap_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SwapServiceServer).ReopenSwap(ctx, req.(*ReopenSwapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SwapService_CancelSwap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelSwapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SwapServiceServer).CancelSwap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SwapService_CancelSwap_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SwapServiceServer).CancelSwap(ctx, req.(*CancelSwapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SwapService_GetSwapTimeline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSwapTimelineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SwapServiceServer).GetSwapTimeline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SwapService_GetSwapTimeline_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SwapServiceServer).GetSwapTimeline(ctx, req.(*GetSwapTimelineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SwapService_QuoteSwapIn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuoteSwapInRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SwapServiceServer).QuoteSwapIn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SwapService_QuoteSwapIn_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SwapServiceServer).QuoteSwapIn(ctx, req.(*QuoteSwapInRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SwapService_QuoteSwapOut_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuoteSwapOutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SwapServiceServer).QuoteSwapOut(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SwapService_QuoteSwapOut_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SwapServiceServer).QuoteSwapOut(ctx, req.(*QuoteSwapOutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SwapService_ExportSwaps_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportSwapsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SwapServiceServer).ExportSwaps(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SwapService_ExportSwaps_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SwapServiceServer).ExportSwaps(ctx, req.(*ExportSwapsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // SwapService_ServiceDesc is the grpc.ServiceDesc for SwapService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -269,6 +473,30 @@ var SwapService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RecoverReusedSwapAddress",
 			Handler:    _SwapService_RecoverReusedSwapAddress_Handler,
 		},
+		{
+			MethodName: "ReopenSwap",
+			Handler:    _SwapService_ReopenSwap_Handler,
+		},
+		{
+			MethodName: "CancelSwap",
+			Handler:    _SwapService_CancelSwap_Handler,
+		},
+		{
+			MethodName: "GetSwapTimeline",
+			Handler:    _SwapService_GetSwapTimeline_Handler,
+		},
+		{
+			MethodName: "QuoteSwapIn",
+			Handler:    _SwapService_QuoteSwapIn_Handler,
+		},
+		{
+			MethodName: "QuoteSwapOut",
+			Handler:    _SwapService_QuoteSwapOut_Handler,
+		},
+		{
+			MethodName: "ExportSwaps",
+			Handler:    _SwapService_ExportSwaps_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "40swapd.proto",
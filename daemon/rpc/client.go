@@ -1,28 +1,75 @@
 package rpc
 
 import (
+	"encoding/hex"
 	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
 
-	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
-func NewConnection(host string, port uint32) *grpc.ClientConn {
-	conn, err := grpc.NewClient(
-		fmt.Sprintf("%s:%d", host, port),
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
+// ClientConfig is how the client reaches and authenticates to the daemon.
+// Empty paths connect over TCP, without TLS or without a macaroon.
+type ClientConfig struct {
+	TLSCertPath  string
+	MacaroonPath string
+	// SocketPath is the unix socket the daemon listens on, host and port are
+	// ignored when set
+	SocketPath string
+}
+
+func NewConnection(host string, port uint32, creds ClientConfig) (*grpc.ClientConn, error) {
+	transport := insecure.NewCredentials()
+	if creds.TLSCertPath != "" {
+		var err error
+		transport, err = credentials.NewClientTLSFromFile(creds.TLSCertPath, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS certificate: %w", err)
+		}
+	}
+
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(transport),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	}
+
+	if creds.MacaroonPath != "" {
+		mac, err := os.ReadFile(creds.MacaroonPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read macaroon: %w", err)
+		}
+		opts = append(opts, grpc.WithPerRPCCredentials(macaroonCredential{macaroon: hex.EncodeToString(mac)}))
+	}
+
+	target := net.JoinHostPort(host, strconv.FormatUint(uint64(port), 10))
+	if creds.SocketPath != "" {
+		socketPath, err := filepath.Abs(creds.SocketPath)
+		if err != nil {
+			return nil, fmt.Errorf("invalid socket path: %w", err)
+		}
+		target = "unix://" + socketPath
+	}
+
+	conn, err := grpc.NewClient(target, opts...)
 	if err != nil {
-		log.Fatalf("did not connect: %v", err)
+		return nil, fmt.Errorf("did not connect: %w", err)
 	}
 
-	return conn
+	return conn, nil
 }
 
-func NewRPCClient(host string, port uint32) SwapServiceClient {
-	conn := NewConnection(host, port)
+func NewRPCClient(host string, port uint32, creds ClientConfig) (SwapServiceClient, error) {
+	conn, err := NewConnection(host, port, creds)
+	if err != nil {
+		return nil, err
+	}
 	client := NewSwapServiceClient(conn)
 
-	return client
+	return client, nil
 }
@@ -8,7 +8,8 @@ import (
 )
 
 func TestNewConnection(test *testing.T) {
-	connection := NewConnection("localhost", 50051)
+	connection, err := NewConnection("localhost", 50051, ClientConfig{})
+	require.NoError(test, err)
 	if connection == nil {
 		test.Fatalf("Expected non-nil connection")
 	}
@@ -20,7 +21,8 @@ func TestNewConnection(test *testing.T) {
 }
 
 func TestClient(test *testing.T) {
-	client := NewRPCClient("localhost", 50051)
+	client, err := NewRPCClient("localhost", 50051, ClientConfig{})
+	require.NoError(test, err)
 	if client == nil {
 		test.Fatalf("Expected non-nil client")
 	}
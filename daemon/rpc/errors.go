@@ -0,0 +1,191 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/40acres/40swap/daemon/money"
+	"github.com/40acres/40swap/daemon/swaps"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// errorDomain is the domain of the ErrorInfo details of the errors
+const errorDomain = "40swapd"
+
+// Services an UnavailableError can come from
+const (
+	ServiceSwapServer = "swap server"
+	ServiceLightning  = "lightning node"
+	ServiceBitcoin    = "bitcoin backend"
+)
+
+// InvalidArgumentError is returned as InvalidArgument, with the field in the
+// BadRequest details
+type InvalidArgumentError struct {
+	Field string
+	Err   error
+}
+
+func invalidArgument(field, format string, args ...any) error {
+	return &InvalidArgumentError{Field: field, Err: fmt.Errorf(format, args...)}
+}
+
+func (e *InvalidArgumentError) Error() string { return e.Err.Error() }
+func (e *InvalidArgumentError) Unwrap() error { return e.Err }
+
+// AmountOutOfRangeError is an amount out of the limits of the swap server,
+// returned as InvalidArgument with the limits in the ErrorInfo details
+type AmountOutOfRangeError struct {
+	AmountSats uint64
+	MinSats    uint64
+	MaxSats    uint64
+}
+
+func (e *AmountOutOfRangeError) Error() string {
+	return fmt.Sprintf("amount %s is not in the range [%s, %s]",
+		money.Money(e.AmountSats).ToBtc(), money.Money(e.MinSats).ToBtc(), money.Money(e.MaxSats).ToBtc())
+}
+
+// NotFoundError is returned as NotFound, with the resource in the
+// ResourceInfo details
+type NotFoundError struct {
+	Resource string
+	ID       string
+}
+
+func (e *NotFoundError) Error() string { return fmt.Sprintf("%s %s not found", e.Resource, e.ID) }
+
+// FailedPreconditionError is an operation the state of the subject doesn't
+// allow, returned as FailedPrecondition with the PreconditionFailure details
+type FailedPreconditionError struct {
+	// Type is the kind of precondition, e.g. STATUS
+	Type    string
+	Subject string
+	Err     error
+}
+
+func failedPrecondition(typ, subject, format string, args ...any) error {
+	return &FailedPreconditionError{Type: typ, Subject: subject, Err: fmt.Errorf(format, args...)}
+}
+
+func (e *FailedPreconditionError) Error() string { return e.Err.Error() }
+func (e *FailedPreconditionError) Unwrap() error { return e.Err }
+
+// UnavailableError is a failure of a service the daemon depends on, returned
+// as Unavailable with the service in the ErrorInfo details
+type UnavailableError struct {
+	Service string
+	Err     error
+}
+
+func unavailable(service, format string, args ...any) error {
+	return &UnavailableError{Service: service, Err: fmt.Errorf(format, args...)}
+}
+
+func (e *UnavailableError) Error() string { return e.Err.Error() }
+func (e *UnavailableError) Unwrap() error { return e.Err }
+
+// swapServerError wraps an error of the swap server with the format. Requests
+// the server rejects fail again if retried, so they're invalid arguments, or
+// failed preconditions when it isn't the request that is wrong, instead of
+// the server being unavailable.
+func swapServerError(field, format string, err error) error {
+	var rejectedErr *swaps.RejectedError
+	if !errors.As(err, &rejectedErr) {
+		return unavailable(ServiceSwapServer, format, err)
+	}
+
+	switch rejectedErr.StatusCode {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return invalidArgument(field, format, err)
+	default:
+		return failedPrecondition("REJECTED", ServiceSwapServer, format, err)
+	}
+}
+
+// toStatus maps an error returned by a handler to its gRPC status. Errors
+// that already are statuses are kept, unknown errors are internal.
+func toStatus(err error) *status.Status {
+	if st, ok := status.FromError(err); ok {
+		return st
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err)
+	}
+
+	var (
+		invalidArgumentErr *InvalidArgumentError
+		outOfRangeErr      *AmountOutOfRangeError
+		notFoundErr        *NotFoundError
+		preconditionErr    *FailedPreconditionError
+		unavailableErr     *UnavailableError
+	)
+	switch {
+	case errors.As(err, &outOfRangeErr):
+		return withDetails(status.New(codes.InvalidArgument, err.Error()),
+			&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "amountSats", Description: outOfRangeErr.Error()},
+			}},
+			&errdetails.ErrorInfo{
+				Reason: "AMOUNT_OUT_OF_RANGE",
+				Domain: errorDomain,
+				Metadata: map[string]string{
+					"amountSats":    strconv.FormatUint(outOfRangeErr.AmountSats, 10),
+					"minAmountSats": strconv.FormatUint(outOfRangeErr.MinSats, 10),
+					"maxAmountSats": strconv.FormatUint(outOfRangeErr.MaxSats, 10),
+				},
+			})
+	case errors.As(err, &invalidArgumentErr):
+		return withDetails(status.New(codes.InvalidArgument, err.Error()),
+			&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: invalidArgumentErr.Field, Description: invalidArgumentErr.Error()},
+			}})
+	case errors.As(err, &notFoundErr):
+		return withDetails(status.New(codes.NotFound, err.Error()),
+			&errdetails.ResourceInfo{ResourceType: notFoundErr.Resource, ResourceName: notFoundErr.ID})
+	case errors.As(err, &preconditionErr):
+		return withDetails(status.New(codes.FailedPrecondition, err.Error()),
+			&errdetails.PreconditionFailure{Violations: []*errdetails.PreconditionFailure_Violation{
+				{Type: preconditionErr.Type, Subject: preconditionErr.Subject, Description: preconditionErr.Error()},
+			}})
+	case errors.As(err, &unavailableErr):
+		return withDetails(status.New(codes.Unavailable, err.Error()),
+			&errdetails.ErrorInfo{
+				Reason:   "SERVICE_UNAVAILABLE",
+				Domain:   errorDomain,
+				Metadata: map[string]string{"service": unavailableErr.Service},
+			})
+	default:
+		return status.New(codes.Internal, err.Error())
+	}
+}
+
+func withDetails(st *status.Status, details ...protoadapt.MessageV1) *status.Status {
+	withDetails, err := st.WithDetails(details...)
+	if err != nil {
+		log.WithError(err).Error("could not add details to status")
+
+		return st
+	}
+
+	return withDetails
+}
+
+// errorInterceptor returns the errors of the handlers with the gRPC code of
+// their type
+func errorInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	resp, err := handler(ctx, req)
+	if err != nil {
+		return resp, toStatus(err).Err()
+	}
+
+	return resp, nil
+}
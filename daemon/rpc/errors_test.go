@@ -0,0 +1,175 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/40acres/40swap/daemon/swaps"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestToStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantCode    codes.Code
+		wantMessage string
+		wantDetails []proto.Message
+	}{
+		{
+			name:        "invalid argument",
+			err:         invalidArgument("id", "swap id is required"),
+			wantCode:    codes.InvalidArgument,
+			wantMessage: "swap id is required",
+			wantDetails: []proto.Message{
+				&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+					{Field: "id", Description: "swap id is required"},
+				}},
+			},
+		},
+		{
+			name:        "amount out of range",
+			err:         &AmountOutOfRangeError{AmountSats: 1000, MinSats: 20000, MaxSats: 1300000},
+			wantCode:    codes.InvalidArgument,
+			wantMessage: "amount 0.00001 is not in the range [0.0002, 0.013]",
+			wantDetails: []proto.Message{
+				&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+					{Field: "amountSats", Description: "amount 0.00001 is not in the range [0.0002, 0.013]"},
+				}},
+				&errdetails.ErrorInfo{
+					Reason: "AMOUNT_OUT_OF_RANGE",
+					Domain: errorDomain,
+					Metadata: map[string]string{
+						"amountSats":    "1000",
+						"minAmountSats": "20000",
+						"maxAmountSats": "1300000",
+					},
+				},
+			},
+		},
+		{
+			name:        "wrapped not found",
+			err:         fmt.Errorf("could not reopen: %w", &NotFoundError{Resource: "swap", ID: "swap-id"}),
+			wantCode:    codes.NotFound,
+			wantMessage: "could not reopen: swap swap-id not found",
+			wantDetails: []proto.Message{
+				&errdetails.ResourceInfo{ResourceType: "swap", ResourceName: "swap-id"},
+			},
+		},
+		{
+			name:        "failed precondition",
+			err:         failedPrecondition("STATUS", "swap/swap-id", "only failed swaps can be reopened"),
+			wantCode:    codes.FailedPrecondition,
+			wantMessage: "only failed swaps can be reopened",
+			wantDetails: []proto.Message{
+				&errdetails.PreconditionFailure{Violations: []*errdetails.PreconditionFailure_Violation{
+					{Type: "STATUS", Subject: "swap/swap-id", Description: "only failed swaps can be reopened"},
+				}},
+			},
+		},
+		{
+			name:        "unavailable",
+			err:         unavailable(ServiceSwapServer, "could not get configuration: %w", errors.New("connection refused")),
+			wantCode:    codes.Unavailable,
+			wantMessage: "could not get configuration: connection refused",
+			wantDetails: []proto.Message{
+				&errdetails.ErrorInfo{
+					Reason:   "SERVICE_UNAVAILABLE",
+					Domain:   errorDomain,
+					Metadata: map[string]string{"service": ServiceSwapServer},
+				},
+			},
+		},
+		{
+			name:        "swap server rejects the request",
+			err:         swapServerError("invoice", "could not create swap: %w", &swaps.RejectedError{StatusCode: 400, Status: "400 Bad Request", Message: "invalid invoice"}),
+			wantCode:    codes.InvalidArgument,
+			wantMessage: "could not create swap: failed to get swap: 400 - 400 Bad Request: invalid invoice",
+			wantDetails: []proto.Message{
+				&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+					{Field: "invoice", Description: "could not create swap: failed to get swap: 400 - 400 Bad Request: invalid invoice"},
+				}},
+			},
+		},
+		{
+			name:        "swap server refuses the request",
+			err:         swapServerError("invoice", "could not create swap: %w", &swaps.RejectedError{StatusCode: 409, Status: "409 Conflict", Message: "swap in progress"}),
+			wantCode:    codes.FailedPrecondition,
+			wantMessage: "could not create swap: failed to get swap: 409 - 409 Conflict: swap in progress",
+			wantDetails: []proto.Message{
+				&errdetails.PreconditionFailure{Violations: []*errdetails.PreconditionFailure_Violation{
+					{Type: "REJECTED", Subject: ServiceSwapServer, Description: "could not create swap: failed to get swap: 409 - 409 Conflict: swap in progress"},
+				}},
+			},
+		},
+		{
+			name:        "swap server fails",
+			err:         swapServerError("invoice", "could not create swap: %w", errors.New("failed to get swap: 502 - 502 Bad Gateway: <nil>")),
+			wantCode:    codes.Unavailable,
+			wantMessage: "could not create swap: failed to get swap: 502 - 502 Bad Gateway: <nil>",
+			wantDetails: []proto.Message{
+				&errdetails.ErrorInfo{
+					Reason:   "SERVICE_UNAVAILABLE",
+					Domain:   errorDomain,
+					Metadata: map[string]string{"service": ServiceSwapServer},
+				},
+			},
+		},
+		{
+			name:        "status is kept",
+			err:         status.Error(codes.PermissionDenied, "denied"),
+			wantCode:    codes.PermissionDenied,
+			wantMessage: "denied",
+		},
+		{
+			name:        "context error",
+			err:         fmt.Errorf("could not get swap: %w", context.DeadlineExceeded),
+			wantCode:    codes.DeadlineExceeded,
+			wantMessage: "could not get swap: context deadline exceeded",
+		},
+		{
+			name:        "unknown error is internal",
+			err:         errors.New("database is down"),
+			wantCode:    codes.Internal,
+			wantMessage: "database is down",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := toStatus(tt.err)
+			require.Equal(t, tt.wantCode, st.Code())
+			require.Equal(t, tt.wantMessage, st.Message())
+
+			details := st.Details()
+			require.Len(t, details, len(tt.wantDetails))
+			for i, want := range tt.wantDetails {
+				got, ok := details[i].(proto.Message)
+				require.True(t, ok)
+				require.True(t, proto.Equal(want, got), "got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestErrorInterceptor(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: SwapService_GetSwapIn_FullMethodName}
+
+	_, err := errorInterceptor(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+		return nil, &NotFoundError{Resource: "swap in", ID: "swap-id"}
+	})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	resp, err := errorInterceptor(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+		return "ok", nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, "ok", resp)
+}
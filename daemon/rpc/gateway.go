@@ -0,0 +1,138 @@
+package rpc
+
+import (
+	"context"
+	"crypto/tls"
+	_ "embed"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// OpenAPISpec is the spec of the REST/JSON gateway, generated from the proto
+//
+//go:embed 40swapd.swagger.json
+var OpenAPISpec []byte
+
+const openAPIPath = "/v1/openapi.json"
+
+// NewGateway creates a REST/JSON handler for the SwapService that forwards
+// every request to the gRPC server through conn. Requests authenticate like
+// gRPC calls do, sending the hex encoded macaroon in the Macaroon header.
+func NewGateway(ctx context.Context, conn *grpc.ClientConn) (http.Handler, error) {
+	gateway := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(gatewayHeaderMatcher),
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+			MarshalOptions:   protojson.MarshalOptions{EmitUnpopulated: true},
+			UnmarshalOptions: protojson.UnmarshalOptions{DiscardUnknown: true},
+		}),
+	)
+	if err := RegisterSwapServiceHandler(ctx, gateway, conn); err != nil {
+		return nil, fmt.Errorf("failed to register gateway handlers: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(openAPIPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(OpenAPISpec)
+	})
+	mux.Handle("/", gateway)
+
+	return mux, nil
+}
+
+// gatewayHeaderMatcher forwards the macaroon header to the gRPC server, along
+// with the headers the gateway forwards by default
+func gatewayHeaderMatcher(key string) (string, bool) {
+	if strings.EqualFold(key, MacaroonMetadataKey) {
+		return MacaroonMetadataKey, true
+	}
+
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// EnableGateway serves the REST/JSON gateway on the port along the gRPC
+// server, over TLS when a certificate is given. self is how the gateway
+// reaches the gRPC server.
+func (server *Server) EnableGateway(port uint32, self ClientConfig, cert *tls.Certificate) {
+	server.gatewayPort = port
+	server.gatewaySelf = self
+	server.gatewayCert = cert
+}
+
+// GatewayEnabled reports whether the REST/JSON gateway has to be served
+func (server *Server) GatewayEnabled() bool {
+	return server.gatewayPort != 0
+}
+
+// ServeGateway serves the REST/JSON gateway until the context is cancelled
+func (server *Server) ServeGateway(ctx context.Context) error {
+	host := server.Host
+	if host == "" {
+		host = "localhost"
+	}
+	conn, err := NewConnection(host, server.Port, server.gatewaySelf)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	handler, err := NewGateway(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	return serveHTTP(ctx, server.gatewayAddr(), handler, server.gatewayCert)
+}
+
+// gatewayAddr is the address the gateway listens on. It binds the listen host
+// like the gRPC server does, but a daemon serving gRPC on a unix socket only
+// exposes the gateway on localhost unless a listen host is given.
+func (server *Server) gatewayAddr() string {
+	host := server.Host
+	if host == "" && server.SocketPath != "" {
+		host = "localhost"
+	}
+
+	return net.JoinHostPort(host, strconv.FormatUint(uint64(server.gatewayPort), 10))
+}
+
+func serveHTTP(ctx context.Context, addr string, handler http.Handler, cert *tls.Certificate) error {
+	httpServer := &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	if cert != nil {
+		httpServer.TLSConfig = &tls.Config{Certificates: []tls.Certificate{*cert}, MinVersion: tls.VersionTLS12}
+	}
+
+	go func() {
+		<-ctx.Done()
+		if err := httpServer.Shutdown(context.Background()); err != nil {
+			log.Errorf("failed to shut down REST gateway: %v", err)
+		}
+	}()
+
+	log.Infof("Serving REST gateway on %s", addr)
+	var err error
+	if cert != nil {
+		err = httpServer.ListenAndServeTLS("", "")
+	} else {
+		err = httpServer.ListenAndServe()
+	}
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve REST gateway: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package rpc
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/40acres/40swap/daemon/database/models"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+)
+
+func TestGateway(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repository := NewMockRepository(ctrl)
+
+	dir := t.TempDir()
+	macaroons, err := LoadOrCreateMacaroons(dir)
+	require.NoError(t, err)
+
+	server := NewRPCServer(0, repository, nil, nil, nil, 1000, Network_REGTEST,
+		grpc.ChainUnaryInterceptor(macaroons.UnaryServerInterceptor),
+	)
+	server.SocketPath = filepath.Join(t.TempDir(), "40swapd.sock")
+	go func() {
+		_ = server.ListenAndServe()
+	}()
+	t.Cleanup(server.Stop)
+
+	conn, err := NewConnection("", 0, ClientConfig{SocketPath: server.SocketPath})
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	handler, err := NewGateway(context.Background(), conn)
+	require.NoError(t, err)
+	gateway := httptest.NewServer(handler)
+	t.Cleanup(gateway.Close)
+
+	readonly, err := os.ReadFile(filepath.Join(dir, PermissionReadOnly+".macaroon"))
+	require.NoError(t, err)
+
+	repository.EXPECT().GetSwapIn(gomock.Any(), "abc").Return(&models.SwapIn{
+		SwapID:    "abc",
+		Status:    models.StatusCreated,
+		CreatedAt: time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC),
+	}, nil)
+
+	get := func(path string, mac []byte) *http.Response {
+		req, err := http.NewRequest(http.MethodGet, gateway.URL+path, nil)
+		require.NoError(t, err)
+		if mac != nil {
+			req.Header.Set("Macaroon", hex.EncodeToString(mac))
+		}
+		var res *http.Response
+		// The gRPC server may still be starting
+		require.Eventually(t, func() bool {
+			res, err = http.DefaultClient.Do(req)
+
+			return err == nil && res.StatusCode != http.StatusServiceUnavailable
+		}, 5*time.Second, 10*time.Millisecond)
+
+		return res
+	}
+
+	res := get("/v1/swap/in/abc", nil)
+	res.Body.Close()
+	require.Equal(t, http.StatusUnauthorized, res.StatusCode)
+
+	res = get("/v1/swap/in/abc", readonly)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	var body map[string]any
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+	require.Equal(t, "abc", body["id"])
+	require.Equal(t, "CREATED", body["status"])
+
+	res = get(openAPIPath, nil)
+	res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+}
+
+func TestServer_gatewayAddr(t *testing.T) {
+	tests := []struct {
+		name       string
+		host       string
+		socketPath string
+		want       string
+	}{
+		{name: "all interfaces", want: ":8080"},
+		{name: "listen host", host: "10.0.0.1", want: "10.0.0.1:8080"},
+		{name: "socket", socketPath: "40swapd.sock", want: "localhost:8080"},
+		{name: "socket and listen host", host: "0.0.0.0", socketPath: "40swapd.sock", want: "0.0.0.0:8080"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &Server{Host: tt.host, SocketPath: tt.socketPath, gatewayPort: 8080}
+			require.Equal(t, tt.want, server.gatewayAddr())
+		})
+	}
+}
@@ -5,11 +5,14 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/40acres/40swap/daemon/bitcoin"
+	"github.com/40acres/40swap/daemon/database"
 	"github.com/40acres/40swap/daemon/database/models"
 	"github.com/40acres/40swap/daemon/lightning"
+	"github.com/40acres/40swap/daemon/metrics"
 	"github.com/40acres/40swap/daemon/money"
 	"github.com/40acres/40swap/daemon/swaps"
 	"github.com/btcsuite/btcd/btcec/v2"
@@ -22,13 +25,40 @@ import (
 	"gorm.io/gorm"
 )
 
+// 0.5% is a good max value for Lightning Network
+const defaultMaxRoutingFeeRatio = 0.005
+
+// maxLabelLength is the longest label a swap can be tagged with
+const maxLabelLength = 256
+
 func (server *Server) SwapIn(ctx context.Context, req *SwapInRequest) (*SwapInResponse, error) {
 	log.Infof("Received SwapIn request: %v", req)
 	network := ToLightningNetworkType(server.network)
 
+	if err := validateSwapRequest(req.ClientRequestId, req.Label); err != nil {
+		return nil, err
+	}
+	// Requests with the same client request ID are handled one at a time, so
+	// only the first one creates a swap and the rest get it back
+	defer server.lockClientRequest(req.ClientRequestId)()
+	existing, err := server.swapInByClientRequestID(ctx, req.ClientRequestId)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		if err := checkSwapInRequest(existing, req); err != nil {
+			return nil, err
+		}
+		log.Infof("Swap %s was already created for client request %s", existing.SwapID, *req.ClientRequestId)
+
+		return toSwapInResponse(existing), nil
+	}
+
+	// Only the invoices generated here can be cancelled in the lightning node
+	invoiceGenerated := req.Invoice == nil
 	if req.Invoice == nil {
 		if req.AmountSats == nil {
-			return nil, fmt.Errorf("either invoice or amountSats must be provided")
+			return nil, invalidArgument("amountSats", "either invoice or amountSats must be provided")
 		}
 		amt := decimal.NewFromUint64(uint64(*req.AmountSats))
 
@@ -40,7 +70,7 @@ func (server *Server) SwapIn(ctx context.Context, req *SwapInRequest) (*SwapInRe
 
 		invoice, _, err := server.lightningClient.GenerateInvoice(ctx, amt, expiry, "")
 		if err != nil {
-			return nil, fmt.Errorf("could not generate invoice: %w", err)
+			return nil, unavailable(ServiceLightning, "could not generate invoice: %w", err)
 		}
 
 		req.Invoice = &invoice
@@ -50,24 +80,24 @@ func (server *Server) SwapIn(ctx context.Context, req *SwapInRequest) (*SwapInRe
 	if err != nil {
 		// Bug in zpay32 when using regtest invoice with mainnet network
 		if err.Error() == "strconv.ParseUint: parsing \"rt2\": invalid syntax" {
-			return nil, fmt.Errorf("invalid invoice: %w", errors.New("invoice not for current active network 'mainnet'"))
+			return nil, invalidArgument("invoice", "invalid invoice: %w", errors.New("invoice not for current active network 'mainnet'"))
 		}
 
-		return nil, fmt.Errorf("invalid invoice: %w", err)
+		return nil, invalidArgument("invoice", "invalid invoice: %w", err)
 	}
 
 	if invoice.MilliSat == nil {
-		return nil, fmt.Errorf("zero amount invoices are not supported")
+		return nil, invalidArgument("invoice", "zero amount invoices are not supported")
 	}
 	if req.AmountSats != nil && *req.AmountSats != uint64(*invoice.MilliSat/1000) {
-		return nil, fmt.Errorf("request amount %d does not match invoice amount %d", *req.AmountSats, *invoice.MilliSat/1000)
+		return nil, invalidArgument("amountSats", "request amount %d does not match invoice amount %d", *req.AmountSats, *invoice.MilliSat/1000)
 	}
 
 	// If the user didn't provide a refund address, generate one to the connected lightning node
 	if req.RefundTo == "" {
 		address, err := server.lightningClient.GenerateAddress(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("could not generate address: %w", err)
+			return nil, unavailable(ServiceLightning, "could not generate address: %w", err)
 		}
 
 		req.RefundTo = address
@@ -75,15 +105,15 @@ func (server *Server) SwapIn(ctx context.Context, req *SwapInRequest) (*SwapInRe
 
 	address, err := btcutil.DecodeAddress(req.RefundTo, lightning.ToChainCfgNetwork(network))
 	if err != nil {
-		return nil, fmt.Errorf("invalid refund address: %w", err)
+		return nil, invalidArgument("refundTo", "invalid refund address: %w", err)
 	}
 	if !address.IsForNet(lightning.ToChainCfgNetwork(network)) {
-		return nil, fmt.Errorf("invalid refund address: address is not for the current active network '%s'", network)
+		return nil, invalidArgument("refundTo", "invalid refund address: address is not for the current active network '%s'", network)
 	}
 
 	config, err := server.swapClient.GetConfiguration(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("could not get configuration: %w", err)
+		return nil, unavailable(ServiceSwapServer, "could not get configuration: %w", err)
 	}
 
 	var invoiceAmount decimal.Decimal
@@ -93,8 +123,8 @@ func (server *Server) SwapIn(ctx context.Context, req *SwapInRequest) (*SwapInRe
 		invoiceAmount = decimal.NewFromUint64(uint64(*req.AmountSats)).Div(decimal.NewFromInt(1e8))
 	}
 
-	if invoiceAmount.LessThan(config.MinimumAmount) || invoiceAmount.GreaterThan(config.MaximumAmount) {
-		return nil, fmt.Errorf("amount %s is not in the range [%s, %s]", invoiceAmount, config.MinimumAmount, config.MaximumAmount)
+	if err := checkAmount(invoiceAmount, config); err != nil {
+		return nil, err
 	}
 
 	feeRatio := config.FeePercentage.Div(decimal.NewFromInt(100))
@@ -113,13 +143,13 @@ func (server *Server) SwapIn(ctx context.Context, req *SwapInRequest) (*SwapInRe
 		Invoice:         *req.Invoice,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("could not create swap: %w", err)
+		return nil, swapServerError("invoice", "could not create swap: %w", err)
 	}
 	outputAmountSats := swap.OutputAmount.Mul(decimal.NewFromInt(1e8))
 	inputAmountSats := swap.InputAmount.Mul(decimal.NewFromInt(1e8))
 	timeoutBlockHeight := int64(swap.TimeoutBlockHeight)
 
-	err = server.Repository.SaveSwapIn(ctx, &models.SwapIn{
+	swapModel := models.SwapIn{
 		SwapID: swap.SwapId,
 		//nolint:gosec
 		AmountSats: int64(*invoice.MilliSat / 1000),
@@ -132,12 +162,22 @@ func (server *Server) SwapIn(ctx context.Context, req *SwapInRequest) (*SwapInRe
 		RefundPrivatekey:   hex.EncodeToString(refundPrivateKey.Serialize()),
 		RedeemScript:       swap.RedeemScript,
 		PaymentRequest:     *req.Invoice,
+		InvoiceGenerated:   invoiceGenerated,
 		ServiceFeeSats:     serviceFeeSats.IntPart(),
 		OnchainFeeSats:     inputAmountSats.Sub(outputAmountSats).Sub(serviceFeeSats).IntPart(),
-	})
+		ClientRequestID:    req.ClientRequestId,
+		Label:              req.Label,
+	}
+	err = server.Repository.SaveSwapIn(ctx, &swapModel)
 	if err != nil {
+		// A concurrent request with the same client request ID won the race
+		if existing, _ := server.swapInByClientRequestID(ctx, req.ClientRequestId); existing != nil {
+			return toSwapInResponse(existing), nil
+		}
+
 		return nil, fmt.Errorf("could not save swap: %w", err)
 	}
+	server.recordEvent(ctx, models.NewSwapInEvent(&swapModel, nil))
 
 	log.Info("Swap created: ", swap.SwapId)
 
@@ -153,12 +193,31 @@ func (server *Server) SwapOut(ctx context.Context, req *SwapOutRequest) (*SwapOu
 	log.Infof("Received SwapOut request: %v", req)
 	network := ToLightningNetworkType(server.network)
 
+	if err := validateSwapRequest(req.ClientRequestId, req.Label); err != nil {
+		return nil, err
+	}
+	// Requests with the same client request ID are handled one at a time, so
+	// only the first one creates a swap and the rest get it back
+	defer server.lockClientRequest(req.ClientRequestId)()
+	existing, err := server.swapOutByClientRequestID(ctx, req.ClientRequestId)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		if err := checkSwapOutRequest(existing, req); err != nil {
+			return nil, err
+		}
+		log.Infof("Swap %s was already created for client request %s", existing.SwapID, *req.ClientRequestId)
+
+		return toSwapOutResponse(existing), nil
+	}
+
 	// Validate request
 	// If the user didn't provide any address, generate one from the LND wallet
 	if req.Address == "" {
 		addr, err := server.lightningClient.GenerateAddress(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("could not generate address: %w", err)
+			return nil, unavailable(ServiceLightning, "could not generate address: %w", err)
 		}
 
 		req.Address = addr
@@ -166,11 +225,11 @@ func (server *Server) SwapOut(ctx context.Context, req *SwapOutRequest) (*SwapOu
 
 	config, err := server.swapClient.GetConfiguration(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("could not get configuration: %w", err)
+		return nil, unavailable(ServiceSwapServer, "could not get configuration: %w", err)
 	}
 	invoiceAmount := decimal.NewFromUint64(req.AmountSats).Div(decimal.NewFromInt(1e8))
-	if invoiceAmount.LessThan(config.MinimumAmount) || invoiceAmount.GreaterThan(config.MaximumAmount) {
-		return nil, fmt.Errorf("amount %s is not in the range [%s, %s]", invoiceAmount, config.MinimumAmount, config.MaximumAmount)
+	if err := checkAmount(invoiceAmount, config); err != nil {
+		return nil, err
 	}
 
 	feeRate := config.FeePercentage.Div(decimal.NewFromInt(100))
@@ -178,10 +237,10 @@ func (server *Server) SwapOut(ctx context.Context, req *SwapOutRequest) (*SwapOu
 
 	address, err := btcutil.DecodeAddress(req.Address, lightning.ToChainCfgNetwork(network))
 	if err != nil {
-		return nil, fmt.Errorf("invalid address: %w", err)
+		return nil, invalidArgument("address", "invalid address: %w", err)
 	}
 	if !address.IsForNet(lightning.ToChainCfgNetwork(network)) {
-		return nil, fmt.Errorf("invalid address: address is not for the current active network '%s'", network)
+		return nil, invalidArgument("address", "invalid address: address is not for the current active network '%s'", network)
 	}
 
 	// Private key for the claim
@@ -194,7 +253,7 @@ func (server *Server) SwapOut(ctx context.Context, req *SwapOutRequest) (*SwapOu
 	// Create swap out
 	swap, preimage, err := server.CreateSwapOut(ctx, pubkey, money.Money(req.AmountSats))
 	if err != nil {
-		return nil, fmt.Errorf("error creating the swap: %w", err)
+		return nil, swapServerError("amountSats", "error creating the swap: %w", err)
 	}
 
 	// Save swap to the database
@@ -203,7 +262,7 @@ func (server *Server) SwapOut(ctx context.Context, req *SwapOutRequest) (*SwapOu
 		return nil, fmt.Errorf("error converting amount to BTC: %w", err)
 	}
 
-	maxRoutingFeeRatio := 0.005 // 0.5% is a good max value for Lightning Network
+	maxRoutingFeeRatio := defaultMaxRoutingFeeRatio
 	if req.MaxRoutingFeePercent != nil {
 		maxRoutingFeeRatio = decimal.NewFromFloat32(*req.MaxRoutingFeePercent).
 			Div(decimal.NewFromInt(100)).
@@ -221,18 +280,22 @@ func (server *Server) SwapOut(ctx context.Context, req *SwapOutRequest) (*SwapOu
 		ServiceFeeSats:     serviceFeeSats.IntPart(),
 		MaxRoutingFeeRatio: maxRoutingFeeRatio,
 		PreImage:           preimage,
+		ClientRequestID:    req.ClientRequestId,
+		Label:              req.Label,
+		// The monitor pays the invoice, retrying until it expires
+		PaymentStatus: models.PaymentPending,
 	}
 
 	err = server.Repository.SaveSwapOut(ctx, &swapModel)
 	if err != nil {
-		return nil, err
-	}
+		// A concurrent request with the same client request ID won the race
+		if existing, _ := server.swapOutByClientRequestID(ctx, req.ClientRequestId); existing != nil {
+			return toSwapOutResponse(existing), nil
+		}
 
-	// Send L2 payment
-	err = server.lightningClient.PayInvoice(ctx, swap.Invoice, swapModel.MaxRoutingFeeRatio)
-	if err != nil {
-		return nil, fmt.Errorf("error paying the invoice: %w", err)
+		return nil, err
 	}
+	server.recordEvent(ctx, models.NewSwapOutEvent(&swapModel, nil))
 
 	log.Info("Swap created: ", swap.SwapId)
 
@@ -247,6 +310,159 @@ func (server *Server) SwapOut(ctx context.Context, req *SwapOutRequest) (*SwapOu
 	}, nil
 }
 
+// validateSwapRequest checks the client request ID and label of a request to
+// create a swap
+func validateSwapRequest(clientRequestID *string, label string) error {
+	if clientRequestID != nil && *clientRequestID == "" {
+		return invalidArgument("clientRequestId", "client request id can't be empty")
+	}
+	if len(label) > maxLabelLength {
+		return invalidArgument("label", "label can't be longer than %d characters", maxLabelLength)
+	}
+
+	return nil
+}
+
+// clientRequestLocks serializes the requests using the same client request ID
+type clientRequestLocks struct {
+	sync.Mutex
+	locks map[string]*clientRequestLock
+}
+
+type clientRequestLock struct {
+	sync.Mutex
+	// users is the number of requests holding or waiting for the lock
+	users int
+}
+
+// lockClientRequest waits until no other request is using the client request
+// ID and returns the function releasing it, it does nothing without an ID
+func (server *Server) lockClientRequest(clientRequestID *string) func() {
+	if clientRequestID == nil || server.clientRequests == nil {
+		return func() {}
+	}
+
+	requests := server.clientRequests
+	requests.Lock()
+	lock, ok := requests.locks[*clientRequestID]
+	if !ok {
+		lock = &clientRequestLock{}
+		requests.locks[*clientRequestID] = lock
+	}
+	lock.users++
+	requests.Unlock()
+
+	lock.Lock()
+
+	return func() {
+		lock.Unlock()
+
+		requests.Lock()
+		defer requests.Unlock()
+		lock.users--
+		if lock.users == 0 {
+			delete(requests.locks, *clientRequestID)
+		}
+	}
+}
+
+// checkSwapInRequest fails when a request reuses the client request ID of an
+// existing swap in to ask for a different one
+func checkSwapInRequest(existing *models.SwapIn, req *SwapInRequest) error {
+	id := *req.ClientRequestId
+	if req.Invoice != nil && *req.Invoice != existing.PaymentRequest {
+		return invalidArgument("invoice", "client request id %s was already used to swap a different invoice", id)
+	}
+	if req.AmountSats != nil && *req.AmountSats != uint64(existing.AmountSats) { // nolint:gosec
+		return invalidArgument("amountSats", "client request id %s was already used to swap %d sats", id, existing.AmountSats)
+	}
+	if req.RefundTo != "" && req.RefundTo != existing.RefundAddress {
+		return invalidArgument("refundTo", "client request id %s was already used with a different refund address", id)
+	}
+
+	return nil
+}
+
+// checkSwapOutRequest fails when a request reuses the client request ID of an
+// existing swap out to ask for a different one
+func checkSwapOutRequest(existing *models.SwapOut, req *SwapOutRequest) error {
+	id := *req.ClientRequestId
+	if req.AmountSats != uint64(existing.AmountSats) { // nolint:gosec
+		return invalidArgument("amountSats", "client request id %s was already used to swap %d sats", id, existing.AmountSats)
+	}
+	if req.Address != "" && req.Address != existing.DestinationAddress {
+		return invalidArgument("address", "client request id %s was already used with a different address", id)
+	}
+
+	return nil
+}
+
+// swapInByClientRequestID returns the swap in created by the request with the
+// client request ID, nil when there is none
+func (server *Server) swapInByClientRequestID(ctx context.Context, clientRequestID *string) (*models.SwapIn, error) {
+	if clientRequestID == nil {
+		return nil, nil
+	}
+
+	swap, err := server.Repository.GetSwapInByClientRequestID(ctx, *clientRequestID)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("could not get swap in: %w", err)
+	}
+
+	return swap, nil
+}
+
+// swapOutByClientRequestID returns the swap out created by the request with
+// the client request ID, nil when there is none
+func (server *Server) swapOutByClientRequestID(ctx context.Context, clientRequestID *string) (*models.SwapOut, error) {
+	if clientRequestID == nil {
+		return nil, nil
+	}
+
+	swap, err := server.Repository.GetSwapOutByClientRequestID(ctx, *clientRequestID)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("could not get swap out: %w", err)
+	}
+
+	return swap, nil
+}
+
+func toSwapInResponse(swap *models.SwapIn) *SwapInResponse {
+	return &SwapInResponse{
+		SwapId:        swap.SwapID,
+		AmountSats:    uint64(swap.AmountSats + swap.ServiceFeeSats + swap.OnchainFeeSats), // nolint:gosec
+		ClaimAddress:  swap.ClaimAddress,
+		RefundAddress: swap.RefundAddress,
+	}
+}
+
+func toSwapOutResponse(swap *models.SwapOut) *SwapOutResponse {
+	return &SwapOutResponse{
+		SwapId:     swap.SwapID,
+		AmountSats: uint64(swap.AmountSats), // nolint:gosec
+	}
+}
+
+// checkAmount returns an AmountOutOfRangeError when the amount, in BTC, is out
+// of the limits of the swap server
+func checkAmount(amount decimal.Decimal, config *swaps.ConfigurationResponse) error {
+	if amount.LessThan(config.MinimumAmount) || amount.GreaterThan(config.MaximumAmount) {
+		return &AmountOutOfRangeError{
+			AmountSats: uint64(amount.Mul(decimal.NewFromInt(1e8)).IntPart()),               // nolint:gosec
+			MinSats:    uint64(config.MinimumAmount.Mul(decimal.NewFromInt(1e8)).IntPart()), // nolint:gosec
+			MaxSats:    uint64(config.MaximumAmount.Mul(decimal.NewFromInt(1e8)).IntPart()), // nolint:gosec
+		}
+	}
+
+	return nil
+}
+
 // mapStatus maps the swap status from the database to the RPC status
 func mapStatus(status models.SwapStatus) (Status, error) {
 	switch status {
@@ -273,15 +489,31 @@ func mapStatus(status models.SwapStatus) (Status, error) {
 	}
 }
 
+// mapPaymentStatus maps the payment status of a swap out from the database to
+// the RPC status
+func mapPaymentStatus(status models.PaymentStatus) PaymentStatus {
+	switch status {
+	// A payment not checked with the node yet may be in flight
+	case models.PaymentInFlight, models.PaymentUnknown:
+		return PaymentStatus_PAYMENT_IN_FLIGHT
+	case models.PaymentSucceeded:
+		return PaymentStatus_PAYMENT_SUCCEEDED
+	case models.PaymentFailed:
+		return PaymentStatus_PAYMENT_FAILED
+	default:
+		return PaymentStatus_PAYMENT_PENDING
+	}
+}
+
 func (s *Server) GetSwapIn(ctx context.Context, req *GetSwapInRequest) (*GetSwapInResponse, error) {
 	if req.Id == "" {
-		return nil, fmt.Errorf("swap id is required")
+		return nil, invalidArgument("id", "swap id is required")
 	}
 
 	swap, err := s.Repository.GetSwapIn(ctx, req.Id)
 	if err != nil {
-		if errors.Is(err, swaps.ErrSwapNotFound) {
-			return nil, fmt.Errorf("swap not found: %w", err)
+		if errors.Is(err, gorm.ErrRecordNotFound) || errors.Is(err, swaps.ErrSwapNotFound) {
+			return nil, &NotFoundError{Resource: "swap in", ID: req.Id}
 		}
 
 		return nil, fmt.Errorf("could not get swap in: %w", err)
@@ -305,6 +537,9 @@ func (s *Server) GetSwapIn(ctx context.Context, req *GetSwapInRequest) (*GetSwap
 		RefundTxId:         &swap.RefundTxID,
 		ServiceFeeSats:     uint64(swap.ServiceFeeSats), // nolint:gosec
 		OnchainFeeSats:     uint64(swap.OnchainFeeSats), // nolint:gosec
+		Fiat:               toFiatValue(swap.FiatCurrency, swap.FiatRate, swap.AmountSats, swap.ServiceFeeSats, swap.OnchainFeeSats, 0),
+		Label:              swap.Label,
+		ClientRequestId:    swap.ClientRequestID,
 	}
 
 	if swap.Outcome != nil {
@@ -321,13 +556,13 @@ func (s *Server) GetSwapIn(ctx context.Context, req *GetSwapInRequest) (*GetSwap
 
 func (s *Server) GetSwapOut(ctx context.Context, req *GetSwapOutRequest) (*GetSwapOutResponse, error) {
 	if req.Id == "" {
-		return nil, fmt.Errorf("swap id is required")
+		return nil, invalidArgument("id", "swap id is required")
 	}
 
 	swap, err := s.Repository.GetSwapOut(ctx, req.Id)
 	if err != nil {
-		if errors.Is(err, swaps.ErrSwapNotFound) {
-			return nil, fmt.Errorf("swap not found: %w", err)
+		if errors.Is(err, gorm.ErrRecordNotFound) || errors.Is(err, swaps.ErrSwapNotFound) {
+			return nil, &NotFoundError{Resource: "swap out", ID: req.Id}
 		}
 
 		return nil, fmt.Errorf("could not get swap out: %w", err)
@@ -350,12 +585,20 @@ func (s *Server) GetSwapOut(ctx context.Context, req *GetSwapOutRequest) (*GetSw
 		ServiceFeeSats:     uint64(swap.ServiceFeeSats),  // nolint:gosec
 		OnchainFeeSats:     uint64(swap.OnchainFeeSats),  // nolint:gosec
 		OffchainFeeSats:    uint64(swap.OffchainFeeSats), // nolint:gosec
+		Fiat:               toFiatValue(swap.FiatCurrency, swap.FiatRate, swap.AmountSats, swap.ServiceFeeSats, swap.OnchainFeeSats, swap.OffchainFeeSats),
+		Label:              swap.Label,
+		ClientRequestId:    swap.ClientRequestID,
+		PaymentStatus:      mapPaymentStatus(swap.PaymentStatus),
+		PaymentAttempts:    uint32(swap.PaymentAttempts), // nolint:gosec
 	}
 
 	if swap.Outcome != nil {
 		outcome := swap.Outcome.String()
 		res.Outcome = &outcome
 	}
+	if swap.PaymentError != "" {
+		res.PaymentError = &swap.PaymentError
+	}
 
 	return res, nil
 }
@@ -364,14 +607,14 @@ func (s *Server) RecoverReusedSwapAddress(ctx context.Context, req *RecoverReuse
 	network := ToLightningNetworkType(s.network)
 	_, vout, err := bitcoin.ParseOutpoint(req.Outpoint)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse outpoint %s: %w", req.Outpoint, err)
+		return nil, invalidArgument("outpoint", "failed to parse outpoint %s: %w", req.Outpoint, err)
 	}
 
 	// If the user didn't provide a refund address, generate one to the connected lightning node
 	if req.RefundTo == nil {
 		address, err := s.lightningClient.GenerateAddress(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("could not generate address: %w", err)
+			return nil, unavailable(ServiceLightning, "could not generate address: %w", err)
 		}
 
 		req.RefundTo = &address
@@ -379,18 +622,18 @@ func (s *Server) RecoverReusedSwapAddress(ctx context.Context, req *RecoverReuse
 
 	tx, err := s.bitcoin.GetTxFromOutpoint(ctx, req.Outpoint)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get address from outpoint %s: %w", req.Outpoint, err)
+		return nil, unavailable(ServiceBitcoin, "failed to get address from outpoint %s: %w", req.Outpoint, err)
 	}
 
 	address, err := bitcoin.GetOutputAddress(tx, vout, network)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get address from output: %w", err)
+		return nil, invalidArgument("outpoint", "failed to get address from output: %w", err)
 	}
 
 	swap, err := s.Repository.GetSwapInByClaimAddress(ctx, address.String())
 	switch {
 	case errors.Is(err, gorm.ErrRecordNotFound):
-		return nil, fmt.Errorf("outpoint doesn't belong to any address registered for a swap in the database")
+		return nil, &NotFoundError{Resource: "swap with claim address", ID: address.String()}
 	case err != nil:
 		return nil, fmt.Errorf("failed to get swap from outpoint: %w", err)
 	}
@@ -402,11 +645,11 @@ func (s *Server) RecoverReusedSwapAddress(ctx context.Context, req *RecoverReuse
 
 	recommendedFeeRate, err := s.bitcoin.GetRecommendedFees(ctx, bitcoin.HalfHourFee)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get recommended fees: %w", err)
+		return nil, unavailable(ServiceBitcoin, "failed to get recommended fees: %w", err)
 	}
 
 	if recommendedFeeRate > 200 {
-		return nil, fmt.Errorf("recommended fee rate is too high: %d", recommendedFeeRate)
+		return nil, failedPrecondition("FEE_RATE", "bitcoin", "recommended fee rate is too high: %d", recommendedFeeRate)
 	}
 	logger.Infof("Claiming reused address outpoint for swap: %s", swap.SwapID)
 	pkt, err := bitcoin.BuildPSBTFromOutpoint(tx, swap.RedeemScript, req.Outpoint, *req.RefundTo, recommendedFeeRate, s.minRelayFee, network)
@@ -441,7 +684,7 @@ func (s *Server) RecoverReusedSwapAddress(ctx context.Context, req *RecoverReuse
 	logger.Debug("broadcasting transaction")
 	err = s.bitcoin.PostRefund(ctx, serializedTx)
 	if err != nil {
-		return nil, err
+		return nil, unavailable(ServiceBitcoin, "failed to broadcast refund: %w", err)
 	}
 
 	return &RecoverReusedSwapAddressResponse{
@@ -449,3 +692,318 @@ func (s *Server) RecoverReusedSwapAddress(ctx context.Context, req *RecoverReuse
 		RecoveredAmount: money.Money(pkt.Inputs[0].WitnessUtxo.Value).ToBtc().InexactFloat64(), //nolint:gosec
 	}, nil
 }
+
+// ReopenSwap resumes monitoring of a swap that was marked as failed, e.g.
+// because the server reported it as not found. The monitor picks up its real
+// status on the next iteration.
+func (s *Server) ReopenSwap(ctx context.Context, req *ReopenSwapRequest) (*ReopenSwapResponse, error) {
+	if req.Id == "" {
+		return nil, invalidArgument("id", "swap id is required")
+	}
+
+	swapIn, err := s.Repository.GetSwapIn(ctx, req.Id)
+	switch {
+	case err == nil:
+		if !canReopen(swapIn.Status, swapIn.Outcome) {
+			return nil, failedPrecondition("STATUS", "swap/"+req.Id, "swap in %s can't be reopened: only failed swaps can be reopened", req.Id)
+		}
+
+		previousStatus := swapIn.Status
+		swapIn.Status = models.StatusCreated
+		swapIn.Outcome = nil
+		swapIn.NotFoundSince = nil
+		if err := s.Repository.SaveSwapIn(ctx, swapIn); err != nil {
+			return nil, fmt.Errorf("could not save swap in: %w", err)
+		}
+
+		event := models.NewSwapInEvent(swapIn, &previousStatus)
+		event.Message = "reopened"
+		s.recordEvent(ctx, event)
+
+		return &ReopenSwapResponse{Id: req.Id, Type: "IN", Status: Status_CREATED}, nil
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return nil, fmt.Errorf("could not get swap in: %w", err)
+	}
+
+	swapOut, err := s.Repository.GetSwapOut(ctx, req.Id)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return nil, &NotFoundError{Resource: "swap", ID: req.Id}
+	case err != nil:
+		return nil, fmt.Errorf("could not get swap out: %w", err)
+	}
+
+	if !canReopen(swapOut.Status, swapOut.Outcome) {
+		return nil, failedPrecondition("STATUS", "swap/"+req.Id, "swap out %s can't be reopened: only failed swaps can be reopened", req.Id)
+	}
+
+	previousStatus := swapOut.Status
+	swapOut.Status = models.StatusCreated
+	swapOut.Outcome = nil
+	swapOut.NotFoundSince = nil
+	if err := s.Repository.SaveSwapOut(ctx, swapOut); err != nil {
+		return nil, fmt.Errorf("could not save swap out: %w", err)
+	}
+
+	event := models.NewSwapOutEvent(swapOut, &previousStatus)
+	event.Message = "reopened"
+	s.recordEvent(ctx, event)
+
+	return &ReopenSwapResponse{Id: req.Id, Type: "OUT", Status: Status_CREATED}, nil
+}
+
+func canReopen(status models.SwapStatus, outcome *models.SwapOutcome) bool {
+	return status == models.StatusDone && outcome != nil && *outcome == models.OutcomeFailed
+}
+
+func (s *Server) GetSwapTimeline(ctx context.Context, req *GetSwapTimelineRequest) (*GetSwapTimelineResponse, error) {
+	if req.Id == "" {
+		return nil, invalidArgument("id", "swap id is required")
+	}
+
+	events, err := s.Repository.GetSwapEvents(ctx, req.Id)
+	if err != nil {
+		return nil, fmt.Errorf("could not get swap events: %w", err)
+	}
+
+	res := &GetSwapTimelineResponse{
+		Id:     req.Id,
+		Events: make([]*SwapEvent, 0, len(events)),
+	}
+	for _, event := range events {
+		rpcEvent, err := toRPCSwapEvent(event)
+		if err != nil {
+			return nil, err
+		}
+		res.Events = append(res.Events, rpcEvent)
+	}
+
+	return res, nil
+}
+
+func toRPCSwapEvent(event *models.SwapEvent) (*SwapEvent, error) {
+	toStatus, err := mapStatus(event.ToStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &SwapEvent{
+		CreatedAt:       timestamppb.New(event.CreatedAt),
+		Type:            event.Direction.String(),
+		ToStatus:        toStatus,
+		ServiceFeeSats:  uint64(event.ServiceFeeSats),  // nolint:gosec
+		OnchainFeeSats:  uint64(event.OnchainFeeSats),  // nolint:gosec
+		OffchainFeeSats: uint64(event.OffchainFeeSats), // nolint:gosec
+		LockTxId:        optionalString(event.LockTxID),
+		ClaimTxId:       optionalString(event.ClaimTxID),
+		RefundTxId:      optionalString(event.RefundTxID),
+		Message:         optionalString(event.Message),
+		Error:           optionalString(event.Error),
+	}
+
+	if event.FromStatus != nil {
+		fromStatus, err := mapStatus(*event.FromStatus)
+		if err != nil {
+			return nil, err
+		}
+		res.FromStatus = &fromStatus
+	}
+	if event.Outcome != nil {
+		outcome := event.Outcome.String()
+		res.Outcome = &outcome
+	}
+
+	return res, nil
+}
+
+// recordEvent stores an event in the swap history and notifies the webhooks
+// about it. Failing to store it is logged but doesn't fail the request.
+func (s *Server) recordEvent(ctx context.Context, event *models.SwapEvent) {
+	metrics.ObserveSwapEvent(event)
+	if err := s.Repository.SaveSwapEvent(ctx, event); err != nil {
+		log.WithField("id", event.SwapID).Errorf("failed to save swap event: %v", err)
+	}
+	s.notifier.NotifySwapEvent(ctx, event)
+}
+
+// CancelSwap cancels a swap that hasn't been paid or funded yet. The invoice of
+// a swap in is cancelled in the lightning node when the node generated it and
+// the invoice of a swap out is no longer paid, either way the swap is done with
+// the cancelled outcome.
+// The contract of a cancelled swap in is watched until it expires, and is
+// refunded if it's funded anyway.
+func (s *Server) CancelSwap(ctx context.Context, req *CancelSwapRequest) (*CancelSwapResponse, error) {
+	if req.Id == "" {
+		return nil, invalidArgument("id", "swap id is required")
+	}
+
+	swapIn, err := s.Repository.GetSwapIn(ctx, req.Id)
+	switch {
+	case err == nil:
+		return s.cancelSwapIn(ctx, swapIn)
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return nil, fmt.Errorf("could not get swap in: %w", err)
+	}
+
+	swapOut, err := s.Repository.GetSwapOut(ctx, req.Id)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return nil, &NotFoundError{Resource: "swap", ID: req.Id}
+	case err != nil:
+		return nil, fmt.Errorf("could not get swap out: %w", err)
+	}
+
+	return s.cancelSwapOut(ctx, swapOut)
+}
+
+func (s *Server) cancelSwapIn(ctx context.Context, swap *models.SwapIn) (*CancelSwapResponse, error) {
+	if err := checkCancel("in", swap.SwapID, swap.Status, swap.TimeoutBlockHeight); err != nil {
+		return nil, err
+	}
+
+	// The lockup may not have been seen by the monitor yet
+	serverSwap, err := s.swapClient.GetSwapIn(ctx, swap.SwapID)
+	switch {
+	case errors.Is(err, swaps.ErrSwapNotFound):
+	case err != nil:
+		return nil, unavailable(ServiceSwapServer, "could not get swap in: %w", err)
+	default:
+		err := checkCancel("in", swap.SwapID, serverSwap.Status, int64(serverSwap.TimeoutBlockHeight))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The monitor may see the contract funded meanwhile, so the swap is only
+	// saved if it's still in the status it was read with
+	previousStatus := swap.Status
+	outcome := models.OutcomeCancelled
+	swap.Status = models.StatusDone
+	swap.Outcome = &outcome
+	err = s.Repository.SaveSwapInIf(ctx, swap, previousStatus)
+	if errors.Is(err, database.ErrSwapChanged) {
+		current, err := s.Repository.GetSwapIn(ctx, swap.SwapID)
+		if err != nil {
+			return nil, fmt.Errorf("could not get swap in: %w", err)
+		}
+		if err := checkCancel("in", current.SwapID, current.Status, current.TimeoutBlockHeight); err != nil {
+			return nil, err
+		}
+
+		return nil, failedPrecondition("STATUS", "swap/"+swap.SwapID, "swap in %s changed while being cancelled, try again", swap.SwapID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not save swap in: %w", err)
+	}
+
+	event := models.NewSwapInEvent(swap, &previousStatus)
+	event.Message = "cancelled"
+	s.recordEvent(ctx, event)
+
+	// The invoice is only cancelled once the swap is, so it stays payable if
+	// the contract is funded meanwhile. Failing to cancel it doesn't undo the
+	// cancel, it expires on its own.
+	if swap.InvoiceGenerated {
+		if err := s.cancelInvoice(ctx, swap.PaymentRequest); err != nil {
+			log.WithField("id", swap.SwapID).Errorf("failed to cancel the invoice of the cancelled swap: %v", err)
+		}
+	}
+
+	return &CancelSwapResponse{Id: swap.SwapID, Type: "IN", Status: Status_DONE, Outcome: outcome.String()}, nil
+}
+
+// cancelInvoice cancels an invoice generated by the lightning node
+func (s *Server) cancelInvoice(ctx context.Context, paymentRequest string) error {
+	invoice, err := zpay32.Decode(paymentRequest, lightning.ToChainCfgNetwork(ToLightningNetworkType(s.network)))
+	if err != nil {
+		return fmt.Errorf("could not decode invoice: %w", err)
+	}
+
+	return s.lightningClient.CancelInvoice(ctx, invoice.PaymentHash[:])
+}
+
+func (s *Server) cancelSwapOut(ctx context.Context, swap *models.SwapOut) (*CancelSwapResponse, error) {
+	if err := checkCancelSwapOut(swap); err != nil {
+		return nil, err
+	}
+
+	// The monitor may start paying the invoice meanwhile, so the swap is only
+	// saved if it's still in the status and payment status it was read with
+	previousStatus := swap.Status
+	previousPaymentStatus := swap.PaymentStatus
+	outcome := models.OutcomeCancelled
+	swap.Status = models.StatusDone
+	swap.Outcome = &outcome
+	swap.PaymentStatus = models.PaymentFailed
+	err := s.Repository.SaveSwapOutIf(ctx, swap, previousStatus, previousPaymentStatus)
+	if errors.Is(err, database.ErrSwapChanged) {
+		current, err := s.Repository.GetSwapOut(ctx, swap.SwapID)
+		if err != nil {
+			return nil, fmt.Errorf("could not get swap out: %w", err)
+		}
+		if err := checkCancelSwapOut(current); err != nil {
+			return nil, err
+		}
+
+		return nil, failedPrecondition("STATUS", "swap/"+swap.SwapID, "swap out %s changed while being cancelled, try again", swap.SwapID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not save swap out: %w", err)
+	}
+
+	event := models.NewSwapOutEvent(swap, &previousStatus)
+	event.Message = "cancelled"
+	s.recordEvent(ctx, event)
+
+	return &CancelSwapResponse{Id: swap.SwapID, Type: "OUT", Status: Status_DONE, Outcome: outcome.String()}, nil
+}
+
+// checkCancelSwapOut returns why a swap out can't be cancelled, on top of its
+// status its invoice mustn't be paid or being paid.
+func checkCancelSwapOut(swap *models.SwapOut) error {
+	if err := checkCancel("out", swap.SwapID, swap.Status, swap.TimeoutBlockHeight); err != nil {
+		return err
+	}
+	switch swap.PaymentStatus {
+	case models.PaymentSucceeded:
+		return failedPrecondition("STATUS", "swap/"+swap.SwapID, "swap out %s can't be cancelled: its invoice is paid", swap.SwapID)
+	case models.PaymentInFlight:
+		return failedPrecondition("STATUS", "swap/"+swap.SwapID, "swap out %s can't be cancelled: its invoice is being paid", swap.SwapID)
+	case models.PaymentUnknown:
+		return failedPrecondition("STATUS", "swap/"+swap.SwapID, "swap out %s can't be cancelled: its invoice may be being paid", swap.SwapID)
+	}
+
+	return nil
+}
+
+// checkCancel returns why a swap in a status can't be cancelled, only swaps
+// whose contract isn't funded yet can be. Funded swap ins are told the height
+// their contract can be refunded from, the contract of a funded swap out is
+// refunded by the server.
+func checkCancel(direction, id string, status models.SwapStatus, timeoutBlockHeight int64) error {
+	var reason string
+	switch status {
+	case models.StatusCreated:
+		return nil
+	case models.StatusDone:
+		reason = "it's already done"
+	case models.StatusInvoicePaymentIntentReceived:
+		reason = "its invoice is being paid"
+	case models.StatusInvoicePaid:
+		reason = "its invoice is paid"
+	case models.StatusContractClaimedUnconfirmed:
+		reason = "its contract is being claimed"
+	case models.StatusContractRefundedUnconfirmed:
+		reason = "its contract is being refunded"
+	case models.StatusContractExpired:
+		reason = "its contract expired and is being refunded"
+	default:
+		if direction == "in" {
+			reason = fmt.Sprintf("its contract is funded and can be refunded from block height %d", timeoutBlockHeight)
+		} else {
+			reason = "its contract is funded, the server refunds it if it isn't claimed"
+		}
+	}
+
+	return failedPrecondition("STATUS", "swap/"+id, "swap %s %s can't be cancelled: %s", direction, id, reason)
+}
@@ -4,15 +4,20 @@ import (
 	"context"
 	"errors"
 	"reflect"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/40acres/40swap/daemon/database"
 	"github.com/40acres/40swap/daemon/database/models"
 	"github.com/40acres/40swap/daemon/lightning"
+	"github.com/40acres/40swap/daemon/notifier"
 	"github.com/40acres/40swap/daemon/swaps"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"gorm.io/gorm"
 )
 
 func TestServer_SwapIn(t *testing.T) {
@@ -20,6 +25,7 @@ func TestServer_SwapIn(t *testing.T) {
 	swapId := "ugJHXnF12dUG"
 	amt := uint64(200000)
 	alternativeAmount := uint64(100)
+	clientRequestID := "request-1"
 	contractAddress := "bcrt1qey38yg6kjmtjxr28wrdrdhp22gu064xxj97006"
 	expiry := uint32(3 * 24 * 60 * 60)
 
@@ -29,6 +35,7 @@ func TestServer_SwapIn(t *testing.T) {
 	lightningClient := lightning.NewMockClient(ctrl)
 	swapClient := swaps.NewMockClientInterface(ctrl)
 	reposistory := NewMockRepository(ctrl)
+	reposistory.EXPECT().SaveSwapEvent(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 	server := Server{
 		lightningClient: lightningClient,
 		swapClient:      swapClient,
@@ -171,7 +178,11 @@ func TestServer_SwapIn(t *testing.T) {
 					TimeoutBlockHeight: 1000,
 					RedeemScript:       "test",
 				}, nil)
-				reposistory.EXPECT().SaveSwapIn(ctx, gomock.Any()).Return(nil)
+				reposistory.EXPECT().SaveSwapIn(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, swap *models.SwapIn) error {
+					require.True(t, swap.InvoiceGenerated)
+
+					return nil
+				})
 
 				return &server
 			},
@@ -204,7 +215,11 @@ func TestServer_SwapIn(t *testing.T) {
 					TimeoutBlockHeight: 1000,
 					RedeemScript:       "test",
 				}, nil)
-				reposistory.EXPECT().SaveSwapIn(ctx, gomock.Any()).Return(nil)
+				reposistory.EXPECT().SaveSwapIn(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, swap *models.SwapIn) error {
+					require.False(t, swap.InvoiceGenerated)
+
+					return nil
+				})
 
 				return &server
 			},
@@ -218,6 +233,24 @@ func TestServer_SwapIn(t *testing.T) {
 				RefundAddress: "bcrt1q76kh4zg0vfkt7yy8dz8tpfwqgcnm0pxd76az73d8wmqgln5640fsdy0mjx",
 			},
 		},
+		{
+			name: "Client request id reused for a different invoice",
+			setup: func() *Server {
+				reposistory.EXPECT().GetSwapInByClientRequestID(ctx, "request-1").Return(&models.SwapIn{
+					SwapID:         swapId,
+					AmountSats:     int64(amt),
+					PaymentRequest: "other-invoice",
+				}, nil)
+
+				return &server
+			},
+			req: &SwapInRequest{
+				Invoice:         &invoice,
+				ClientRequestId: &clientRequestID,
+			},
+			wantErr: true,
+			err:     errors.New("client request id request-1 was already used to swap a different invoice"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -277,6 +310,7 @@ func TestStatus_SwapIn(t *testing.T) {
 	require.Equal(t, 0.013, res.OutputAmount)
 	require.Equal(t, "dummy-redeem-script", res.RedeemScript)
 	require.Equal(t, uint32(12345), res.TimeoutBlockHeight)
+	require.Nil(t, res.Fiat)
 }
 
 func TestStatus_SwapOut(t *testing.T) {
@@ -292,6 +326,7 @@ func TestStatus_SwapOut(t *testing.T) {
 	req := &GetSwapOutRequest{
 		Id: "swap-out-id",
 	}
+	fiatRate := decimal.NewFromInt(65000)
 
 	mockRepositoryClient.EXPECT().GetSwapOut(ctx, "swap-out-id").Return(&models.SwapOut{
 		SwapID:             "swap-out-id",
@@ -301,6 +336,11 @@ func TestStatus_SwapOut(t *testing.T) {
 		AmountSats:         200000,
 		ServiceFeeSats:     1000,
 		CreatedAt:          time.Now(),
+		FiatCurrency:       "USD",
+		FiatRate:           &fiatRate,
+		PaymentStatus:      models.PaymentInFlight,
+		PaymentAttempts:    2,
+		PaymentError:       "payment failed: FAILURE_REASON_NO_ROUTE",
 	}, nil)
 
 	res, err := server.GetSwapOut(ctx, req)
@@ -313,6 +353,10 @@ func TestStatus_SwapOut(t *testing.T) {
 	require.Equal(t, 0.002, res.InputAmount)
 	require.Equal(t, 0.00199, res.OutputAmount)
 	require.NotZero(t, res.CreatedAt)
+	require.Equal(t, &FiatValue{Currency: "USD", Rate: 65000, Amount: 130, ServiceFee: 0.65}, res.Fiat)
+	require.Equal(t, PaymentStatus_PAYMENT_IN_FLIGHT, res.PaymentStatus)
+	require.Equal(t, uint32(2), res.PaymentAttempts)
+	require.Equal(t, "payment failed: FAILURE_REASON_NO_ROUTE", res.GetPaymentError())
 }
 
 func TestConvertStatus(t *testing.T) {
@@ -402,6 +446,8 @@ func TestServer_SwapOut(t *testing.T) {
 	swapId := "ugJHXnF12dUG"
 	amt := uint64(200000)
 	address := "bcrt1qey38yg6kjmtjxr28wrdrdhp22gu064xxj97006"
+	clientRequestID := "request-1"
+	emptyClientRequestID := ""
 
 	ctrl := gomock.NewController(t)
 	t.Cleanup(ctrl.Finish)
@@ -409,6 +455,7 @@ func TestServer_SwapOut(t *testing.T) {
 	lightningClient := lightning.NewMockClient(ctrl)
 	swapClient := swaps.NewMockClientInterface(ctrl)
 	reposistory := NewMockRepository(ctrl)
+	reposistory.EXPECT().SaveSwapEvent(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 	server := Server{
 		lightningClient: lightningClient,
 		swapClient:      swapClient,
@@ -623,19 +670,24 @@ func TestServer_SwapOut(t *testing.T) {
 			err:     errors.New("failed to save swap out"),
 		},
 		{
-			name: "fail to pay invoice",
+			name: "invoice is left to the monitor to pay",
 			setup: func() *Server {
 				swapClient.EXPECT().GetConfiguration(ctx).Return(&swaps.ConfigurationResponse{
 					MinimumAmount: decimal.NewFromFloat(0.001),
 					MaximumAmount: decimal.NewFromFloat(0.01),
 				}, nil)
 				swapClient.EXPECT().CreateSwapOut(ctx, gomock.Any()).Return(&swaps.SwapOutResponse{
-					SwapId:  swapId,
-					Status:  models.StatusCreated,
-					Invoice: "dummy-invoice",
+					SwapId:      swapId,
+					Status:      models.StatusCreated,
+					Invoice:     "dummy-invoice",
+					InputAmount: decimal.NewFromFloat(0.00200105),
 				}, nil)
-				reposistory.EXPECT().SaveSwapOut(ctx, gomock.Any()).Return(nil)
-				lightningClient.EXPECT().PayInvoice(ctx, "dummy-invoice", gomock.Any()).Return(errors.New("failed to pay invoice"))
+				reposistory.EXPECT().SaveSwapOut(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, swap *models.SwapOut) error {
+					require.Equal(t, models.PaymentPending, swap.PaymentStatus)
+					require.Zero(t, swap.PaymentAttempts)
+
+					return nil
+				})
 
 				return &server
 			},
@@ -646,9 +698,10 @@ func TestServer_SwapOut(t *testing.T) {
 					Address:    address,
 				},
 			},
-			want:    nil,
-			wantErr: true,
-			err:     errors.New("error paying the invoice: failed to pay invoice"),
+			want: &SwapOutResponse{
+				SwapId:     swapId,
+				AmountSats: 200105,
+			},
 		},
 		{
 			name: "valid request (lnd address)",
@@ -668,7 +721,6 @@ func TestServer_SwapOut(t *testing.T) {
 					CreatedAt:          time.Now(),
 				}, nil)
 				reposistory.EXPECT().SaveSwapOut(ctx, gomock.Any()).Return(nil)
-				lightningClient.EXPECT().PayInvoice(ctx, "dummy-invoice", gomock.Any()).Return(nil)
 
 				return &server
 			},
@@ -702,7 +754,6 @@ func TestServer_SwapOut(t *testing.T) {
 					CreatedAt:          time.Now(),
 				}, nil)
 				reposistory.EXPECT().SaveSwapOut(ctx, gomock.Any()).Return(nil)
-				lightningClient.EXPECT().PayInvoice(ctx, "dummy-invoice", gomock.Any()).Return(nil)
 
 				return &server
 			},
@@ -720,6 +771,127 @@ func TestServer_SwapOut(t *testing.T) {
 			wantErr: false,
 			err:     nil,
 		},
+		{
+			name: "repeated client request returns the existing swap",
+			setup: func() *Server {
+				reposistory.EXPECT().GetSwapOutByClientRequestID(ctx, "request-1").Return(&models.SwapOut{
+					SwapID:             swapId,
+					AmountSats:         int64(amt),
+					DestinationAddress: address,
+				}, nil)
+
+				return &server
+			},
+			args: args{
+				ctx: ctx,
+				req: &SwapOutRequest{
+					AmountSats:      amt,
+					Address:         address,
+					ClientRequestId: &clientRequestID,
+				},
+			},
+			want: &SwapOutResponse{
+				SwapId:     swapId,
+				AmountSats: amt,
+			},
+		},
+		{
+			name: "client request id reused for a different amount",
+			setup: func() *Server {
+				reposistory.EXPECT().GetSwapOutByClientRequestID(ctx, "request-1").Return(&models.SwapOut{
+					SwapID:             swapId,
+					AmountSats:         int64(amt),
+					DestinationAddress: address,
+				}, nil)
+
+				return &server
+			},
+			args: args{
+				ctx: ctx,
+				req: &SwapOutRequest{
+					AmountSats:      amt + 1,
+					Address:         address,
+					ClientRequestId: &clientRequestID,
+				},
+			},
+			wantErr: true,
+			err:     errors.New("client request id request-1 was already used to swap 200000 sats"),
+		},
+		{
+			name: "client request id reused for a different address",
+			setup: func() *Server {
+				reposistory.EXPECT().GetSwapOutByClientRequestID(ctx, "request-1").Return(&models.SwapOut{
+					SwapID:             swapId,
+					AmountSats:         int64(amt),
+					DestinationAddress: "bcrt1q76kh4zg0vfkt7yy8dz8tpfwqgcnm0pxd76az73d8wmqgln5640fsdy0mjx",
+				}, nil)
+
+				return &server
+			},
+			args: args{
+				ctx: ctx,
+				req: &SwapOutRequest{
+					AmountSats:      amt,
+					Address:         address,
+					ClientRequestId: &clientRequestID,
+				},
+			},
+			wantErr: true,
+			err:     errors.New("client request id request-1 was already used with a different address"),
+		},
+		{
+			name: "new client request stores its id and label",
+			setup: func() *Server {
+				reposistory.EXPECT().GetSwapOutByClientRequestID(ctx, "request-1").Return(nil, gorm.ErrRecordNotFound)
+				swapClient.EXPECT().GetConfiguration(ctx).Return(&swaps.ConfigurationResponse{
+					MinimumAmount: decimal.NewFromFloat(0.001),
+					MaximumAmount: decimal.NewFromFloat(0.01),
+				}, nil)
+				swapClient.EXPECT().CreateSwapOut(ctx, gomock.Any()).Return(&swaps.SwapOutResponse{
+					SwapId:       swapId,
+					Status:       models.StatusCreated,
+					Invoice:      "dummy-invoice",
+					InputAmount:  decimal.NewFromFloat(0.00200105),
+					OutputAmount: decimal.NewFromFloat(0.00200000),
+				}, nil)
+				reposistory.EXPECT().SaveSwapOut(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, swap *models.SwapOut) error {
+					require.Equal(t, &clientRequestID, swap.ClientRequestID)
+					require.Equal(t, "rebalance", swap.Label)
+
+					return nil
+				})
+
+				return &server
+			},
+			args: args{
+				ctx: ctx,
+				req: &SwapOutRequest{
+					AmountSats:      amt,
+					Address:         address,
+					ClientRequestId: &clientRequestID,
+					Label:           "rebalance",
+				},
+			},
+			want: &SwapOutResponse{
+				SwapId:     swapId,
+				AmountSats: 200105,
+			},
+		},
+		{
+			name: "empty client request id",
+			setup: func() *Server {
+				return &server
+			},
+			args: args{
+				ctx: ctx,
+				req: &SwapOutRequest{
+					AmountSats:      amt,
+					ClientRequestId: &emptyClientRequestID,
+				},
+			},
+			wantErr: true,
+			err:     errors.New("client request id can't be empty"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -739,3 +911,536 @@ func TestServer_SwapOut(t *testing.T) {
 		})
 	}
 }
+
+func TestServer_SwapOut_ConcurrentClientRequests(t *testing.T) {
+	ctx := context.Background()
+	amt := uint64(200000)
+	address := "bcrt1qey38yg6kjmtjxr28wrdrdhp22gu064xxj97006"
+	clientRequestID := "request-1"
+
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	swapClient := swaps.NewMockClientInterface(ctrl)
+	repository := NewMockRepository(ctrl)
+	repository.EXPECT().SaveSwapEvent(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	server := NewRPCServer(0, repository, swapClient, nil, nil, 1000, Network_REGTEST)
+
+	var mu sync.Mutex
+	var saved *models.SwapOut
+	repository.EXPECT().GetSwapOutByClientRequestID(ctx, clientRequestID).DoAndReturn(func(context.Context, string) (*models.SwapOut, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if saved == nil {
+			return nil, gorm.ErrRecordNotFound
+		}
+
+		return saved, nil
+	}).Times(2)
+	repository.EXPECT().SaveSwapOut(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, swap *models.SwapOut) error {
+		mu.Lock()
+		defer mu.Unlock()
+		saved = swap
+
+		return nil
+	})
+	// Slow enough for the other request to look the client request ID up
+	// while the first one is creating the swap
+	swapClient.EXPECT().GetConfiguration(ctx).DoAndReturn(func(context.Context) (*swaps.ConfigurationResponse, error) {
+		time.Sleep(50 * time.Millisecond)
+
+		return &swaps.ConfigurationResponse{
+			MinimumAmount: decimal.NewFromFloat(0.001),
+			MaximumAmount: decimal.NewFromFloat(0.01),
+		}, nil
+	})
+	// Only one of the requests creates the swap in the server
+	swapClient.EXPECT().CreateSwapOut(ctx, gomock.Any()).Return(&swaps.SwapOutResponse{
+		SwapId:      "ugJHXnF12dUG",
+		Status:      models.StatusCreated,
+		Invoice:     "dummy-invoice",
+		InputAmount: decimal.NewFromFloat(0.002),
+	}, nil)
+
+	var wg sync.WaitGroup
+	responses := make([]*SwapOutResponse, 2)
+	errs := make([]error, 2)
+	for i := range responses {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			responses[i], errs[i] = server.SwapOut(ctx, &SwapOutRequest{
+				AmountSats:      amt,
+				Address:         address,
+				ClientRequestId: &clientRequestID,
+			})
+		}()
+	}
+	wg.Wait()
+
+	require.NoError(t, errors.Join(errs...))
+	require.Equal(t, responses[0], responses[1])
+	require.Empty(t, server.clientRequests.locks)
+}
+
+func TestServer_ReopenSwap(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := NewMockRepository(ctrl)
+	mockRepository.EXPECT().SaveSwapEvent(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	server := &Server{
+		Repository: mockRepository,
+	}
+	ctx := context.Background()
+	outcomeFailed := models.OutcomeFailed
+	outcomeSuccess := models.OutcomeSuccess
+	notFoundSince := time.Now()
+
+	tests := []struct {
+		name     string
+		setup    func()
+		want     *ReopenSwapResponse
+		wantErr  string
+		wantCode codes.Code
+	}{
+		{
+			name: "failed swap in",
+			setup: func() {
+				mockRepository.EXPECT().GetSwapIn(ctx, "swap-id").Return(&models.SwapIn{
+					SwapID:        "swap-id",
+					Status:        models.StatusDone,
+					Outcome:       &outcomeFailed,
+					NotFoundSince: &notFoundSince,
+				}, nil)
+				mockRepository.EXPECT().SaveSwapIn(ctx, &models.SwapIn{
+					SwapID: "swap-id",
+					Status: models.StatusCreated,
+				}).Return(nil)
+			},
+			want: &ReopenSwapResponse{Id: "swap-id", Type: "IN", Status: Status_CREATED},
+		},
+		{
+			name: "failed swap out",
+			setup: func() {
+				mockRepository.EXPECT().GetSwapIn(ctx, "swap-id").Return(nil, gorm.ErrRecordNotFound)
+				mockRepository.EXPECT().GetSwapOut(ctx, "swap-id").Return(&models.SwapOut{
+					SwapID:  "swap-id",
+					Status:  models.StatusDone,
+					Outcome: &outcomeFailed,
+				}, nil)
+				mockRepository.EXPECT().SaveSwapOut(ctx, &models.SwapOut{
+					SwapID: "swap-id",
+					Status: models.StatusCreated,
+				}).Return(nil)
+			},
+			want: &ReopenSwapResponse{Id: "swap-id", Type: "OUT", Status: Status_CREATED},
+		},
+		{
+			name: "successful swap can't be reopened",
+			setup: func() {
+				mockRepository.EXPECT().GetSwapIn(ctx, "swap-id").Return(&models.SwapIn{
+					SwapID:  "swap-id",
+					Status:  models.StatusDone,
+					Outcome: &outcomeSuccess,
+				}, nil)
+			},
+			wantErr:  "only failed swaps can be reopened",
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name: "unknown swap",
+			setup: func() {
+				mockRepository.EXPECT().GetSwapIn(ctx, "swap-id").Return(nil, gorm.ErrRecordNotFound)
+				mockRepository.EXPECT().GetSwapOut(ctx, "swap-id").Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantErr:  "swap swap-id not found",
+			wantCode: codes.NotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			res, err := server.ReopenSwap(ctx, &ReopenSwapRequest{Id: "swap-id"})
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				require.Equal(t, tt.wantCode, toStatus(err).Code())
+
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, res)
+		})
+	}
+}
+
+func TestServer_CancelSwap(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := NewMockRepository(ctrl)
+	mockRepository.EXPECT().SaveSwapEvent(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	lightningClient := lightning.NewMockClient(ctrl)
+	swapClient := swaps.NewMockClientInterface(ctrl)
+	server := &Server{
+		Repository:      mockRepository,
+		lightningClient: lightningClient,
+		swapClient:      swapClient,
+		network:         2, // regtest
+	}
+	ctx := context.Background()
+	invoice := lightning.CreateMockInvoice(t, 1000)
+	outcomeCancelled := models.OutcomeCancelled
+
+	tests := []struct {
+		name     string
+		setup    func()
+		want     *CancelSwapResponse
+		wantErr  string
+		wantCode codes.Code
+	}{
+		{
+			name: "unfunded swap in",
+			setup: func() {
+				mockRepository.EXPECT().GetSwapIn(ctx, "swap-id").Return(&models.SwapIn{
+					SwapID:           "swap-id",
+					Status:           models.StatusCreated,
+					PaymentRequest:   invoice,
+					InvoiceGenerated: true,
+				}, nil)
+				swapClient.EXPECT().GetSwapIn(ctx, "swap-id").Return(&swaps.SwapInResponse{Status: models.StatusCreated}, nil)
+				save := mockRepository.EXPECT().SaveSwapInIf(ctx, &models.SwapIn{
+					SwapID:           "swap-id",
+					Status:           models.StatusDone,
+					Outcome:          &outcomeCancelled,
+					PaymentRequest:   invoice,
+					InvoiceGenerated: true,
+				}, models.StatusCreated).Return(nil)
+				lightningClient.EXPECT().CancelInvoice(ctx, lightning.TestPaymentHash[:]).Return(nil).After(save)
+			},
+			want: &CancelSwapResponse{Id: "swap-id", Type: "IN", Status: Status_DONE, Outcome: "CANCELLED"},
+		},
+		{
+			name: "unfunded swap in paying an external invoice",
+			setup: func() {
+				// The lightning node didn't create the invoice, so it's left alone
+				mockRepository.EXPECT().GetSwapIn(ctx, "swap-id").Return(&models.SwapIn{
+					SwapID:         "swap-id",
+					Status:         models.StatusCreated,
+					PaymentRequest: invoice,
+				}, nil)
+				swapClient.EXPECT().GetSwapIn(ctx, "swap-id").Return(&swaps.SwapInResponse{Status: models.StatusCreated}, nil)
+				mockRepository.EXPECT().SaveSwapInIf(ctx, gomock.Any(), models.StatusCreated).Return(nil)
+			},
+			want: &CancelSwapResponse{Id: "swap-id", Type: "IN", Status: Status_DONE, Outcome: "CANCELLED"},
+		},
+		{
+			name: "swap in whose invoice can't be cancelled",
+			setup: func() {
+				mockRepository.EXPECT().GetSwapIn(ctx, "swap-id").Return(&models.SwapIn{
+					SwapID:           "swap-id",
+					Status:           models.StatusCreated,
+					PaymentRequest:   invoice,
+					InvoiceGenerated: true,
+				}, nil)
+				swapClient.EXPECT().GetSwapIn(ctx, "swap-id").Return(&swaps.SwapInResponse{Status: models.StatusCreated}, nil)
+				mockRepository.EXPECT().SaveSwapInIf(ctx, gomock.Any(), models.StatusCreated).Return(nil)
+				lightningClient.EXPECT().CancelInvoice(ctx, lightning.TestPaymentHash[:]).Return(errors.New("lnd is down"))
+			},
+			want: &CancelSwapResponse{Id: "swap-id", Type: "IN", Status: Status_DONE, Outcome: "CANCELLED"},
+		},
+		{
+			name: "swap in funded while being cancelled",
+			setup: func() {
+				mockRepository.EXPECT().GetSwapIn(ctx, "swap-id").Return(&models.SwapIn{
+					SwapID:           "swap-id",
+					Status:           models.StatusCreated,
+					PaymentRequest:   invoice,
+					InvoiceGenerated: true,
+				}, nil)
+				swapClient.EXPECT().GetSwapIn(ctx, "swap-id").Return(&swaps.SwapInResponse{Status: models.StatusCreated}, nil)
+				// The monitor saved the lockup after the swap was read, so the
+				// invoice stays open
+				mockRepository.EXPECT().SaveSwapInIf(ctx, gomock.Any(), models.StatusCreated).Return(database.ErrSwapChanged)
+				mockRepository.EXPECT().GetSwapIn(ctx, "swap-id").Return(&models.SwapIn{
+					SwapID:             "swap-id",
+					Status:             models.StatusContractFundedUnconfirmed,
+					TimeoutBlockHeight: 850000,
+				}, nil)
+			},
+			wantErr:  "its contract is funded and can be refunded from block height 850000",
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name: "swap in funded in the server",
+			setup: func() {
+				mockRepository.EXPECT().GetSwapIn(ctx, "swap-id").Return(&models.SwapIn{
+					SwapID:         "swap-id",
+					Status:         models.StatusCreated,
+					PaymentRequest: invoice,
+				}, nil)
+				swapClient.EXPECT().GetSwapIn(ctx, "swap-id").Return(&swaps.SwapInResponse{
+					Status:             models.StatusContractFundedUnconfirmed,
+					TimeoutBlockHeight: 850000,
+				}, nil)
+			},
+			wantErr:  "its contract is funded and can be refunded from block height 850000",
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name: "funded swap in",
+			setup: func() {
+				mockRepository.EXPECT().GetSwapIn(ctx, "swap-id").Return(&models.SwapIn{
+					SwapID:             "swap-id",
+					Status:             models.StatusContractFunded,
+					TimeoutBlockHeight: 850000,
+				}, nil)
+			},
+			wantErr:  "swap in swap-id can't be cancelled: its contract is funded and can be refunded from block height 850000",
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name: "unpaid swap out",
+			setup: func() {
+				mockRepository.EXPECT().GetSwapIn(ctx, "swap-id").Return(nil, gorm.ErrRecordNotFound)
+				mockRepository.EXPECT().GetSwapOut(ctx, "swap-id").Return(&models.SwapOut{
+					SwapID:        "swap-id",
+					Status:        models.StatusCreated,
+					PaymentStatus: models.PaymentPending,
+				}, nil)
+				mockRepository.EXPECT().SaveSwapOutIf(ctx, &models.SwapOut{
+					SwapID:        "swap-id",
+					Status:        models.StatusDone,
+					Outcome:       &outcomeCancelled,
+					PaymentStatus: models.PaymentFailed,
+				}, models.StatusCreated, models.PaymentPending).Return(nil)
+			},
+			want: &CancelSwapResponse{Id: "swap-id", Type: "OUT", Status: Status_DONE, Outcome: "CANCELLED"},
+		},
+		{
+			name: "swap out whose payment isn't checked yet",
+			setup: func() {
+				mockRepository.EXPECT().GetSwapIn(ctx, "swap-id").Return(nil, gorm.ErrRecordNotFound)
+				mockRepository.EXPECT().GetSwapOut(ctx, "swap-id").Return(&models.SwapOut{
+					SwapID:        "swap-id",
+					Status:        models.StatusCreated,
+					PaymentStatus: models.PaymentUnknown,
+				}, nil)
+			},
+			wantErr:  "swap out swap-id can't be cancelled: its invoice may be being paid",
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name: "swap out paid while being cancelled",
+			setup: func() {
+				mockRepository.EXPECT().GetSwapIn(ctx, "swap-id").Return(nil, gorm.ErrRecordNotFound)
+				mockRepository.EXPECT().GetSwapOut(ctx, "swap-id").Return(&models.SwapOut{
+					SwapID:        "swap-id",
+					Status:        models.StatusCreated,
+					PaymentStatus: models.PaymentPending,
+				}, nil)
+				// The monitor saved the payment as in flight after the swap was read
+				mockRepository.EXPECT().SaveSwapOutIf(ctx, gomock.Any(), models.StatusCreated, models.PaymentPending).
+					Return(database.ErrSwapChanged)
+				mockRepository.EXPECT().GetSwapOut(ctx, "swap-id").Return(&models.SwapOut{
+					SwapID:        "swap-id",
+					Status:        models.StatusCreated,
+					PaymentStatus: models.PaymentInFlight,
+				}, nil)
+			},
+			wantErr:  "swap out swap-id can't be cancelled: its invoice is being paid",
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name: "swap out changed while being cancelled",
+			setup: func() {
+				mockRepository.EXPECT().GetSwapIn(ctx, "swap-id").Return(nil, gorm.ErrRecordNotFound)
+				mockRepository.EXPECT().GetSwapOut(ctx, "swap-id").Return(&models.SwapOut{
+					SwapID:        "swap-id",
+					Status:        models.StatusCreated,
+					PaymentStatus: models.PaymentFailed,
+				}, nil)
+				mockRepository.EXPECT().SaveSwapOutIf(ctx, gomock.Any(), models.StatusCreated, models.PaymentFailed).
+					Return(database.ErrSwapChanged)
+				// Read again after the failed save, the swap still looks cancellable
+				mockRepository.EXPECT().GetSwapOut(ctx, "swap-id").Return(&models.SwapOut{
+					SwapID:        "swap-id",
+					Status:        models.StatusCreated,
+					PaymentStatus: models.PaymentPending,
+				}, nil)
+			},
+			wantErr:  "swap out swap-id changed while being cancelled, try again",
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name: "swap out being paid",
+			setup: func() {
+				mockRepository.EXPECT().GetSwapIn(ctx, "swap-id").Return(nil, gorm.ErrRecordNotFound)
+				mockRepository.EXPECT().GetSwapOut(ctx, "swap-id").Return(&models.SwapOut{
+					SwapID:        "swap-id",
+					Status:        models.StatusCreated,
+					PaymentStatus: models.PaymentInFlight,
+				}, nil)
+			},
+			wantErr:  "its invoice is being paid",
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name: "done swap",
+			setup: func() {
+				mockRepository.EXPECT().GetSwapIn(ctx, "swap-id").Return(nil, gorm.ErrRecordNotFound)
+				mockRepository.EXPECT().GetSwapOut(ctx, "swap-id").Return(&models.SwapOut{
+					SwapID: "swap-id",
+					Status: models.StatusDone,
+				}, nil)
+			},
+			wantErr:  "it's already done",
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name: "unknown swap",
+			setup: func() {
+				mockRepository.EXPECT().GetSwapIn(ctx, "swap-id").Return(nil, gorm.ErrRecordNotFound)
+				mockRepository.EXPECT().GetSwapOut(ctx, "swap-id").Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantErr:  "swap swap-id not found",
+			wantCode: codes.NotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			res, err := server.CancelSwap(ctx, &CancelSwapRequest{Id: "swap-id"})
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				require.Equal(t, tt.wantCode, toStatus(err).Code())
+
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, res)
+		})
+	}
+}
+
+func TestServer_CancelSwap_NotifiesWebhooks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepository := NewMockRepository(ctrl)
+	webhooks := notifier.NewMockRepository(ctrl)
+	server := &Server{
+		Repository: mockRepository,
+		notifier:   notifier.New(webhooks, notifier.Config{URLs: []string{"http://webhook"}}),
+	}
+
+	mockRepository.EXPECT().GetSwapIn(ctx, "swap-id").Return(nil, gorm.ErrRecordNotFound)
+	mockRepository.EXPECT().GetSwapOut(ctx, "swap-id").Return(&models.SwapOut{
+		SwapID:        "swap-id",
+		Status:        models.StatusCreated,
+		PaymentStatus: models.PaymentPending,
+	}, nil)
+	mockRepository.EXPECT().SaveSwapOutIf(ctx, gomock.Any(), models.StatusCreated, models.PaymentPending).Return(nil)
+	mockRepository.EXPECT().SaveSwapEvent(ctx, gomock.Any()).Return(nil)
+	webhooks.EXPECT().SaveWebhookNotifications(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, notifications []*models.WebhookNotification) error {
+		require.Len(t, notifications, 1)
+		require.Equal(t, notifier.EventSwapCancelled, notifications[0].EventType)
+
+		return nil
+	})
+
+	_, err := server.CancelSwap(ctx, &CancelSwapRequest{Id: "swap-id"})
+	require.NoError(t, err)
+}
+
+func TestCheckCancel(t *testing.T) {
+	tests := []struct {
+		direction string
+		status    models.SwapStatus
+		wantErr   string
+	}{
+		{direction: "in", status: models.StatusCreated},
+		{direction: "out", status: models.StatusCreated},
+		{direction: "in", status: models.StatusContractFundedUnconfirmed, wantErr: "its contract is funded and can be refunded from block height 850000"},
+		{direction: "in", status: models.StatusContractFunded, wantErr: "its contract is funded and can be refunded from block height 850000"},
+		{direction: "out", status: models.StatusContractFunded, wantErr: "its contract is funded, the server refunds it if it isn't claimed"},
+		{direction: "in", status: models.StatusInvoicePaid, wantErr: "its invoice is paid"},
+		{direction: "out", status: models.StatusContractClaimedUnconfirmed, wantErr: "its contract is being claimed"},
+		{direction: "in", status: models.StatusContractRefundedUnconfirmed, wantErr: "its contract is being refunded"},
+		{direction: "in", status: models.StatusContractExpired, wantErr: "its contract expired and is being refunded"},
+		{direction: "out", status: models.StatusDone, wantErr: "it's already done"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.direction+" "+string(tt.status), func(t *testing.T) {
+			err := checkCancel(tt.direction, "swap-id", tt.status, 850000)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+
+				return
+			}
+			require.EqualError(t, err, "swap "+tt.direction+" swap-id can't be cancelled: "+tt.wantErr)
+			require.Equal(t, codes.FailedPrecondition, toStatus(err).Code())
+		})
+	}
+}
+
+func TestServer_GetSwapTimeline(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := NewMockRepository(ctrl)
+	server := &Server{
+		Repository: mockRepository,
+	}
+	ctx := context.Background()
+	createdAt := time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)
+	statusCreated := models.StatusCreated
+	outcomeRefunded := models.OutcomeRefunded
+
+	mockRepository.EXPECT().GetSwapEvents(ctx, "swap-id").Return([]*models.SwapEvent{
+		{
+			SwapID:         "swap-id",
+			Direction:      models.SwapDirectionIn,
+			ToStatus:       models.StatusCreated,
+			ServiceFeeSats: 100,
+			CreatedAt:      createdAt,
+		},
+		{
+			SwapID:     "swap-id",
+			Direction:  models.SwapDirectionIn,
+			FromStatus: &statusCreated,
+			ToStatus:   models.StatusDone,
+			Outcome:    &outcomeRefunded,
+			RefundTxID: "refund-tx-id",
+			Message:    "contract expired",
+			CreatedAt:  createdAt.Add(time.Hour),
+		},
+	}, nil)
+
+	res, err := server.GetSwapTimeline(ctx, &GetSwapTimelineRequest{Id: "swap-id"})
+	require.NoError(t, err)
+	require.Equal(t, "swap-id", res.Id)
+	require.Len(t, res.Events, 2)
+
+	require.Equal(t, "IN", res.Events[0].Type)
+	require.Nil(t, res.Events[0].FromStatus)
+	require.Equal(t, Status_CREATED, res.Events[0].ToStatus)
+	require.Equal(t, uint64(100), res.Events[0].ServiceFeeSats)
+	require.Nil(t, res.Events[0].RefundTxId)
+	require.Equal(t, createdAt, res.Events[0].CreatedAt.AsTime())
+
+	require.Equal(t, Status_CREATED, res.Events[1].GetFromStatus())
+	require.Equal(t, Status_DONE, res.Events[1].ToStatus)
+	require.Equal(t, "REFUNDED", res.Events[1].GetOutcome())
+	require.Equal(t, "refund-tx-id", res.Events[1].GetRefundTxId())
+	require.Equal(t, "contract expired", res.Events[1].GetMessage())
+
+	_, err = server.GetSwapTimeline(ctx, &GetSwapTimelineRequest{})
+	require.Error(t, err)
+}
@@ -3,6 +3,8 @@ package rpc
 import (
 	"github.com/40acres/40swap/daemon/database/models"
 	"github.com/40acres/40swap/daemon/lightning"
+	"github.com/40acres/40swap/daemon/money"
+	"github.com/shopspring/decimal"
 )
 
 func ToLightningNetworkType(network Network) lightning.Network {
@@ -28,3 +30,29 @@ func ToModelsChainType(chain Chain) models.Chain {
 		return models.Bitcoin
 	}
 }
+
+// optionalString returns nil for empty strings so they are left unset
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+
+	return &s
+}
+
+// toFiatValue values a swap at the rate recorded when it completed, nil when
+// there's no rate
+func toFiatValue(currency string, rate *decimal.Decimal, amountSats, serviceFeeSats, onchainFeeSats, offchainFeeSats int64) *FiatValue {
+	if rate == nil {
+		return nil
+	}
+
+	return &FiatValue{
+		Currency:    currency,
+		Rate:        rate.InexactFloat64(),
+		Amount:      money.Money(amountSats).ToFiat(*rate).InexactFloat64(),      // nolint:gosec
+		ServiceFee:  money.Money(serviceFeeSats).ToFiat(*rate).InexactFloat64(),  // nolint:gosec
+		OnchainFee:  money.Money(onchainFeeSats).ToFiat(*rate).InexactFloat64(),  // nolint:gosec
+		OffchainFee: money.Money(offchainFeeSats).ToFiat(*rate).InexactFloat64(), // nolint:gosec
+	}
+}
@@ -0,0 +1,203 @@
+package rpc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gopkg.in/macaroon.v2"
+)
+
+const (
+	// PermissionAdmin grants every call
+	PermissionAdmin = "admin"
+	// PermissionReadOnly grants looking swaps up
+	PermissionReadOnly = "readonly"
+	// PermissionSwap grants looking swaps up and creating new ones
+	PermissionSwap = "swap"
+
+	// MacaroonMetadataKey is the metadata the client sends the hex encoded
+	// macaroon in
+	MacaroonMetadataKey = "macaroon"
+
+	macaroonLocation = "40swapd"
+	macaroonRootKey  = "macaroons.key"
+	permissionCaveat = "permission "
+)
+
+var Permissions = []string{PermissionAdmin, PermissionReadOnly, PermissionSwap}
+
+// methodPermissions are the permissions granting each call besides admin,
+// calls not listed need admin
+var methodPermissions = map[string][]string{
+	SwapService_SwapIn_FullMethodName:          {PermissionSwap},
+	SwapService_SwapOut_FullMethodName:         {PermissionSwap},
+	SwapService_CancelSwap_FullMethodName:      {PermissionSwap},
+	SwapService_GetSwapIn_FullMethodName:       {PermissionReadOnly, PermissionSwap},
+	SwapService_GetSwapOut_FullMethodName:      {PermissionReadOnly, PermissionSwap},
+	SwapService_GetSwapTimeline_FullMethodName: {PermissionReadOnly, PermissionSwap},
+	SwapService_QuoteSwapIn_FullMethodName:     {PermissionReadOnly, PermissionSwap},
+	SwapService_QuoteSwapOut_FullMethodName:    {PermissionReadOnly, PermissionSwap},
+	SwapService_ExportSwaps_FullMethodName:     {PermissionReadOnly, PermissionSwap},
+}
+
+// unauthenticatedMethods can be called without a macaroon, so that health
+// probes don't need one
+var unauthenticatedMethods = map[string]bool{
+	healthpb.Health_Check_FullMethodName: true,
+	healthpb.Health_Watch_FullMethodName: true,
+}
+
+// Macaroons bakes and verifies the macaroons of the daemon
+type Macaroons struct {
+	rootKey []byte
+}
+
+// LoadOrCreateMacaroons loads the root key in dir. When it's missing, a new
+// one is created along with a macaroon for every permission, invalidating
+// any macaroon baked before.
+func LoadOrCreateMacaroons(dir string) (*Macaroons, error) {
+	rootKeyPath := filepath.Join(dir, macaroonRootKey)
+	rootKey, err := os.ReadFile(rootKeyPath)
+	if err == nil {
+		return &Macaroons{rootKey: rootKey}, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read macaroon root key: %w", err)
+	}
+
+	rootKey = make([]byte, 32)
+	if _, err := rand.Read(rootKey); err != nil {
+		return nil, fmt.Errorf("failed to generate macaroon root key: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create macaroon directory: %w", err)
+	}
+	if err := os.WriteFile(rootKeyPath, rootKey, 0600); err != nil {
+		return nil, fmt.Errorf("failed to write macaroon root key: %w", err)
+	}
+
+	macaroons := &Macaroons{rootKey: rootKey}
+	for _, permission := range Permissions {
+		mac, err := macaroons.Bake(permission)
+		if err != nil {
+			return nil, err
+		}
+		data, err := mac.MarshalBinary()
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s macaroon: %w", permission, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, permission+".macaroon"), data, 0600); err != nil {
+			return nil, fmt.Errorf("failed to write %s macaroon: %w", permission, err)
+		}
+	}
+	log.Infof("✅ Generated macaroons at %s", dir)
+
+	return macaroons, nil
+}
+
+// Bake creates a macaroon granting the permission
+func (m *Macaroons) Bake(permission string) (*macaroon.Macaroon, error) {
+	if !slices.Contains(Permissions, permission) {
+		return nil, fmt.Errorf("unknown permission %q", permission)
+	}
+
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, fmt.Errorf("failed to generate macaroon id: %w", err)
+	}
+
+	mac, err := macaroon.New(m.rootKey, id, macaroonLocation, macaroon.LatestVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create macaroon: %w", err)
+	}
+	if err := mac.AddFirstPartyCaveat([]byte(permissionCaveat + permission)); err != nil {
+		return nil, fmt.Errorf("failed to add permission to macaroon: %w", err)
+	}
+
+	return mac, nil
+}
+
+// Authorize checks the macaroon sent along the call grants it
+func (m *Macaroons) Authorize(ctx context.Context, method string) error {
+	if unauthenticatedMethods[method] {
+		return nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(MacaroonMetadataKey)
+	if len(values) != 1 {
+		return status.Error(codes.Unauthenticated, "expected one macaroon")
+	}
+
+	data, err := hex.DecodeString(values[0])
+	if err != nil {
+		return status.Error(codes.Unauthenticated, "macaroon is not hex encoded")
+	}
+	var mac macaroon.Macaroon
+	if err := mac.UnmarshalBinary(data); err != nil {
+		return status.Error(codes.Unauthenticated, "invalid macaroon")
+	}
+
+	err = mac.Verify(m.rootKey, func(caveat string) error {
+		permission, ok := strings.CutPrefix(caveat, permissionCaveat)
+		if !ok {
+			return fmt.Errorf("unknown caveat %q", caveat)
+		}
+		if permission != PermissionAdmin && !slices.Contains(methodPermissions[method], permission) {
+			return fmt.Errorf("permission %s doesn't grant %s", permission, method)
+		}
+
+		return nil
+	}, nil)
+	if err != nil {
+		return status.Errorf(codes.PermissionDenied, "macaroon verification failed: %v", err)
+	}
+
+	return nil
+}
+
+// UnaryServerInterceptor rejects the calls the macaroon doesn't grant
+func (m *Macaroons) UnaryServerInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if err := m.Authorize(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+// StreamServerInterceptor rejects the streams the macaroon doesn't grant
+func (m *Macaroons) StreamServerInterceptor(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := m.Authorize(stream.Context(), info.FullMethod); err != nil {
+		return err
+	}
+
+	return handler(srv, stream)
+}
+
+// macaroonCredential sends a macaroon along every call
+type macaroonCredential struct {
+	macaroon string
+}
+
+func (c macaroonCredential) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{MacaroonMetadataKey: c.macaroon}, nil
+}
+
+// RequireTransportSecurity is false so that macaroons can also be used
+// without TLS, when the daemon has it disabled
+func (c macaroonCredential) RequireTransportSecurity() bool {
+	return false
+}
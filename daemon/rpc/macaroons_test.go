@@ -0,0 +1,94 @@
+package rpc
+
+import (
+	"context"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestMacaroons_Authorize(t *testing.T) {
+	dir := t.TempDir()
+	macaroons, err := LoadOrCreateMacaroons(dir)
+	require.NoError(t, err)
+
+	withMacaroon := func(permission string) context.Context {
+		data, err := os.ReadFile(filepath.Join(dir, permission+".macaroon"))
+		require.NoError(t, err)
+
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(MacaroonMetadataKey, hex.EncodeToString(data)))
+	}
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		method   string
+		wantCode codes.Code
+	}{
+		{name: "admin can reopen", ctx: withMacaroon(PermissionAdmin), method: SwapService_ReopenSwap_FullMethodName},
+		{name: "swap can swap out", ctx: withMacaroon(PermissionSwap), method: SwapService_SwapOut_FullMethodName},
+		{name: "swap can't reopen", ctx: withMacaroon(PermissionSwap), method: SwapService_ReopenSwap_FullMethodName, wantCode: codes.PermissionDenied},
+		{name: "readonly can read", ctx: withMacaroon(PermissionReadOnly), method: SwapService_GetSwapTimeline_FullMethodName},
+		{name: "readonly can't swap in", ctx: withMacaroon(PermissionReadOnly), method: SwapService_SwapIn_FullMethodName, wantCode: codes.PermissionDenied},
+		{name: "no macaroon", ctx: context.Background(), method: SwapService_GetSwapIn_FullMethodName, wantCode: codes.Unauthenticated},
+		{name: "health needs none", ctx: context.Background(), method: healthpb.Health_Check_FullMethodName},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := macaroons.Authorize(tt.ctx, tt.method)
+			require.Equal(t, tt.wantCode, status.Code(err), "unexpected error: %v", err)
+		})
+	}
+}
+
+func TestMacaroons_OtherRootKey(t *testing.T) {
+	dir := t.TempDir()
+	_, err := LoadOrCreateMacaroons(dir)
+	require.NoError(t, err)
+
+	// Loading again keeps the root key, so the macaroons stay valid
+	macaroons, err := LoadOrCreateMacaroons(dir)
+	require.NoError(t, err)
+
+	other, err := LoadOrCreateMacaroons(t.TempDir())
+	require.NoError(t, err)
+	mac, err := other.Bake(PermissionAdmin)
+	require.NoError(t, err)
+	data, err := mac.MarshalBinary()
+	require.NoError(t, err)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(MacaroonMetadataKey, hex.EncodeToString(data)))
+	err = macaroons.Authorize(ctx, SwapService_GetSwapIn_FullMethodName)
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	data, err = os.ReadFile(filepath.Join(dir, PermissionAdmin+".macaroon"))
+	require.NoError(t, err)
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(MacaroonMetadataKey, hex.EncodeToString(data)))
+	require.NoError(t, macaroons.Authorize(ctx, SwapService_GetSwapIn_FullMethodName))
+}
+
+func TestLoadOrCreateTLSCert(t *testing.T) {
+	dir := t.TempDir()
+	certPath := filepath.Join(dir, "tls.cert")
+	keyPath := filepath.Join(dir, "tls.key")
+
+	cert, err := LoadOrCreateTLSCert(certPath, keyPath, []string{"swap.example.com", "10.0.0.1"})
+	require.NoError(t, err)
+	require.NotNil(t, cert.Leaf)
+	require.Contains(t, cert.Leaf.DNSNames, "swap.example.com")
+	require.Contains(t, cert.Leaf.DNSNames, "localhost")
+	require.Len(t, cert.Leaf.IPAddresses, 3)
+
+	// The certificate is reused once generated
+	again, err := LoadOrCreateTLSCert(certPath, keyPath, nil)
+	require.NoError(t, err)
+	require.Equal(t, cert.Certificate, again.Certificate)
+}
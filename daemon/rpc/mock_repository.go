@@ -12,6 +12,7 @@ package rpc
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	models "github.com/40acres/40swap/daemon/database/models"
 	gomock "go.uber.org/mock/gomock"
@@ -41,6 +42,51 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
+// GetCancelledSwapIns mocks base method.
+func (m *MockRepository) GetCancelledSwapIns(ctx context.Context, height int64) ([]*models.SwapIn, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCancelledSwapIns", ctx, height)
+	ret0, _ := ret[0].([]*models.SwapIn)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCancelledSwapIns indicates an expected call of GetCancelledSwapIns.
+func (mr *MockRepositoryMockRecorder) GetCancelledSwapIns(ctx, height any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCancelledSwapIns", reflect.TypeOf((*MockRepository)(nil).GetCancelledSwapIns), ctx, height)
+}
+
+// GetFinishedSwapIns mocks base method.
+func (m *MockRepository) GetFinishedSwapIns(ctx context.Context, from, to time.Time) ([]*models.SwapIn, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFinishedSwapIns", ctx, from, to)
+	ret0, _ := ret[0].([]*models.SwapIn)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFinishedSwapIns indicates an expected call of GetFinishedSwapIns.
+func (mr *MockRepositoryMockRecorder) GetFinishedSwapIns(ctx, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFinishedSwapIns", reflect.TypeOf((*MockRepository)(nil).GetFinishedSwapIns), ctx, from, to)
+}
+
+// GetFinishedSwapOuts mocks base method.
+func (m *MockRepository) GetFinishedSwapOuts(ctx context.Context, from, to time.Time) ([]*models.SwapOut, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFinishedSwapOuts", ctx, from, to)
+	ret0, _ := ret[0].([]*models.SwapOut)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFinishedSwapOuts indicates an expected call of GetFinishedSwapOuts.
+func (mr *MockRepositoryMockRecorder) GetFinishedSwapOuts(ctx, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFinishedSwapOuts", reflect.TypeOf((*MockRepository)(nil).GetFinishedSwapOuts), ctx, from, to)
+}
+
 // GetPendingAutoSwapOuts mocks base method.
 func (m *MockRepository) GetPendingAutoSwapOuts(ctx context.Context) ([]*models.SwapOut, error) {
 	m.ctrl.T.Helper()
@@ -86,6 +132,21 @@ func (mr *MockRepositoryMockRecorder) GetPendingSwapOuts(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingSwapOuts", reflect.TypeOf((*MockRepository)(nil).GetPendingSwapOuts), ctx)
 }
 
+// GetSwapEvents mocks base method.
+func (m *MockRepository) GetSwapEvents(ctx context.Context, swapID string) ([]*models.SwapEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSwapEvents", ctx, swapID)
+	ret0, _ := ret[0].([]*models.SwapEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSwapEvents indicates an expected call of GetSwapEvents.
+func (mr *MockRepositoryMockRecorder) GetSwapEvents(ctx, swapID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSwapEvents", reflect.TypeOf((*MockRepository)(nil).GetSwapEvents), ctx, swapID)
+}
+
 // GetSwapIn mocks base method.
 func (m *MockRepository) GetSwapIn(ctx context.Context, swapID string) (*models.SwapIn, error) {
 	m.ctrl.T.Helper()
@@ -116,6 +177,21 @@ func (mr *MockRepositoryMockRecorder) GetSwapInByClaimAddress(ctx, address any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSwapInByClaimAddress", reflect.TypeOf((*MockRepository)(nil).GetSwapInByClaimAddress), ctx, address)
 }
 
+// GetSwapInByClientRequestID mocks base method.
+func (m *MockRepository) GetSwapInByClientRequestID(ctx context.Context, clientRequestID string) (*models.SwapIn, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSwapInByClientRequestID", ctx, clientRequestID)
+	ret0, _ := ret[0].(*models.SwapIn)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSwapInByClientRequestID indicates an expected call of GetSwapInByClientRequestID.
+func (mr *MockRepositoryMockRecorder) GetSwapInByClientRequestID(ctx, clientRequestID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSwapInByClientRequestID", reflect.TypeOf((*MockRepository)(nil).GetSwapInByClientRequestID), ctx, clientRequestID)
+}
+
 // GetSwapOut mocks base method.
 func (m *MockRepository) GetSwapOut(ctx context.Context, swapID string) (*models.SwapOut, error) {
 	m.ctrl.T.Helper()
@@ -131,6 +207,35 @@ func (mr *MockRepositoryMockRecorder) GetSwapOut(ctx, swapID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSwapOut", reflect.TypeOf((*MockRepository)(nil).GetSwapOut), ctx, swapID)
 }
 
+// GetSwapOutByClientRequestID mocks base method.
+func (m *MockRepository) GetSwapOutByClientRequestID(ctx context.Context, clientRequestID string) (*models.SwapOut, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSwapOutByClientRequestID", ctx, clientRequestID)
+	ret0, _ := ret[0].(*models.SwapOut)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSwapOutByClientRequestID indicates an expected call of GetSwapOutByClientRequestID.
+func (mr *MockRepositoryMockRecorder) GetSwapOutByClientRequestID(ctx, clientRequestID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSwapOutByClientRequestID", reflect.TypeOf((*MockRepository)(nil).GetSwapOutByClientRequestID), ctx, clientRequestID)
+}
+
+// SaveSwapEvent mocks base method.
+func (m *MockRepository) SaveSwapEvent(ctx context.Context, event *models.SwapEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSwapEvent", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSwapEvent indicates an expected call of SaveSwapEvent.
+func (mr *MockRepositoryMockRecorder) SaveSwapEvent(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSwapEvent", reflect.TypeOf((*MockRepository)(nil).SaveSwapEvent), ctx, event)
+}
+
 // SaveSwapIn mocks base method.
 func (m *MockRepository) SaveSwapIn(ctx context.Context, swapIn *models.SwapIn) error {
 	m.ctrl.T.Helper()
@@ -145,6 +250,20 @@ func (mr *MockRepositoryMockRecorder) SaveSwapIn(ctx, swapIn any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSwapIn", reflect.TypeOf((*MockRepository)(nil).SaveSwapIn), ctx, swapIn)
 }
 
+// SaveSwapInIf mocks base method.
+func (m *MockRepository) SaveSwapInIf(ctx context.Context, swapIn *models.SwapIn, status models.SwapStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSwapInIf", ctx, swapIn, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSwapInIf indicates an expected call of SaveSwapInIf.
+func (mr *MockRepositoryMockRecorder) SaveSwapInIf(ctx, swapIn, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSwapInIf", reflect.TypeOf((*MockRepository)(nil).SaveSwapInIf), ctx, swapIn, status)
+}
+
 // SaveSwapOut mocks base method.
 func (m *MockRepository) SaveSwapOut(ctx context.Context, swapOut *models.SwapOut) error {
 	m.ctrl.T.Helper()
@@ -159,6 +278,25 @@ func (mr *MockRepositoryMockRecorder) SaveSwapOut(ctx, swapOut any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSwapOut", reflect.TypeOf((*MockRepository)(nil).SaveSwapOut), ctx, swapOut)
 }
 
+// SaveSwapOutIf mocks base method.
+func (m *MockRepository) SaveSwapOutIf(ctx context.Context, swapOut *models.SwapOut, status models.SwapStatus, paymentStatuses ...models.PaymentStatus) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, swapOut, status}
+	for _, a := range paymentStatuses {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SaveSwapOutIf", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSwapOutIf indicates an expected call of SaveSwapOutIf.
+func (mr *MockRepositoryMockRecorder) SaveSwapOutIf(ctx, swapOut, status any, paymentStatuses ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, swapOut, status}, paymentStatuses...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSwapOutIf", reflect.TypeOf((*MockRepository)(nil).SaveSwapOutIf), varargs...)
+}
+
 // UpdateAutoSwap mocks base method.
 func (m *MockRepository) UpdateAutoSwap(ctx context.Context, swapID string, isAutoSwap bool) error {
 	m.ctrl.T.Helper()
@@ -1,5 +1,6 @@
 // Code generated by MockGen. DO NOT EDIT.
 // Source: rpc/40swapd_grpc.pb.go
+//
 
 // Package rpc is a generated GoMock package.
 package rpc
@@ -35,6 +36,46 @@ func (m *MockSwapServiceClient) EXPECT() *MockSwapServiceClientMockRecorder {
 	return m.recorder
 }
 
+// CancelSwap mocks base method.
+func (m *MockSwapServiceClient) CancelSwap(ctx context.Context, in *CancelSwapRequest, opts ...grpc.CallOption) (*CancelSwapResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CancelSwap", varargs...)
+	ret0, _ := ret[0].(*CancelSwapResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelSwap indicates an expected call of CancelSwap.
+func (mr *MockSwapServiceClientMockRecorder) CancelSwap(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelSwap", reflect.TypeOf((*MockSwapServiceClient)(nil).CancelSwap), varargs...)
+}
+
+// ExportSwaps mocks base method.
+func (m *MockSwapServiceClient) ExportSwaps(ctx context.Context, in *ExportSwapsRequest, opts ...grpc.CallOption) (*ExportSwapsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ExportSwaps", varargs...)
+	ret0, _ := ret[0].(*ExportSwapsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportSwaps indicates an expected call of ExportSwaps.
+func (mr *MockSwapServiceClientMockRecorder) ExportSwaps(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportSwaps", reflect.TypeOf((*MockSwapServiceClient)(nil).ExportSwaps), varargs...)
+}
+
 // GetSwapIn mocks base method.
 func (m *MockSwapServiceClient) GetSwapIn(ctx context.Context, in *GetSwapInRequest, opts ...grpc.CallOption) (*GetSwapInResponse, error) {
 	m.ctrl.T.Helper()
@@ -75,6 +116,66 @@ func (mr *MockSwapServiceClientMockRecorder) GetSwapOut(ctx, in interface{}, opt
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSwapOut", reflect.TypeOf((*MockSwapServiceClient)(nil).GetSwapOut), varargs...)
 }
 
+// GetSwapTimeline mocks base method.
+func (m *MockSwapServiceClient) GetSwapTimeline(ctx context.Context, in *GetSwapTimelineRequest, opts ...grpc.CallOption) (*GetSwapTimelineResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetSwapTimeline", varargs...)
+	ret0, _ := ret[0].(*GetSwapTimelineResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSwapTimeline indicates an expected call of GetSwapTimeline.
+func (mr *MockSwapServiceClientMockRecorder) GetSwapTimeline(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSwapTimeline", reflect.TypeOf((*MockSwapServiceClient)(nil).GetSwapTimeline), varargs...)
+}
+
+// QuoteSwapIn mocks base method.
+func (m *MockSwapServiceClient) QuoteSwapIn(ctx context.Context, in *QuoteSwapInRequest, opts ...grpc.CallOption) (*QuoteSwapInResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "QuoteSwapIn", varargs...)
+	ret0, _ := ret[0].(*QuoteSwapInResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QuoteSwapIn indicates an expected call of QuoteSwapIn.
+func (mr *MockSwapServiceClientMockRecorder) QuoteSwapIn(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QuoteSwapIn", reflect.TypeOf((*MockSwapServiceClient)(nil).QuoteSwapIn), varargs...)
+}
+
+// QuoteSwapOut mocks base method.
+func (m *MockSwapServiceClient) QuoteSwapOut(ctx context.Context, in *QuoteSwapOutRequest, opts ...grpc.CallOption) (*QuoteSwapOutResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "QuoteSwapOut", varargs...)
+	ret0, _ := ret[0].(*QuoteSwapOutResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QuoteSwapOut indicates an expected call of QuoteSwapOut.
+func (mr *MockSwapServiceClientMockRecorder) QuoteSwapOut(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QuoteSwapOut", reflect.TypeOf((*MockSwapServiceClient)(nil).QuoteSwapOut), varargs...)
+}
+
 // RecoverReusedSwapAddress mocks base method.
 func (m *MockSwapServiceClient) RecoverReusedSwapAddress(ctx context.Context, in *RecoverReusedSwapAddressRequest, opts ...grpc.CallOption) (*RecoverReusedSwapAddressResponse, error) {
 	m.ctrl.T.Helper()
@@ -95,6 +196,26 @@ func (mr *MockSwapServiceClientMockRecorder) RecoverReusedSwapAddress(ctx, in in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecoverReusedSwapAddress", reflect.TypeOf((*MockSwapServiceClient)(nil).RecoverReusedSwapAddress), varargs...)
 }
 
+// ReopenSwap mocks base method.
+func (m *MockSwapServiceClient) ReopenSwap(ctx context.Context, in *ReopenSwapRequest, opts ...grpc.CallOption) (*ReopenSwapResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ReopenSwap", varargs...)
+	ret0, _ := ret[0].(*ReopenSwapResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReopenSwap indicates an expected call of ReopenSwap.
+func (mr *MockSwapServiceClientMockRecorder) ReopenSwap(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReopenSwap", reflect.TypeOf((*MockSwapServiceClient)(nil).ReopenSwap), varargs...)
+}
+
 // SwapIn mocks base method.
 func (m *MockSwapServiceClient) SwapIn(ctx context.Context, in *SwapInRequest, opts ...grpc.CallOption) (*SwapInResponse, error) {
 	m.ctrl.T.Helper()
@@ -158,6 +279,36 @@ func (m *MockSwapServiceServer) EXPECT() *MockSwapServiceServerMockRecorder {
 	return m.recorder
 }
 
+// CancelSwap mocks base method.
+func (m *MockSwapServiceServer) CancelSwap(arg0 context.Context, arg1 *CancelSwapRequest) (*CancelSwapResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelSwap", arg0, arg1)
+	ret0, _ := ret[0].(*CancelSwapResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelSwap indicates an expected call of CancelSwap.
+func (mr *MockSwapServiceServerMockRecorder) CancelSwap(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelSwap", reflect.TypeOf((*MockSwapServiceServer)(nil).CancelSwap), arg0, arg1)
+}
+
+// ExportSwaps mocks base method.
+func (m *MockSwapServiceServer) ExportSwaps(arg0 context.Context, arg1 *ExportSwapsRequest) (*ExportSwapsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportSwaps", arg0, arg1)
+	ret0, _ := ret[0].(*ExportSwapsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportSwaps indicates an expected call of ExportSwaps.
+func (mr *MockSwapServiceServerMockRecorder) ExportSwaps(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportSwaps", reflect.TypeOf((*MockSwapServiceServer)(nil).ExportSwaps), arg0, arg1)
+}
+
 // GetSwapIn mocks base method.
 func (m *MockSwapServiceServer) GetSwapIn(arg0 context.Context, arg1 *GetSwapInRequest) (*GetSwapInResponse, error) {
 	m.ctrl.T.Helper()
@@ -188,6 +339,51 @@ func (mr *MockSwapServiceServerMockRecorder) GetSwapOut(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSwapOut", reflect.TypeOf((*MockSwapServiceServer)(nil).GetSwapOut), arg0, arg1)
 }
 
+// GetSwapTimeline mocks base method.
+func (m *MockSwapServiceServer) GetSwapTimeline(arg0 context.Context, arg1 *GetSwapTimelineRequest) (*GetSwapTimelineResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSwapTimeline", arg0, arg1)
+	ret0, _ := ret[0].(*GetSwapTimelineResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSwapTimeline indicates an expected call of GetSwapTimeline.
+func (mr *MockSwapServiceServerMockRecorder) GetSwapTimeline(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSwapTimeline", reflect.TypeOf((*MockSwapServiceServer)(nil).GetSwapTimeline), arg0, arg1)
+}
+
+// QuoteSwapIn mocks base method.
+func (m *MockSwapServiceServer) QuoteSwapIn(arg0 context.Context, arg1 *QuoteSwapInRequest) (*QuoteSwapInResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QuoteSwapIn", arg0, arg1)
+	ret0, _ := ret[0].(*QuoteSwapInResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QuoteSwapIn indicates an expected call of QuoteSwapIn.
+func (mr *MockSwapServiceServerMockRecorder) QuoteSwapIn(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QuoteSwapIn", reflect.TypeOf((*MockSwapServiceServer)(nil).QuoteSwapIn), arg0, arg1)
+}
+
+// QuoteSwapOut mocks base method.
+func (m *MockSwapServiceServer) QuoteSwapOut(arg0 context.Context, arg1 *QuoteSwapOutRequest) (*QuoteSwapOutResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QuoteSwapOut", arg0, arg1)
+	ret0, _ := ret[0].(*QuoteSwapOutResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QuoteSwapOut indicates an expected call of QuoteSwapOut.
+func (mr *MockSwapServiceServerMockRecorder) QuoteSwapOut(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QuoteSwapOut", reflect.TypeOf((*MockSwapServiceServer)(nil).QuoteSwapOut), arg0, arg1)
+}
+
 // RecoverReusedSwapAddress mocks base method.
 func (m *MockSwapServiceServer) RecoverReusedSwapAddress(arg0 context.Context, arg1 *RecoverReusedSwapAddressRequest) (*RecoverReusedSwapAddressResponse, error) {
 	m.ctrl.T.Helper()
@@ -203,6 +399,21 @@ func (mr *MockSwapServiceServerMockRecorder) RecoverReusedSwapAddress(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecoverReusedSwapAddress", reflect.TypeOf((*MockSwapServiceServer)(nil).RecoverReusedSwapAddress), arg0, arg1)
 }
 
+// ReopenSwap mocks base method.
+func (m *MockSwapServiceServer) ReopenSwap(arg0 context.Context, arg1 *ReopenSwapRequest) (*ReopenSwapResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReopenSwap", arg0, arg1)
+	ret0, _ := ret[0].(*ReopenSwapResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReopenSwap indicates an expected call of ReopenSwap.
+func (mr *MockSwapServiceServerMockRecorder) ReopenSwap(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReopenSwap", reflect.TypeOf((*MockSwapServiceServer)(nil).ReopenSwap), arg0, arg1)
+}
+
 // SwapIn mocks base method.
 func (m *MockSwapServiceServer) SwapIn(arg0 context.Context, arg1 *SwapInRequest) (*SwapInResponse, error) {
 	m.ctrl.T.Helper()
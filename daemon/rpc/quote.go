@@ -0,0 +1,116 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/40acres/40swap/daemon/bitcoin"
+	"github.com/40acres/40swap/daemon/swaps"
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+)
+
+// quote is what SwapIn and SwapOut quotes have in common
+type quote struct {
+	config        *swaps.ConfigurationResponse
+	feeRate       int64
+	minAmountSats uint64
+	maxAmountSats uint64
+}
+
+// newQuote fetches the server configuration and the current fee rate to
+// price a swap of the amount. Amounts out of the server limits are still
+// quoted, the limits are returned so the caller can tell.
+func (server *Server) newQuote(ctx context.Context, amountSats uint64) (*quote, error) {
+	if amountSats == 0 {
+		return nil, invalidArgument("amountSats", "amount must be greater than 0")
+	}
+
+	config, err := server.swapClient.GetConfiguration(ctx)
+	if err != nil {
+		return nil, unavailable(ServiceSwapServer, "could not get configuration: %w", err)
+	}
+
+	feeRate, err := server.bitcoin.GetRecommendedFees(ctx, bitcoin.HalfHourFee)
+	if err != nil {
+		return nil, unavailable(ServiceBitcoin, "failed to get recommended fees: %w", err)
+	}
+
+	return &quote{
+		config:        config,
+		feeRate:       feeRate,
+		minAmountSats: uint64(config.MinimumAmount.Mul(decimal.NewFromInt(1e8)).IntPart()), // nolint:gosec
+		maxAmountSats: uint64(config.MaximumAmount.Mul(decimal.NewFromInt(1e8)).IntPart()), // nolint:gosec
+	}, nil
+}
+
+// onchainFee is the estimated fee of a transaction of the given size
+func (q *quote) onchainFee(vsize int64) uint64 {
+	return uint64(vsize * q.feeRate) // nolint:gosec
+}
+
+func (server *Server) QuoteSwapIn(ctx context.Context, req *QuoteSwapInRequest) (*QuoteSwapInResponse, error) {
+	log.Debugf("Received QuoteSwapIn request: %v", req)
+
+	q, err := server.newQuote(ctx, req.AmountSats)
+	if err != nil {
+		return nil, err
+	}
+
+	// The server grosses the amount up by its fee, like getSwapInInputAmount
+	// in shared/src/swap-utils.ts, and pays the fee to claim the contract out
+	// of it
+	hundred := decimal.NewFromInt(100)
+	sendAmount := decimal.NewFromUint64(req.AmountSats).Mul(hundred).Div(hundred.Sub(q.config.FeePercentage))
+	sendAmountSats := uint64(sendAmount.Round(0).IntPart()) // nolint:gosec
+
+	return &QuoteSwapInResponse{
+		AmountSats:          req.AmountSats,
+		ServiceFeeSats:      sendAmountSats - req.AmountSats,
+		ServiceFeePercent:   q.config.FeePercentage.InexactFloat64(),
+		OnchainFeeSats:      q.onchainFee(bitcoin.ClaimTxVSize),
+		SendAmountSats:      sendAmountSats,
+		RefundFeeSats:       q.onchainFee(bitcoin.RefundTxVSize),
+		FeeRateSatsPerVbyte: q.feeRate,
+		MinAmountSats:       q.minAmountSats,
+		MaxAmountSats:       q.maxAmountSats,
+	}, nil
+}
+
+func (server *Server) QuoteSwapOut(ctx context.Context, req *QuoteSwapOutRequest) (*QuoteSwapOutResponse, error) {
+	log.Debugf("Received QuoteSwapOut request: %v", req)
+
+	q, err := server.newQuote(ctx, req.AmountSats)
+	if err != nil {
+		return nil, err
+	}
+
+	maxRoutingFeeRatio := decimal.NewFromFloat(defaultMaxRoutingFeeRatio)
+	if req.MaxRoutingFeePercent != nil {
+		maxRoutingFeeRatio = decimal.NewFromFloat32(*req.MaxRoutingFeePercent).Div(decimal.NewFromInt(100))
+	}
+	maxRoutingFeeSats := uint64(decimal.NewFromUint64(req.AmountSats).Mul(maxRoutingFeeRatio).IntPart()) // nolint:gosec
+
+	// The server takes its fee out of the amount, like getSwapOutOutputAmount
+	// in shared/src/swap-utils.ts
+	hundred := decimal.NewFromInt(100)
+	outputAmount := decimal.NewFromUint64(req.AmountSats).Mul(hundred.Sub(q.config.FeePercentage)).Div(hundred)
+	serviceFeeSats := req.AmountSats - uint64(outputAmount.Round(0).IntPart()) // nolint:gosec
+	claimFeeSats := q.onchainFee(bitcoin.ClaimTxVSize)
+	var receiveAmountSats uint64
+	if fees := serviceFeeSats + claimFeeSats; req.AmountSats > fees {
+		receiveAmountSats = req.AmountSats - fees
+	}
+
+	return &QuoteSwapOutResponse{
+		AmountSats:          req.AmountSats,
+		ServiceFeeSats:      serviceFeeSats,
+		ServiceFeePercent:   q.config.FeePercentage.InexactFloat64(),
+		ClaimFeeSats:        claimFeeSats,
+		MaxRoutingFeeSats:   maxRoutingFeeSats,
+		ReceiveAmountSats:   receiveAmountSats,
+		MaxTotalFeeSats:     serviceFeeSats + claimFeeSats + maxRoutingFeeSats,
+		FeeRateSatsPerVbyte: q.feeRate,
+		MinAmountSats:       q.minAmountSats,
+		MaxAmountSats:       q.maxAmountSats,
+	}, nil
+}
@@ -0,0 +1,137 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/40acres/40swap/daemon/bitcoin"
+	"github.com/40acres/40swap/daemon/lightning"
+	"github.com/40acres/40swap/daemon/swaps"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newQuoteServer(t *testing.T) (*Server, *swaps.MockClientInterface, *bitcoin.MockClient) {
+	ctrl := gomock.NewController(t)
+	swapClient := swaps.NewMockClientInterface(ctrl)
+	bitcoinClient := bitcoin.NewMockClient(ctrl)
+
+	return &Server{swapClient: swapClient, bitcoin: bitcoinClient}, swapClient, bitcoinClient
+}
+
+var quoteConfig = &swaps.ConfigurationResponse{
+	FeePercentage: decimal.NewFromFloat(0.5),
+	MinimumAmount: decimal.NewFromFloat(0.0002),
+	MaximumAmount: decimal.NewFromFloat(0.013),
+}
+
+func TestServer_QuoteSwapIn(t *testing.T) {
+	ctx := context.Background()
+	server, swapClient, bitcoinClient := newQuoteServer(t)
+	swapClient.EXPECT().GetConfiguration(ctx).Return(quoteConfig, nil)
+	bitcoinClient.EXPECT().GetRecommendedFees(ctx, bitcoin.HalfHourFee).Return(int64(10), nil)
+
+	quote, err := server.QuoteSwapIn(ctx, &QuoteSwapInRequest{AmountSats: 100_000})
+	require.NoError(t, err)
+	require.Equal(t, &QuoteSwapInResponse{
+		AmountSats:          100_000,
+		ServiceFeeSats:      503,
+		ServiceFeePercent:   0.5,
+		OnchainFeeSats:      1500,
+		SendAmountSats:      100_503,
+		RefundFeeSats:       1400,
+		FeeRateSatsPerVbyte: 10,
+		MinAmountSats:       20_000,
+		MaxAmountSats:       1_300_000,
+	}, quote)
+}
+
+func TestServer_QuoteSwapIn_MatchesSwapIn(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	swapClient := swaps.NewMockClientInterface(ctrl)
+	bitcoinClient := bitcoin.NewMockClient(ctrl)
+	repository := NewMockRepository(ctrl)
+	server := &Server{swapClient: swapClient, bitcoin: bitcoinClient, Repository: repository, network: Network_REGTEST}
+
+	swapClient.EXPECT().GetConfiguration(ctx).Return(quoteConfig, nil).Times(2)
+	bitcoinClient.EXPECT().GetRecommendedFees(ctx, bitcoin.HalfHourFee).Return(int64(10), nil)
+	// The amounts the server answers with for a 0.5% fee
+	swapClient.EXPECT().CreateSwapIn(ctx, gomock.Any()).Return(&swaps.SwapInResponse{
+		SwapId:          "swap-id",
+		Status:          "CREATED",
+		ContractAddress: "bcrt1qey38yg6kjmtjxr28wrdrdhp22gu064xxj97006",
+		InputAmount:     decimal.RequireFromString("0.00100503"),
+		OutputAmount:    decimal.RequireFromString("0.00100000"),
+	}, nil)
+	repository.EXPECT().SaveSwapIn(ctx, gomock.Any()).Return(nil)
+	repository.EXPECT().SaveSwapEvent(ctx, gomock.Any()).Return(nil)
+
+	quote, err := server.QuoteSwapIn(ctx, &QuoteSwapInRequest{AmountSats: 100_000})
+	require.NoError(t, err)
+
+	invoice := lightning.CreateMockInvoice(t, 100_000)
+	swap, err := server.SwapIn(ctx, &SwapInRequest{
+		Invoice:  &invoice,
+		RefundTo: "bcrt1q76kh4zg0vfkt7yy8dz8tpfwqgcnm0pxd76az73d8wmqgln5640fsdy0mjx",
+	})
+	require.NoError(t, err)
+	require.Equal(t, swap.AmountSats, quote.SendAmountSats)
+}
+
+func TestServer_QuoteSwapOut(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("default routing fee", func(t *testing.T) {
+		server, swapClient, bitcoinClient := newQuoteServer(t)
+		swapClient.EXPECT().GetConfiguration(ctx).Return(quoteConfig, nil)
+		bitcoinClient.EXPECT().GetRecommendedFees(ctx, bitcoin.HalfHourFee).Return(int64(10), nil)
+
+		// Amounts out of range are quoted too
+		quote, err := server.QuoteSwapOut(ctx, &QuoteSwapOutRequest{AmountSats: 2_000_000})
+		require.NoError(t, err)
+		require.Equal(t, &QuoteSwapOutResponse{
+			AmountSats:          2_000_000,
+			ServiceFeeSats:      10_000,
+			ServiceFeePercent:   0.5,
+			ClaimFeeSats:        1500,
+			MaxRoutingFeeSats:   10_000,
+			ReceiveAmountSats:   1_988_500,
+			MaxTotalFeeSats:     21_500,
+			FeeRateSatsPerVbyte: 10,
+			MinAmountSats:       20_000,
+			MaxAmountSats:       1_300_000,
+		}, quote)
+	})
+
+	t.Run("custom routing fee", func(t *testing.T) {
+		server, swapClient, bitcoinClient := newQuoteServer(t)
+		swapClient.EXPECT().GetConfiguration(ctx).Return(quoteConfig, nil)
+		bitcoinClient.EXPECT().GetRecommendedFees(ctx, bitcoin.HalfHourFee).Return(int64(1), nil)
+
+		maxRoutingFeePercent := float32(1)
+		quote, err := server.QuoteSwapOut(ctx, &QuoteSwapOutRequest{AmountSats: 100_000, MaxRoutingFeePercent: &maxRoutingFeePercent})
+		require.NoError(t, err)
+		require.Equal(t, uint64(1000), quote.MaxRoutingFeeSats)
+		require.Equal(t, uint64(150), quote.ClaimFeeSats)
+		require.Equal(t, uint64(99_350), quote.ReceiveAmountSats)
+	})
+
+	t.Run("fees unavailable", func(t *testing.T) {
+		server, swapClient, bitcoinClient := newQuoteServer(t)
+		swapClient.EXPECT().GetConfiguration(ctx).Return(quoteConfig, nil)
+		bitcoinClient.EXPECT().GetRecommendedFees(ctx, bitcoin.HalfHourFee).Return(int64(0), errors.New("mempool down"))
+
+		_, err := server.QuoteSwapOut(ctx, &QuoteSwapOutRequest{AmountSats: 100_000})
+		require.ErrorContains(t, err, "mempool down")
+	})
+
+	t.Run("zero amount", func(t *testing.T) {
+		server, _, _ := newQuoteServer(t)
+
+		_, err := server.QuoteSwapOut(ctx, &QuoteSwapOutRequest{})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,171 @@
+package rpc
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/40acres/40swap/daemon/database/models"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// reportPeriodLayout formats the first day of a report period
+const reportPeriodLayout = time.DateOnly
+
+// ExportSwaps lists the finished swaps created in the requested range with
+// their costs, and totals them per period and type.
+func (server *Server) ExportSwaps(ctx context.Context, req *ExportSwapsRequest) (*ExportSwapsResponse, error) {
+	log.Debugf("Received ExportSwaps request: %v", req)
+
+	if _, ok := ReportPeriod_name[int32(req.Period)]; !ok {
+		return nil, invalidArgument("period", "invalid report period: %d", req.Period)
+	}
+
+	var from time.Time
+	if req.From != nil {
+		from = req.From.AsTime()
+	}
+	to := time.Now()
+	if req.To != nil {
+		to = req.To.AsTime()
+	}
+	if !from.Before(to) {
+		return nil, invalidArgument("from", "from must be before to")
+	}
+
+	swapIns, err := server.Repository.GetFinishedSwapIns(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("could not get swap ins: %w", err)
+	}
+	swapOuts, err := server.Repository.GetFinishedSwapOuts(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("could not get swap outs: %w", err)
+	}
+
+	swaps := make([]*SwapReport, 0, len(swapIns)+len(swapOuts))
+	for _, swap := range swapIns {
+		report, err := swapInReport(swap)
+		if err != nil {
+			return nil, err
+		}
+		swaps = append(swaps, report)
+	}
+	for _, swap := range swapOuts {
+		report, err := swapOutReport(swap)
+		if err != nil {
+			return nil, err
+		}
+		swaps = append(swaps, report)
+	}
+	for _, swap := range swaps {
+		swap.TotalFeeSats = swap.ServiceFeeSats + swap.OnchainFeeSats + swap.OffchainFeeSats
+	}
+	slices.SortStableFunc(swaps, func(a, b *SwapReport) int {
+		return a.CreatedAt.AsTime().Compare(b.CreatedAt.AsTime())
+	})
+
+	return &ExportSwapsResponse{
+		Swaps:  swaps,
+		Totals: reportTotals(swaps, req.Period),
+	}, nil
+}
+
+func swapInReport(swap *models.SwapIn) (*SwapReport, error) {
+	status, err := mapStatus(swap.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SwapReport{
+		Id:             swap.SwapID,
+		Type:           models.SwapDirectionIn.String(),
+		Status:         status,
+		Outcome:        outcomeString(swap.Outcome),
+		AmountSats:     uint64(swap.AmountSats),     // nolint:gosec
+		ServiceFeeSats: uint64(swap.ServiceFeeSats), // nolint:gosec
+		OnchainFeeSats: uint64(swap.OnchainFeeSats), // nolint:gosec
+		LockTxId:       optionalString(swap.LockTxID),
+		RefundTxId:     optionalString(swap.RefundTxID),
+		CreatedAt:      timestamppb.New(swap.CreatedAt),
+		UpdatedAt:      timestamppb.New(swap.UpdatedAt),
+	}, nil
+}
+
+func swapOutReport(swap *models.SwapOut) (*SwapReport, error) {
+	status, err := mapStatus(swap.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SwapReport{
+		Id:              swap.SwapID,
+		Type:            models.SwapDirectionOut.String(),
+		Status:          status,
+		Outcome:         outcomeString(swap.Outcome),
+		AmountSats:      uint64(swap.AmountSats),      // nolint:gosec
+		ServiceFeeSats:  uint64(swap.ServiceFeeSats),  // nolint:gosec
+		OnchainFeeSats:  uint64(swap.OnchainFeeSats),  // nolint:gosec
+		OffchainFeeSats: uint64(swap.OffchainFeeSats), // nolint:gosec
+		ClaimTxId:       optionalString(swap.TxID),
+		CreatedAt:       timestamppb.New(swap.CreatedAt),
+		UpdatedAt:       timestamppb.New(swap.UpdatedAt),
+	}, nil
+}
+
+func outcomeString(outcome *models.SwapOutcome) string {
+	if outcome == nil {
+		return ""
+	}
+
+	return outcome.String()
+}
+
+// reportTotals adds up the swaps per period and type, sorted by period and
+// then type
+func reportTotals(swaps []*SwapReport, period ReportPeriod) []*SwapReportTotal {
+	totals := map[[2]string]*SwapReportTotal{}
+	for _, swap := range swaps {
+		key := [2]string{periodStart(swap.CreatedAt.AsTime(), period).Format(reportPeriodLayout), swap.Type}
+		total, ok := totals[key]
+		if !ok {
+			total = &SwapReportTotal{Period: key[0], Type: key[1]}
+			totals[key] = total
+		}
+		total.Count++
+		total.AmountSats += swap.AmountSats
+		total.ServiceFeeSats += swap.ServiceFeeSats
+		total.OnchainFeeSats += swap.OnchainFeeSats
+		total.OffchainFeeSats += swap.OffchainFeeSats
+		total.TotalFeeSats += swap.TotalFeeSats
+	}
+
+	res := make([]*SwapReportTotal, 0, len(totals))
+	for _, total := range totals {
+		res = append(res, total)
+	}
+	slices.SortFunc(res, func(a, b *SwapReportTotal) int {
+		return cmp.Or(strings.Compare(a.Period, b.Period), strings.Compare(a.Type, b.Type))
+	})
+
+	return res
+}
+
+// periodStart is the start of the period t falls in, in UTC. Weeks start on
+// Monday.
+func periodStart(t time.Time, period ReportPeriod) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch period {
+	case ReportPeriod_WEEK:
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	case ReportPeriod_MONTH:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return day
+	}
+}
@@ -0,0 +1,121 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/40acres/40swap/daemon/database/models"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestServer_ExportSwaps(t *testing.T) {
+	ctx := context.Background()
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	success := models.OutcomeSuccess
+	refunded := models.OutcomeRefunded
+
+	swapIns := []*models.SwapIn{
+		{
+			SwapID:         "in-1",
+			Status:         models.StatusDone,
+			Outcome:        &success,
+			AmountSats:     100_000,
+			ServiceFeeSats: 500,
+			OnchainFeeSats: 200,
+			LockTxID:       "lock-tx",
+			CreatedAt:      time.Date(2025, 1, 6, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			SwapID:         "in-2",
+			Status:         models.StatusDone,
+			Outcome:        &refunded,
+			AmountSats:     50_000,
+			OnchainFeeSats: 300,
+			RefundTxID:     "refund-tx",
+			CreatedAt:      time.Date(2025, 2, 3, 10, 0, 0, 0, time.UTC),
+		},
+	}
+	swapOuts := []*models.SwapOut{
+		{
+			SwapID:          "out-1",
+			Status:          models.StatusDone,
+			Outcome:         &success,
+			AmountSats:      200_000,
+			ServiceFeeSats:  1000,
+			OnchainFeeSats:  150,
+			OffchainFeeSats: 20,
+			TxID:            "claim-tx",
+			CreatedAt:       time.Date(2025, 1, 12, 23, 0, 0, 0, time.UTC),
+		},
+	}
+
+	t.Run("weekly totals", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repository := NewMockRepository(ctrl)
+		server := &Server{Repository: repository}
+		repository.EXPECT().GetFinishedSwapIns(ctx, from, to).Return(swapIns, nil)
+		repository.EXPECT().GetFinishedSwapOuts(ctx, from, to).Return(swapOuts, nil)
+
+		res, err := server.ExportSwaps(ctx, &ExportSwapsRequest{
+			From:   timestamppb.New(from),
+			To:     timestamppb.New(to),
+			Period: ReportPeriod_WEEK,
+		})
+		require.NoError(t, err)
+
+		require.Len(t, res.Swaps, 3)
+		require.Equal(t, []string{"in-1", "out-1", "in-2"}, []string{res.Swaps[0].Id, res.Swaps[1].Id, res.Swaps[2].Id})
+		require.Equal(t, "SUCCESS", res.Swaps[1].Outcome)
+		require.Equal(t, "OUT", res.Swaps[1].Type)
+		require.Equal(t, uint64(1170), res.Swaps[1].TotalFeeSats)
+		require.Equal(t, "claim-tx", res.Swaps[1].GetClaimTxId())
+		require.Equal(t, "refund-tx", res.Swaps[2].GetRefundTxId())
+
+		// The swaps of the 6th and the 12th of January are in the same week
+		require.Equal(t, []*SwapReportTotal{
+			{Period: "2025-01-06", Type: "IN", Count: 1, AmountSats: 100_000, ServiceFeeSats: 500, OnchainFeeSats: 200, TotalFeeSats: 700},
+			{Period: "2025-01-06", Type: "OUT", Count: 1, AmountSats: 200_000, ServiceFeeSats: 1000, OnchainFeeSats: 150, OffchainFeeSats: 20, TotalFeeSats: 1170},
+			{Period: "2025-02-03", Type: "IN", Count: 1, AmountSats: 50_000, OnchainFeeSats: 300, TotalFeeSats: 300},
+		}, res.Totals)
+	})
+
+	t.Run("monthly totals", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repository := NewMockRepository(ctrl)
+		server := &Server{Repository: repository}
+		repository.EXPECT().GetFinishedSwapIns(ctx, from, to).Return(swapIns, nil)
+		repository.EXPECT().GetFinishedSwapOuts(ctx, from, to).Return(nil, nil)
+
+		res, err := server.ExportSwaps(ctx, &ExportSwapsRequest{
+			From:   timestamppb.New(from),
+			To:     timestamppb.New(to),
+			Period: ReportPeriod_MONTH,
+		})
+		require.NoError(t, err)
+		require.Len(t, res.Totals, 2)
+		require.Equal(t, "2025-01-01", res.Totals[0].Period)
+		require.Equal(t, "2025-02-01", res.Totals[1].Period)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repository := NewMockRepository(ctrl)
+		server := &Server{Repository: repository}
+		repository.EXPECT().GetFinishedSwapIns(ctx, from, to).Return(nil, errors.New("database is down"))
+
+		_, err := server.ExportSwaps(ctx, &ExportSwapsRequest{From: timestamppb.New(from), To: timestamppb.New(to)})
+		require.ErrorContains(t, err, "database is down")
+	})
+
+	t.Run("invalid range", func(t *testing.T) {
+		server := &Server{}
+
+		_, err := server.ExportSwaps(ctx, &ExportSwapsRequest{From: timestamppb.New(to), To: timestamppb.New(from)})
+		require.Error(t, err)
+	})
+}
@@ -1,40 +1,83 @@
 package rpc
 
 import (
+	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync/atomic"
 
 	"github.com/40acres/40swap/daemon/bitcoin"
 	"github.com/40acres/40swap/daemon/database"
 	"github.com/40acres/40swap/daemon/lightning"
+	"github.com/40acres/40swap/daemon/notifier"
 	"github.com/40acres/40swap/daemon/swaps"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
 )
 
+// writeMethods are the calls a follower in high-availability mode refuses,
+// as only the leader may create or act on swaps
+var writeMethods = map[string]bool{
+	SwapService_SwapIn_FullMethodName:                   true,
+	SwapService_SwapOut_FullMethodName:                  true,
+	SwapService_RecoverReusedSwapAddress_FullMethodName: true,
+	SwapService_ReopenSwap_FullMethodName:               true,
+	SwapService_CancelSwap_FullMethodName:               true,
+}
+
 //go:generate go tool mockgen -destination=mock_repository.go -package=rpc . Repository
 type Repository interface {
 	database.SwapInRepository
 	// Add more repositories here
 	database.SwapOutRepository
+	database.SwapEventRepository
 }
 
 type Server struct {
 	UnimplementedSwapServiceServer
-	Port            uint32
+	Port uint32
+	// Host is the interface to listen on, all of them when empty
+	Host string
+	// SocketPath is the unix socket to listen on instead of a TCP port
+	SocketPath      string
 	Repository      Repository
 	grpcServer      *grpc.Server
+	health          *health.Server
 	lightningClient lightning.Client
 	swapClient      swaps.ClientInterface
 	bitcoin         bitcoin.Client
 	minRelayFee     int64
 	network         Network
+	// follower is set while another daemon sharing the database is the leader
+	follower *atomic.Bool
+	// notifier sends the swap events recorded by the handlers to the
+	// webhooks, none are sent when nil
+	notifier *notifier.Notifier
+	// clientRequests holds the locks of the client request IDs being used to
+	// create a swap
+	clientRequests *clientRequestLocks
+
+	gatewayPort uint32
+	gatewaySelf ClientConfig
+	gatewayCert *tls.Certificate
 }
 
-func NewRPCServer(port uint32, repository Repository, swapClient swaps.ClientInterface, lightningClient lightning.Client, bitcoin bitcoin.Client, minRelayFee int64, network Network) *Server {
+func NewRPCServer(port uint32, repository Repository, swapClient swaps.ClientInterface, lightningClient lightning.Client, bitcoin bitcoin.Client, minRelayFee int64, network Network, opts ...grpc.ServerOption) *Server {
 	svr := &Server{
 		Port:            port,
 		Repository:      repository,
-		grpcServer:      grpc.NewServer(),
+		health:          health.NewServer(),
+		follower:        &atomic.Bool{},
+		clientRequests:  &clientRequestLocks{locks: make(map[string]*clientRequestLock)},
 		swapClient:      swapClient,
 		lightningClient: lightningClient,
 		bitcoin:         bitcoin,
@@ -42,15 +85,22 @@ func NewRPCServer(port uint32, repository Repository, swapClient swaps.ClientInt
 		network:         network,
 	}
 
+	// Interceptors in opts, like authentication, run before the follower one
+	svr.grpcServer = grpc.NewServer(append(opts,
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(errorInterceptor, svr.followerInterceptor),
+	)...)
+
 	RegisterSwapServiceServer(svr.grpcServer, svr)
+	healthpb.RegisterHealthServer(svr.grpcServer, svr.health)
 
 	return svr
 }
 
 func (server *Server) ListenAndServe() error {
-	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", server.Port))
+	listener, err := server.listen()
 	if err != nil {
-		return fmt.Errorf("failed to listen to port: %w", err)
+		return err
 	}
 
 	if err := server.grpcServer.Serve(listener); err != nil {
@@ -60,6 +110,114 @@ func (server *Server) ListenAndServe() error {
 	return nil
 }
 
+func (server *Server) listen() (net.Listener, error) {
+	if server.SocketPath == "" {
+		listener, err := net.Listen("tcp", net.JoinHostPort(server.Host, strconv.FormatUint(uint64(server.Port), 10)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to listen to port: %w", err)
+		}
+
+		return listener, nil
+	}
+
+	// A socket left behind by a daemon that didn't stop cleanly is replaced,
+	// as long as nobody is listening on it. Anything else at the path is
+	// most likely a mistake in the configuration, so it's left alone.
+	info, err := os.Lstat(server.SocketPath)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return nil, fmt.Errorf("failed to check socket: %w", err)
+	case info.Mode()&os.ModeSocket == 0:
+		return nil, fmt.Errorf("%s already exists and isn't a socket", server.SocketPath)
+	default:
+		if conn, err := net.Dial("unix", server.SocketPath); err == nil {
+			conn.Close()
+
+			return nil, fmt.Errorf("socket %s is already in use", server.SocketPath)
+		}
+		if err := os.Remove(server.SocketPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("failed to remove stale socket: %w", err)
+		}
+	}
+
+	// Only the user running the daemon may connect. The socket is created in
+	// a directory nobody else can enter and only moved into place once its
+	// permissions are restricted, so there's no window to connect to it.
+	dir, err := os.MkdirTemp(filepath.Dir(server.SocketPath), ".40swapd")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create socket directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "rpc")
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen to socket: %w", err)
+	}
+	// The socket is removed from where it ends up instead
+	listener.(*net.UnixListener).SetUnlinkOnClose(false)
+	if err := os.Chmod(path, 0600); err != nil {
+		listener.Close()
+
+		return nil, fmt.Errorf("failed to restrict socket permissions: %w", err)
+	}
+	if err := os.Rename(path, server.SocketPath); err != nil {
+		listener.Close()
+
+		return nil, fmt.Errorf("failed to move socket into place: %w", err)
+	}
+
+	return &socketListener{Listener: listener, path: server.SocketPath}, nil
+}
+
+// socketListener removes the socket it listens on when closed
+type socketListener struct {
+	net.Listener
+	path string
+}
+
+func (l *socketListener) Close() error {
+	err := l.Listener.Close()
+	if removeErr := os.Remove(l.path); removeErr != nil && !errors.Is(removeErr, os.ErrNotExist) && err == nil {
+		err = removeErr
+	}
+
+	return err
+}
+
 func (server *Server) Stop() {
+	server.health.Shutdown()
 	server.grpcServer.GracefulStop()
 }
+
+// SetServingStatus reports the health of a daemon subsystem through the
+// standard gRPC health service, the empty service being the whole daemon
+func (server *Server) SetServingStatus(service string, serving bool) {
+	status := healthpb.HealthCheckResponse_NOT_SERVING
+	if serving {
+		status = healthpb.HealthCheckResponse_SERVING
+	}
+	server.health.SetServingStatus(service, status)
+}
+
+// SetLeader switches between serving every call and serving only the read
+// ones while another daemon is the leader
+func (server *Server) SetLeader(leader bool) {
+	server.follower.Store(!leader)
+	server.SetServingStatus("leader", leader)
+}
+
+// SetNotifier sends the swap events recorded by the handlers, like swaps being
+// created or cancelled, to the webhooks
+func (server *Server) SetNotifier(webhooks *notifier.Notifier) {
+	server.notifier = webhooks
+}
+
+func (server *Server) followerInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if server.follower != nil && server.follower.Load() && writeMethods[info.FullMethod] {
+		return nil, status.Error(codes.FailedPrecondition, "this daemon is a follower, send the request to the leader")
+	}
+
+	return handler(ctx, req)
+}
@@ -1,7 +1,18 @@
 package rpc
 
 import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
 )
 
 func TestNewRPCServer(test *testing.T) {
@@ -26,3 +37,93 @@ func TestListenAndServe(test *testing.T) {
 		// Server started successfully
 	}
 }
+
+func TestFollowerInterceptor(test *testing.T) {
+	server := NewRPCServer(50051, nil, nil, nil, nil, 1000, Network_REGTEST)
+	handler := func(ctx context.Context, req any) (any, error) {
+		return "ok", nil
+	}
+
+	server.SetLeader(false)
+	_, err := server.followerInterceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: SwapService_SwapOut_FullMethodName}, handler)
+	if status.Code(err) != codes.FailedPrecondition {
+		test.Fatalf("Expected followers to refuse swap outs, got %v", err)
+	}
+	if _, err := server.followerInterceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: SwapService_GetSwapOut_FullMethodName}, handler); err != nil {
+		test.Fatalf("Expected followers to serve reads, got %v", err)
+	}
+
+	server.SetLeader(true)
+	if _, err := server.followerInterceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: SwapService_SwapOut_FullMethodName}, handler); err != nil {
+		test.Fatalf("Expected the leader to serve swap outs, got %v", err)
+	}
+}
+
+func TestListenAndServeSocket(test *testing.T) {
+	server := NewRPCServer(0, nil, nil, nil, nil, 1000, Network_REGTEST)
+	server.SocketPath = filepath.Join(test.TempDir(), "40swapd.sock")
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- server.ListenAndServe()
+	}()
+	test.Cleanup(server.Stop)
+
+	conn, err := NewConnection("", 0, ClientConfig{SocketPath: server.SocketPath})
+	require.NoError(test, err)
+	defer conn.Close()
+
+	// The health service answers without a macaroon once the server is up
+	require.Eventually(test, func() bool {
+		_, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	info, err := os.Stat(server.SocketPath)
+	require.NoError(test, err)
+	require.Equal(test, os.FileMode(0600), info.Mode().Perm())
+
+	// A second daemon can't take over the socket in use
+	other := NewRPCServer(0, nil, nil, nil, nil, 1000, Network_REGTEST)
+	other.SocketPath = server.SocketPath
+	require.ErrorContains(test, other.ListenAndServe(), "already in use")
+
+	// Only the socket is left in its directory, which is removed on stop
+	entries, err := os.ReadDir(filepath.Dir(server.SocketPath))
+	require.NoError(test, err)
+	require.Len(test, entries, 1)
+	server.Stop()
+	require.NoError(test, <-errChan)
+	require.NoFileExists(test, server.SocketPath)
+}
+
+func TestListenAndServeSocketPathInUse(test *testing.T) {
+	test.Run("stale socket", func(test *testing.T) {
+		path := filepath.Join(test.TempDir(), "40swapd.sock")
+		stale, err := net.Listen("unix", path)
+		require.NoError(test, err)
+		stale.(*net.UnixListener).SetUnlinkOnClose(false)
+		require.NoError(test, stale.Close())
+
+		server := NewRPCServer(0, nil, nil, nil, nil, 1000, Network_REGTEST)
+		server.SocketPath = path
+		listener, err := server.listen()
+		require.NoError(test, err)
+		require.NoError(test, listener.Close())
+	})
+
+	test.Run("not a socket", func(test *testing.T) {
+		path := filepath.Join(test.TempDir(), "40swapd.conf")
+		require.NoError(test, os.WriteFile(path, []byte("important"), 0600))
+
+		server := NewRPCServer(0, nil, nil, nil, nil, 1000, Network_REGTEST)
+		server.SocketPath = path
+		_, err := server.listen()
+		require.ErrorContains(test, err, "isn't a socket")
+
+		content, err := os.ReadFile(path)
+		require.NoError(test, err)
+		require.Equal(test, "important", string(content))
+	})
+}
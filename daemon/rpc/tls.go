@@ -0,0 +1,106 @@
+package rpc
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const tlsCertValidity = 14 * 30 * 24 * time.Hour
+
+// LoadOrCreateTLSCert loads the certificate of the daemon, generating a
+// self-signed one valid for localhost and the extra hosts when it's missing
+func LoadOrCreateTLSCert(certPath, keyPath string, extraHosts []string) (tls.Certificate, error) {
+	_, certErr := os.Stat(certPath)
+	_, keyErr := os.Stat(keyPath)
+	if errors.Is(certErr, os.ErrNotExist) && errors.Is(keyErr, os.ErrNotExist) {
+		if err := generateTLSCert(certPath, keyPath, extraHosts); err != nil {
+			return tls.Certificate{}, err
+		}
+		log.Infof("✅ Generated TLS certificate at %s", certPath)
+	}
+
+	cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+
+	return cert, nil
+}
+
+func generateTLSCert(certPath, keyPath string, extraHosts []string) error {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return fmt.Errorf("failed to generate TLS key: %w", err)
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return fmt.Errorf("failed to generate TLS serial number: %w", err)
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{Organization: []string{"40swapd autogenerated cert"}},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(tlsCertValidity),
+		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+	}
+	if hostname, err := os.Hostname(); err == nil {
+		template.DNSNames = append(template.DNSNames, hostname)
+	}
+	for _, host := range extraHosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return fmt.Errorf("failed to create TLS certificate: %w", err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return fmt.Errorf("failed to encode TLS key: %w", err)
+	}
+
+	if err := writePEM(certPath, "CERTIFICATE", der, 0644); err != nil {
+		return err
+	}
+
+	return writePEM(keyPath, "EC PRIVATE KEY", keyDer, 0600)
+}
+
+func writePEM(path, blockType string, bytes []byte, perm os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", path, err)
+	}
+
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: bytes})
+	if err := os.WriteFile(path, data, perm); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return nil
+}
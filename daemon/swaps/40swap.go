@@ -53,7 +53,7 @@ func parseErr(response *http.Response) error {
 			return fmt.Errorf("failed to get swap: %d - %s: %s", response.StatusCode, response.Status, body["error"])
 		}
 
-		return fmt.Errorf("failed to get swap: %d - %s: %s", response.StatusCode, response.Status, body["message"])
+		return &RejectedError{StatusCode: response.StatusCode, Status: response.Status, Message: body["message"]}
 	}
 
 	return nil
@@ -3,6 +3,7 @@ package swaps
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -164,6 +165,13 @@ func TestClient_GetConfiguration(t *testing.T) {
 			expectError:      true,
 			expectedErrorMsg: "failed to get swap: 500",
 		},
+		{
+			name:             "rejected request returns rejected error",
+			serverResponse:   map[string]interface{}{"message": "Bad request"},
+			serverStatusCode: 400,
+			expectError:      true,
+			expectedErrorMsg: "failed to get swap: 400",
+		},
 	}
 
 	for _, tt := range tests {
@@ -205,6 +213,8 @@ func TestClient_GetConfiguration(t *testing.T) {
 				if tt.expectedErrorMsg != "" {
 					assert.Contains(t, err.Error(), tt.expectedErrorMsg)
 				}
+				var rejectedErr *RejectedError
+				assert.Equal(t, tt.serverStatusCode >= 400 && tt.serverStatusCode < 500, errors.As(err, &rejectedErr))
 				assert.Nil(t, config)
 			} else {
 				require.NoError(t, err)
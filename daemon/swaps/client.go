@@ -13,6 +13,18 @@ import (
 
 var ErrSwapNotFound = fmt.Errorf("swap not found")
 
+// RejectedError is a request the server refused with a 4xx status, it fails
+// the same way if it's sent again
+type RejectedError struct {
+	StatusCode int
+	Status     string
+	Message    any
+}
+
+func (e *RejectedError) Error() string {
+	return fmt.Sprintf("failed to get swap: %d - %s: %s", e.StatusCode, e.Status, e.Message)
+}
+
 //go:generate go tool mockgen -destination=mock.go -package=swaps . ClientInterface
 type ClientInterface interface {
 	GetConfiguration(ctx context.Context) (*ConfigurationResponse, error)
@@ -0,0 +1,243 @@
+//nolint:dupl
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"github.com/40acres/40swap/daemon/bitcoin"
+	"github.com/40acres/40swap/daemon/lightning"
+	"github.com/40acres/40swap/daemon/swaps"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/shopspring/decimal"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	swapsBackend     = "swaps"
+	lightningBackend = "lightning"
+	bitcoinBackend   = "bitcoin"
+)
+
+// end ends a span with the error a decorated call returned. It takes a pointer
+// to the error so it can be deferred.
+func end(span trace.Span, err *error) {
+	End(span, *err)
+}
+
+// swapsClient traces the calls to the swap server
+type swapsClient struct {
+	client swaps.ClientInterface
+}
+
+// NewSwapsClient wraps a swap server client to trace its calls
+func NewSwapsClient(client swaps.ClientInterface) swaps.ClientInterface {
+	return &swapsClient{client: client}
+}
+
+func (c *swapsClient) GetConfiguration(ctx context.Context) (res *swaps.ConfigurationResponse, err error) {
+	ctx, span := Start(ctx, swapsBackend+".GetConfiguration")
+	defer end(span, &err)
+
+	return c.client.GetConfiguration(ctx)
+}
+
+func (c *swapsClient) CreateSwapOut(ctx context.Context, swapReq swaps.CreateSwapOutRequest) (res *swaps.SwapOutResponse, err error) {
+	ctx, span := Start(ctx, swapsBackend+".CreateSwapOut")
+	defer end(span, &err)
+
+	res, err = c.client.CreateSwapOut(ctx, swapReq)
+	if res != nil {
+		span.SetAttributes(SwapIDKey.String(res.SwapId))
+	}
+
+	return res, err
+}
+
+func (c *swapsClient) GetSwapOut(ctx context.Context, swapId string) (res *swaps.SwapOutResponse, err error) {
+	ctx, span := Start(ctx, swapsBackend+".GetSwapOut", SwapIDKey.String(swapId))
+	defer end(span, &err)
+
+	return c.client.GetSwapOut(ctx, swapId)
+}
+
+func (c *swapsClient) GetClaimPSBT(ctx context.Context, swapId, address string) (res *swaps.GetClaimPSBTResponse, err error) {
+	ctx, span := Start(ctx, swapsBackend+".GetClaimPSBT", SwapIDKey.String(swapId))
+	defer end(span, &err)
+
+	return c.client.GetClaimPSBT(ctx, swapId, address)
+}
+
+func (c *swapsClient) PostClaim(ctx context.Context, swapId, tx string) (err error) {
+	ctx, span := Start(ctx, swapsBackend+".PostClaim", SwapIDKey.String(swapId))
+	defer end(span, &err)
+
+	return c.client.PostClaim(ctx, swapId, tx)
+}
+
+func (c *swapsClient) CreateSwapIn(ctx context.Context, req *swaps.CreateSwapInRequest) (res *swaps.SwapInResponse, err error) {
+	ctx, span := Start(ctx, swapsBackend+".CreateSwapIn")
+	defer end(span, &err)
+
+	res, err = c.client.CreateSwapIn(ctx, req)
+	if res != nil {
+		span.SetAttributes(SwapIDKey.String(res.SwapId))
+	}
+
+	return res, err
+}
+
+func (c *swapsClient) GetSwapIn(ctx context.Context, swapId string) (res *swaps.SwapInResponse, err error) {
+	ctx, span := Start(ctx, swapsBackend+".GetSwapIn", SwapIDKey.String(swapId))
+	defer end(span, &err)
+
+	return c.client.GetSwapIn(ctx, swapId)
+}
+
+func (c *swapsClient) GetRefundPSBT(ctx context.Context, swapId, address string) (res *swaps.RefundPSBTResponse, err error) {
+	ctx, span := Start(ctx, swapsBackend+".GetRefundPSBT", SwapIDKey.String(swapId))
+	defer end(span, &err)
+
+	return c.client.GetRefundPSBT(ctx, swapId, address)
+}
+
+func (c *swapsClient) PostRefund(ctx context.Context, swapId, tx string) (err error) {
+	ctx, span := Start(ctx, swapsBackend+".PostRefund", SwapIDKey.String(swapId))
+	defer end(span, &err)
+
+	return c.client.PostRefund(ctx, swapId, tx)
+}
+
+// lightningClient traces the calls to the lightning node
+type lightningClient struct {
+	client lightning.Client
+}
+
+// NewLightningClient wraps a lightning client to trace its calls
+func NewLightningClient(client lightning.Client) lightning.Client {
+	return &lightningClient{client: client}
+}
+
+func (c *lightningClient) PayInvoice(ctx context.Context, paymentRequest string, feeLimitRatio float64) (err error) {
+	ctx, span := Start(ctx, lightningBackend+".PayInvoice")
+	defer end(span, &err)
+
+	return c.client.PayInvoice(ctx, paymentRequest, feeLimitRatio)
+}
+
+func (c *lightningClient) MonitorPaymentRequest(ctx context.Context, paymentHash string) (preimage lightning.Preimage, fee lightning.NetworkFeeSats, err error) {
+	ctx, span := Start(ctx, lightningBackend+".MonitorPaymentRequest")
+	defer end(span, &err)
+
+	return c.client.MonitorPaymentRequest(ctx, paymentHash)
+}
+
+func (c *lightningClient) MonitorPaymentReception(ctx context.Context, rhash []byte) (preimage lightning.Preimage, err error) {
+	ctx, span := Start(ctx, lightningBackend+".MonitorPaymentReception")
+	defer end(span, &err)
+
+	return c.client.MonitorPaymentReception(ctx, rhash)
+}
+
+func (c *lightningClient) CancelInvoice(ctx context.Context, rhash []byte) (err error) {
+	ctx, span := Start(ctx, lightningBackend+".CancelInvoice")
+	defer end(span, &err)
+
+	return c.client.CancelInvoice(ctx, rhash)
+}
+
+func (c *lightningClient) GenerateInvoice(ctx context.Context, amountSats decimal.Decimal, expiry time.Duration, memo string) (paymentRequest string, rhash []byte, err error) {
+	ctx, span := Start(ctx, lightningBackend+".GenerateInvoice")
+	defer end(span, &err)
+
+	return c.client.GenerateInvoice(ctx, amountSats, expiry, memo)
+}
+
+func (c *lightningClient) GenerateAddress(ctx context.Context) (address string, err error) {
+	ctx, span := Start(ctx, lightningBackend+".GenerateAddress")
+	defer end(span, &err)
+
+	return c.client.GenerateAddress(ctx)
+}
+
+func (c *lightningClient) GetChannelLocalBalance(ctx context.Context) (balance decimal.Decimal, err error) {
+	ctx, span := Start(ctx, lightningBackend+".GetChannelLocalBalance")
+	defer end(span, &err)
+
+	return c.client.GetChannelLocalBalance(ctx)
+}
+
+func (c *lightningClient) GetInfo(ctx context.Context) (res *lnrpc.GetInfoResponse, err error) {
+	ctx, span := Start(ctx, lightningBackend+".GetInfo")
+	defer end(span, &err)
+
+	return c.client.GetInfo(ctx)
+}
+
+// bitcoinClient traces the calls to the bitcoin backend
+type bitcoinClient struct {
+	client bitcoin.Client
+}
+
+// NewBitcoinClient wraps a bitcoin client to trace its calls
+func NewBitcoinClient(client bitcoin.Client) bitcoin.Client {
+	return &bitcoinClient{client: client}
+}
+
+func (c *bitcoinClient) PostRefund(ctx context.Context, tx string) (err error) {
+	ctx, span := Start(ctx, bitcoinBackend+".PostRefund")
+	defer end(span, &err)
+
+	return c.client.PostRefund(ctx, tx)
+}
+
+func (c *bitcoinClient) GetTxFromOutpoint(ctx context.Context, outpoint string) (tx *wire.MsgTx, err error) {
+	ctx, span := Start(ctx, bitcoinBackend+".GetTxFromOutpoint")
+	defer end(span, &err)
+
+	return c.client.GetTxFromOutpoint(ctx, outpoint)
+}
+
+func (c *bitcoinClient) GetTxFromTxID(ctx context.Context, txID string) (tx *wire.MsgTx, err error) {
+	ctx, span := Start(ctx, bitcoinBackend+".GetTxFromTxID")
+	defer end(span, &err)
+
+	return c.client.GetTxFromTxID(ctx, txID)
+}
+
+func (c *bitcoinClient) GetTxsFromAddress(ctx context.Context, address string) (txs []*wire.MsgTx, err error) {
+	ctx, span := Start(ctx, bitcoinBackend+".GetTxsFromAddress")
+	defer end(span, &err)
+
+	return c.client.GetTxsFromAddress(ctx, address)
+}
+
+func (c *bitcoinClient) GetRecommendedFees(ctx context.Context, speed bitcoin.Speed) (fee int64, err error) {
+	ctx, span := Start(ctx, bitcoinBackend+".GetRecommendedFees")
+	defer end(span, &err)
+
+	return c.client.GetRecommendedFees(ctx, speed)
+}
+
+func (c *bitcoinClient) GetFeeFromTxId(ctx context.Context, txId string) (fee int64, err error) {
+	ctx, span := Start(ctx, bitcoinBackend+".GetFeeFromTxId")
+	defer end(span, &err)
+
+	return c.client.GetFeeFromTxId(ctx, txId)
+}
+
+func (c *bitcoinClient) GetBlockHeight(ctx context.Context) (height int64, err error) {
+	ctx, span := Start(ctx, bitcoinBackend+".GetBlockHeight")
+	defer end(span, &err)
+
+	return c.client.GetBlockHeight(ctx)
+}
+
+// SubscribeBlocks only traces setting up the subscription
+func (c *bitcoinClient) SubscribeBlocks(ctx context.Context) (blocks <-chan int64, err error) {
+	ctx, span := Start(ctx, bitcoinBackend+".SubscribeBlocks")
+	defer end(span, &err)
+
+	return c.client.SubscribeBlocks(ctx)
+}
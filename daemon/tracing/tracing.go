@@ -0,0 +1,152 @@
+// Package tracing sets up OpenTelemetry tracing for the daemon.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	serviceName = "40swapd"
+	tracerName  = "github.com/40acres/40swap/daemon"
+)
+
+// Supported exporters
+const (
+	ExporterNone   = "none"
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+	ExporterFile   = "file"
+)
+
+// SwapIDKey is the attribute holding the id of the swap a span belongs to
+const SwapIDKey = attribute.Key("swap.id")
+
+// Config holds the tracing settings of the daemon
+type Config struct {
+	// Exporter is one of none, otlp, stdout or file
+	Exporter string
+	// OTLPEndpoint is the host:port of the OTLP gRPC collector. When empty the
+	// OTEL_EXPORTER_OTLP_* environment variables are used.
+	OTLPEndpoint string
+	// OTLPInsecure disables TLS when talking to the collector
+	OTLPInsecure bool
+	// File is where spans are written with the file exporter
+	File string
+}
+
+// Enabled reports whether spans are exported at all
+func (c Config) Enabled() bool {
+	return c.Exporter != "" && c.Exporter != ExporterNone
+}
+
+// Init installs the global tracer provider. The returned function flushes the
+// pending spans and must be called before exiting.
+func Init(ctx context.Context, config Config) (func(context.Context) error, error) {
+	if !config.Enabled() {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, closer, err := newExporter(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		attribute.String("service.name", serviceName),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tracing resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if closer != nil {
+			err = errors.Join(err, closer.Close())
+		}
+
+		return err
+	}, nil
+}
+
+func newExporter(ctx context.Context, config Config) (sdktrace.SpanExporter, io.Closer, error) {
+	switch config.Exporter {
+	case ExporterOTLP:
+		options := []otlptracegrpc.Option{}
+		if config.OTLPEndpoint != "" {
+			options = append(options, otlptracegrpc.WithEndpoint(config.OTLPEndpoint))
+		}
+		if config.OTLPInsecure {
+			options = append(options, otlptracegrpc.WithInsecure())
+		}
+
+		exporter, err := otlptracegrpc.New(ctx, options...)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+		}
+
+		return exporter, nil, nil
+	case ExporterStdout:
+		exporter, err := stdouttrace.New(stdouttrace.WithPrettyPrint())
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create stdout exporter: %w", err)
+		}
+
+		return exporter, nil, nil
+	case ExporterFile:
+		if config.File == "" {
+			return nil, nil, errors.New("a file is required for the file exporter")
+		}
+
+		file, err := os.OpenFile(config.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open traces file: %w", err)
+		}
+
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+		if err != nil {
+			return nil, nil, errors.Join(fmt.Errorf("failed to create file exporter: %w", err), file.Close())
+		}
+
+		return exporter, file, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown tracing exporter %q", config.Exporter)
+	}
+}
+
+// Start creates a span named after the operation being traced
+func Start(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// End records the error, if any, and ends the span
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
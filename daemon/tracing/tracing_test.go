@@ -0,0 +1,82 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/40acres/40swap/daemon/swaps"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/mock/gomock"
+)
+
+func TestInit(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{
+			name:   "disabled",
+			config: Config{Exporter: ExporterNone},
+		},
+		{
+			name:    "unknown exporter",
+			config:  Config{Exporter: "jaeger"},
+			wantErr: true,
+		},
+		{
+			name:    "file exporter without a file",
+			config:  Config{Exporter: ExporterFile},
+			wantErr: true,
+		},
+		{
+			name:   "file exporter",
+			config: Config{Exporter: ExporterFile, File: t.TempDir() + "/traces.json"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shutdown, err := Init(ctx, tt.config)
+			if tt.wantErr {
+				require.Error(t, err)
+
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, shutdown(ctx))
+		})
+	}
+}
+
+func TestSwapsClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	ctx := context.Background()
+	mock := swaps.NewMockClientInterface(ctrl)
+	client := NewSwapsClient(mock)
+
+	mock.EXPECT().GetSwapIn(gomock.Any(), "abc").Return(nil, errors.New("server down"))
+	_, err := client.GetSwapIn(ctx, "abc")
+	require.Error(t, err)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	require.Equal(t, "swaps.GetSwapIn", spans[0].Name)
+	require.Equal(t, codes.Error, spans[0].Status.Code)
+	require.Contains(t, spans[0].Attributes, SwapIDKey.String("abc"))
+}